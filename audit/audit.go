@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// Audited actions.
+const (
+	ActionIssue         = "issue"
+	ActionRolesetChange = "roleset_change"
+	ActionUnwrap        = "unwrap"
+	// Requests refused before reaching their route, by address lists,
+	// authentication or authorization
+	ActionAccess = "access"
+)
+
+// Outcomes of an audited request.
+const (
+	OutcomeSuccess = "success"
+	OutcomeDenied  = "denied"
+	OutcomeFailure = "failure"
+)
+
+// Event records one secret access or roleset change. Roleset is the roleset,
+// account or role the request names.
+type Event struct {
+	Time       time.Time `json:"time"`
+	Action     string    `json:"action"`
+	Kind       string    `json:"kind,omitempty"`
+	Method     string    `json:"method"`
+	Route      string    `json:"route"`
+	Caller     string    `json:"caller"`
+	AuthMethod string    `json:"auth_method"`
+	Tenant     string    `json:"tenant,omitempty"`
+	Mount      string    `json:"mount,omitempty"`
+	Roleset    string    `json:"roleset,omitempty"`
+	LeaseID    string    `json:"lease_id,omitempty"`
+	Outcome    string    `json:"outcome"`
+	Status     int       `json:"status"`
+	RequestID  string    `json:"request_id,omitempty"`
+	ClientIP   string    `json:"client_ip"`
+}
+
+// Sink delivers audit events somewhere outside the request logs.
+type Sink interface {
+	Name() string
+	Write(ctx context.Context, event *Event) error
+	Close() error
+}
+
+// Logger hands audit events to its sinks in the background, so a slow sink
+// never delays requests. Events that do not fit in the buffer are written to
+// the application log instead of being lost silently. A nil Logger discards
+// events.
+type Logger struct {
+	sinks   []Sink
+	timeout time.Duration
+	logger  *logrus.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	events chan *Event
+	done   chan struct{}
+}
+
+// New creates a logger for the sinks enabled in cfg, or returns nil when
+// none is.
+func New(cfg config.AuditLogConfig, logger *logrus.Logger) (*Logger, error) {
+	var sinks []Sink
+	if cfg.File.Path != "" {
+		sink, err := newFileSink(cfg.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.Syslog.Enabled {
+		sink, err := newSyslogSink(cfg.Syslog)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if cfg.HTTP.URL != "" {
+		sinks = append(sinks, newHTTPSink(cfg.HTTP))
+	}
+
+	if len(sinks) == 0 {
+		return nil, nil
+	}
+
+	return &Logger{
+		sinks:   sinks,
+		timeout: cfg.Timeout,
+		logger:  logger,
+		events:  make(chan *Event, cfg.BufferSize),
+		done:    make(chan struct{}),
+	}, nil
+}
+
+// Log queues an event for the sinks.
+func (l *Logger) Log(event *Event) {
+	if l == nil {
+		return
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		l.logger.WithFields(logrus.Fields{
+			"audit_action":  event.Action,
+			"audit_caller":  event.Caller,
+			"audit_roleset": event.Roleset,
+			"audit_lease":   event.LeaseID,
+			"audit_outcome": event.Outcome,
+		}).Error("Audit log closed, event not delivered to audit sinks")
+		return
+	}
+
+	select {
+	case l.events <- event:
+	default:
+		l.logger.WithFields(logrus.Fields{
+			"audit_action":  event.Action,
+			"audit_caller":  event.Caller,
+			"audit_roleset": event.Roleset,
+			"audit_lease":   event.LeaseID,
+			"audit_outcome": event.Outcome,
+		}).Error("Audit buffer full, event not delivered to audit sinks")
+	}
+}
+
+// Run delivers queued events until Close is called and the queue is empty.
+func (l *Logger) Run() {
+	if l == nil {
+		return
+	}
+	defer close(l.done)
+
+	for event := range l.events {
+		for _, sink := range l.sinks {
+			ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+			if err := sink.Write(ctx, event); err != nil {
+				l.logger.WithError(err).WithFields(logrus.Fields{
+					"sink":          sink.Name(),
+					"audit_action":  event.Action,
+					"audit_caller":  event.Caller,
+					"audit_roleset": event.Roleset,
+					"audit_lease":   event.LeaseID,
+					"audit_outcome": event.Outcome,
+				}).Error("Failed to write audit event")
+			}
+			cancel()
+		}
+	}
+}
+
+// Close stops accepting events, waits until the queued ones are delivered or
+// ctx ends, and closes the sinks. The event being written when ctx ends is
+// still given the sink timeout to complete.
+func (l *Logger) Close(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	if !l.closed {
+		l.closed = true
+		close(l.events)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-l.done:
+	case <-ctx.Done():
+		if queued := len(l.events); queued > 0 {
+			return fmt.Errorf("%d audit events left undelivered: %w", queued, ctx.Err())
+		}
+		<-l.done
+	}
+
+	var firstErr error
+	for _, sink := range l.sinks {
+		if err := sink.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close %s audit sink: %w", sink.Name(), err)
+		}
+	}
+	return firstErr
+}
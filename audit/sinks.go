@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// fileSink appends events to a file as JSON lines.
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileSink(cfg config.AuditFileSinkConfig) (*fileSink, error) {
+	file, err := os.OpenFile(cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	return &fileSink{file: file}, nil
+}
+
+func (s *fileSink) Name() string { return "file" }
+
+func (s *fileSink) Write(_ context.Context, event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log file: %w", err)
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
+
+// syslogSink sends events to syslog as JSON messages.
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink(cfg config.AuditSyslogSinkConfig) (*syslogSink, error) {
+	// An empty network and address select the local syslog daemon
+	writer, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_AUTH, cfg.Tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return &syslogSink{writer: writer}, nil
+}
+
+func (s *syslogSink) Name() string { return "syslog" }
+
+func (s *syslogSink) Write(_ context.Context, event *Event) error {
+	message, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+	if err := s.writer.Info(string(message)); err != nil {
+		return fmt.Errorf("failed to write to syslog: %w", err)
+	}
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
+
+// httpSink POSTs each event as JSON to a collector.
+type httpSink struct {
+	cfg    config.AuditHTTPSinkConfig
+	client *http.Client
+}
+
+func newHTTPSink(cfg config.AuditHTTPSinkConfig) *httpSink {
+	return &httpSink{
+		cfg:    cfg,
+		client: &http.Client{},
+	}
+}
+
+func (s *httpSink) Name() string { return "http" }
+
+func (s *httpSink) Write(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build audit request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range s.cfg.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send audit event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to send audit event: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
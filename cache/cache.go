@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// Cache is implemented by every cache backend. Values are opaque bytes so
+// callers decide how to encode what they store.
+type Cache interface {
+	// Get returns the value for key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores value under key for ttl. A zero ttl never expires.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Add stores value under key only if key is not present, reporting
+	// whether it was stored.
+	Add(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// Increment adds delta to the counter under key and returns the new
+	// value. A missing counter starts at zero and expires after ttl; the
+	// ttl of an existing counter is left unchanged.
+	Increment(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+	// Invalidate removes key from the cache.
+	Invalidate(ctx context.Context, key string) error
+	// Close releases resources held by the backend.
+	Close() error
+}
+
+// New creates the cache backend selected in config.
+func New(cfg config.CacheConfig) (Cache, error) {
+	switch cfg.Backend {
+	case "", "memory":
+		return NewMemory(), nil
+	case "redis":
+		return NewRedis(cfg.Redis)
+	default:
+		return nil, fmt.Errorf("unsupported cache backend %q", cfg.Backend)
+	}
+}
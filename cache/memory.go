@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// sweepThreshold is the number of entries after which expired entries are
+// swept on write, keeping memory bounded without a background goroutine.
+const sweepThreshold = 1024
+
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// Memory is an in-process cache backend.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		entries: make(map[string]memoryEntry),
+	}
+}
+
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if entry.expired(time.Now()) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.set(key, value, ttl, time.Now())
+	return nil
+}
+
+func (m *Memory) Add(_ context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if entry, ok := m.entries[key]; ok && !entry.expired(now) {
+		return false, nil
+	}
+
+	m.set(key, value, ttl, now)
+	return true, nil
+}
+
+func (m *Memory) Increment(_ context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	entry, ok := m.entries[key]
+	if !ok || entry.expired(now) {
+		m.set(key, []byte(strconv.FormatInt(delta, 10)), ttl, now)
+		return delta, nil
+	}
+
+	value, err := strconv.ParseInt(string(entry.value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value of %s is not a counter", key)
+	}
+	value += delta
+	entry.value = []byte(strconv.FormatInt(value, 10))
+	m.entries[key] = entry
+	return value, nil
+}
+
+// set stores an entry; the caller must hold m.mu.
+func (m *Memory) set(key string, value []byte, ttl time.Duration, now time.Time) {
+	if len(m.entries) >= sweepThreshold {
+		for k, entry := range m.entries {
+			if entry.expired(now) {
+				delete(m.entries, k)
+			}
+		}
+	}
+
+	entry := memoryEntry{value: value}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+	m.entries[key] = entry
+}
+
+func (m *Memory) Invalidate(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.entries, key)
+	return nil
+}
+
+func (m *Memory) Close() error {
+	return nil
+}
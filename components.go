@@ -0,0 +1,286 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/audit"
+	"github.com/kalpesh172000/hcvapi/cache"
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/handlers"
+	"github.com/kalpesh172000/hcvapi/incident"
+	"github.com/kalpesh172000/hcvapi/lifecycle"
+	"github.com/kalpesh172000/hcvapi/reports"
+	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/kalpesh172000/hcvapi/webhook"
+)
+
+const cacheProbeKey = "health:cache"
+
+// cacheComponent closes the cache backend on shutdown. The backend connects
+// when it is created, since the Vault client needs it before anything starts.
+func cacheComponent(store cache.Cache) lifecycle.Component {
+	return &lifecycle.Hooks{
+		ComponentName: "cache",
+		OnStop: func(ctx context.Context) error {
+			return store.Close()
+		},
+		OnHealth: func(ctx context.Context) error {
+			_, _, err := store.Get(ctx, cacheProbeKey)
+			return err
+		},
+	}
+}
+
+// auditComponent delivers audit events to the configured sinks in the
+// background. Events still queued on shutdown are flushed before the sinks
+// are closed.
+func auditComponent(auditLogger *audit.Logger) lifecycle.Component {
+	return &lifecycle.Hooks{
+		ComponentName: "audit",
+		OnStart: func(ctx context.Context) error {
+			go auditLogger.Run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return auditLogger.Close(ctx)
+		},
+	}
+}
+
+// webhookComponent delivers webhook notifications in the background. Pending
+// deliveries are given until the shutdown deadline to complete.
+func webhookComponent(webhooks *webhook.Dispatcher) lifecycle.Component {
+	return &lifecycle.Hooks{
+		ComponentName: "webhooks",
+		OnStart: func(ctx context.Context) error {
+			go webhooks.Run()
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			return webhooks.Close(ctx)
+		},
+	}
+}
+
+// vaultComponent authenticates to Vault, sets up the GCP secrets engine and
+// enables the configured audit devices. Unless the startup mode is fail, it
+// retries until Vault is ready.
+func vaultComponent(cfg *config.Config, vaultClient *vault.Client, logger *logrus.Logger) lifecycle.Component {
+	start := func(ctx context.Context) error {
+		// Authenticate using the configured auth method chain
+		if err := vaultClient.Login(ctx); err != nil {
+			return fmt.Errorf("failed to authenticate to Vault: %w", err)
+		}
+
+		if err := vaultClient.Initialize(ctx); err != nil {
+			return fmt.Errorf("failed to initialize Vault GCP secrets engine: %w", err)
+		}
+
+		if err := vaultClient.InitializeAuditDevices(ctx); err != nil {
+			return fmt.Errorf("failed to enable Vault audit devices: %w", err)
+		}
+
+		if err := vaultClient.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("initial Vault health check failed: %w", err)
+		}
+		return nil
+	}
+
+	return &lifecycle.Hooks{
+		ComponentName: "vault",
+		OnStart: func(ctx context.Context) error {
+			if cfg.Vault.Startup.Mode == config.StartupFail {
+				return start(ctx)
+			}
+			return waitForVault(ctx, cfg.Vault.Startup, logger, start)
+		},
+		OnStop: func(ctx context.Context) error {
+			// Revoke our own Vault token so no orphan tokens remain
+			if !cfg.Vault.RevokeToken {
+				return nil
+			}
+			return vaultClient.RevokeSelf(ctx)
+		},
+		OnHealth: vaultClient.HealthCheck,
+	}
+}
+
+// waitForVault retries start with exponential backoff until it succeeds, the
+// startup timeout passes or ctx ends.
+func waitForVault(ctx context.Context, startup config.StartupConfig, logger *logrus.Logger, start func(ctx context.Context) error) error {
+	if startup.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, startup.Timeout)
+		defer cancel()
+	}
+
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		err := start(ctx)
+		if err == nil {
+			return nil
+		}
+
+		logger.WithError(err).WithFields(logrus.Fields{
+			"attempt":  attempt,
+			"retry_in": backoff.String(),
+		}).Warn("Vault is not ready, retrying...")
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("vault did not become ready: %w", err)
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, startup.MaxBackoff)
+	}
+}
+
+// engineComponent sets up an optional secrets engine once Vault is
+// authenticated and reports on its mount.
+func engineComponent(engine handlers.Engine) lifecycle.Component {
+	return &lifecycle.Hooks{
+		ComponentName: "engine:" + engine.Name(),
+		OnStart:       engine.Initialize,
+		OnHealth:      engine.Health,
+	}
+}
+
+// jobsComponent runs the background jobs until shutdown.
+func jobsComponent(cfg *config.Config, vaultClient *vault.Client, reportScheduler *reports.Scheduler, incidents *incident.Controller) lifecycle.Component {
+	var stopJobs context.CancelFunc
+
+	return &lifecycle.Hooks{
+		ComponentName: "jobs",
+		OnStart: func(ctx context.Context) error {
+			var jobsCtx context.Context
+			jobsCtx, stopJobs = context.WithCancel(context.Background())
+
+			if cfg.Vault.Accessors.Enabled {
+				go vaultClient.RunAccessorAudit(jobsCtx)
+			}
+
+			// Pre-fetch tokens for hot rolesets before accepting traffic
+			if len(cfg.Cache.WarmRolesets) > 0 && cfg.Cache.Tokens {
+				vaultClient.WarmTokens(ctx)
+			}
+			if len(cfg.Cache.WarmRolesets) > 0 {
+				go vaultClient.RunTokenWarmer(jobsCtx)
+			}
+
+			if cfg.Reports.Enabled {
+				go reportScheduler.Run(jobsCtx)
+			}
+			if cfg.Reports.Stale.Enabled {
+				go reportScheduler.RunStaleAnalyzer(jobsCtx)
+			}
+
+			if cfg.GCP.RootRotationPeriod > 0 {
+				go vaultClient.RunRootRotation(jobsCtx)
+			}
+
+			go incidents.Run(jobsCtx)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			stopJobs()
+			return nil
+		},
+	}
+}
+
+// serverTLSConfig loads the server certificate and, when client
+// certificates are verified, the client CA bundle. It returns nil when TLS
+// is not configured.
+func serverTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	if cfg.CertFile == "" {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	switch cfg.ClientAuth {
+	case config.ClientAuthOptional:
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	case config.ClientAuthRequired:
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return tlsConfig, nil
+	}
+
+	bundle, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA bundle: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("client CA bundle %s has no PEM certificates", cfg.ClientCAFile)
+	}
+	tlsConfig.ClientCAs = pool
+
+	return tlsConfig, nil
+}
+
+// serverComponent binds the listener on start, so address errors fail
+// startup, and drains in-flight requests on stop.
+func serverComponent(server *http.Server, logger *logrus.Logger) lifecycle.Component {
+	var (
+		mu       sync.Mutex
+		serveErr error
+	)
+
+	return &lifecycle.Hooks{
+		ComponentName: "http",
+		OnStart: func(ctx context.Context) error {
+			listener, err := net.Listen("tcp", server.Addr)
+			if err != nil {
+				return fmt.Errorf("failed to listen on %s: %w", server.Addr, err)
+			}
+
+			go func() {
+				logger.WithFields(logrus.Fields{
+					"address": server.Addr,
+					"tls":     server.TLSConfig != nil,
+				}).Info("Starting server...")
+
+				// Certificates are already loaded into the TLS config
+				serve := server.Serve
+				if server.TLSConfig != nil {
+					serve = func(l net.Listener) error { return server.ServeTLS(l, "", "") }
+				}
+				if err := serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+					logger.WithError(err).Error("Server stopped unexpectedly")
+					mu.Lock()
+					serveErr = err
+					mu.Unlock()
+				}
+			}()
+			return nil
+		},
+		OnStop: server.Shutdown,
+		OnHealth: func(ctx context.Context) error {
+			mu.Lock()
+			defer mu.Unlock()
+			return serveErr
+		},
+	}
+}
@@ -1,37 +1,621 @@
 package config
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"net/netip"
+	"path"
 	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server ServerConfig `mapstructure:"server"`
-	Vault  VaultConfig  `mapstructure:"vault"`
-	GCP    GCPConfig    `mapstructure:"gcp"`
+	Server     ServerConfig            `mapstructure:"server"`
+	Vault      VaultConfig             `mapstructure:"vault"`
+	GCP        GCPConfig               `mapstructure:"gcp"`
+	KV         KVConfig                `mapstructure:"kv"`
+	AWS        AWSConfig               `mapstructure:"aws"`
+	Database   DatabaseConfig          `mapstructure:"database"`
+	PKI        PKIConfig               `mapstructure:"pki"`
+	SSH        SSHConfig               `mapstructure:"ssh"`
+	TOTP       TOTPConfig              `mapstructure:"totp"`
+	Consul     ConsulConfig            `mapstructure:"consul"`
+	RabbitMQ   RabbitMQConfig          `mapstructure:"rabbitmq"`
+	LDAP       LDAPConfig              `mapstructure:"ldap"`
+	Nomad      NomadConfig             `mapstructure:"nomad"`
+	Kubernetes KubernetesConfig        `mapstructure:"kubernetes"`
+	Terraform  TerraformConfig         `mapstructure:"terraform"`
+	GCPKMS     GCPKMSConfig            `mapstructure:"gcpkms"`
+	Transit    TransitConfig           `mapstructure:"transit"`
+	Auth       AuthConfig              `mapstructure:"auth"`
+	RBAC       RBACConfig              `mapstructure:"rbac"`
+	AuditLog   AuditLogConfig          `mapstructure:"audit_log"`
+	Webhooks   WebhooksConfig          `mapstructure:"webhooks"`
+	Cache      CacheConfig             `mapstructure:"cache"`
+	Reports    ReportsConfig           `mapstructure:"reports"`
+	Tenants    map[string]TenantConfig `mapstructure:"tenants"`
 }
 
 type ServerConfig struct {
-	Port int    `mapstructure:"port"`
-	Host string `mapstructure:"host"`
+	Port          int               `mapstructure:"port"`
+	Host          string            `mapstructure:"host"`
+	TenantHeader  string            `mapstructure:"tenant_header"`
+	MountHeader   string            `mapstructure:"mount_header"`
+	NamespaceHeader  string                 `mapstructure:"namespace_header"`
+	SecretHeaders    map[string]string      `mapstructure:"secret_headers"`
+	ReplayProtection ReplayProtectionConfig `mapstructure:"replay_protection"`
+	TLS              TLSConfig              `mapstructure:"tls"`
+	// Proxies whose X-Forwarded-For and X-Real-IP headers are believed when
+	// determining the client address; none by default
+	TrustedProxies   []string               `mapstructure:"trusted_proxies"`
+	IPAccess         IPAccessConfig         `mapstructure:"ip_access"`
+	CORS             CORSConfig             `mapstructure:"cors"`
+	// Largest request body accepted, in bytes
+	MaxBodySize      int64                  `mapstructure:"max_body_size"`
+}
+
+// CORSConfig lets browser applications on AllowedOrigins call the API. It
+// is enabled when AllowedOrigins is set. Origins may contain "*" wildcards,
+// e.g. "https://*.example.com", or be "*" for any origin.
+type CORSConfig struct {
+	AllowedOrigins   []string      `mapstructure:"allowed_origins"`
+	AllowedMethods   []string      `mapstructure:"allowed_methods"`
+	AllowedHeaders   []string      `mapstructure:"allowed_headers"`
+	AllowCredentials bool          `mapstructure:"allow_credentials"`
+	MaxAge           time.Duration `mapstructure:"max_age"`
+}
+
+// IPAccessConfig restricts the client addresses allowed to call the API.
+// Admin requests must pass both the API and the Admin lists.
+type IPAccessConfig struct {
+	API   IPAccessListConfig `mapstructure:"api"`
+	Admin IPAccessListConfig `mapstructure:"admin"`
+}
+
+// IPAccessListConfig holds CIDR ranges or single addresses. Denied
+// addresses are always refused; when Allow is set, only addresses in it are
+// accepted.
+type IPAccessListConfig struct {
+	Allow []string `mapstructure:"allow"`
+	Deny  []string `mapstructure:"deny"`
+}
+
+// Prefixes parses the allow and deny lists. Single addresses become
+// prefixes of their full length.
+func (l IPAccessListConfig) Prefixes() (allow, deny []netip.Prefix, err error) {
+	if allow, err = parsePrefixes(l.Allow); err != nil {
+		return nil, nil, err
+	}
+	if deny, err = parsePrefixes(l.Deny); err != nil {
+		return nil, nil, err
+	}
+	return allow, deny, nil
+}
+
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if prefix, err := netip.ParsePrefix(value); err == nil {
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a CIDR range or IP address", value)
+		}
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// Client certificate modes of TLSConfig.ClientAuth
+const (
+	ClientAuthNone     = "none"
+	ClientAuthOptional = "optional"
+	ClientAuthRequired = "required"
+)
+
+// TLSConfig serves the API over TLS when CertFile is set. With ClientAuth
+// optional or required, client certificates are verified against the CA
+// bundle in ClientCAFile.
+type TLSConfig struct {
+	CertFile     string `mapstructure:"cert_file"`
+	KeyFile      string `mapstructure:"key_file"`
+	ClientCAFile string `mapstructure:"client_ca_file"`
+	ClientAuth   string `mapstructure:"client_auth"`
+}
+
+// ReplayProtectionConfig configures signed, single-use requests for
+// high-impact admin operations.
+type ReplayProtectionConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	Secret  string        `mapstructure:"secret"`
+	MaxSkew time.Duration `mapstructure:"max_skew"`
 }
 
 type VaultConfig struct {
+	Address     string               `mapstructure:"address"`
+	Token       string               `mapstructure:"token"`
+	Namespace   string               `mapstructure:"namespace"`
+	SkipVerify  bool                 `mapstructure:"skip_verify"`
+	AuthMethods []string             `mapstructure:"auth_methods"`
+	RevokeToken bool                 `mapstructure:"revoke_token_on_shutdown"`
+	MinTokenTTL time.Duration        `mapstructure:"min_token_ttl"`
+	UnsealProxy bool                 `mapstructure:"unseal_proxy"`
+	Startup     StartupConfig        `mapstructure:"startup"`
+	Kubernetes  KubernetesAuthConfig `mapstructure:"kubernetes"`
+	AppRole     AppRoleAuthConfig    `mapstructure:"approle"`
+	Accessors   AccessorAuditConfig  `mapstructure:"accessor_audit"`
+	AuditDevices map[string]AuditDeviceConfig `mapstructure:"audit_devices"`
+}
+
+// AuditDeviceConfig is a Vault audit device enabled at startup, keyed by its
+// path. Options depend on the type, e.g. file_path for file devices.
+type AuditDeviceConfig struct {
+	Type        string            `mapstructure:"type"`
+	Description string            `mapstructure:"description"`
+	Options     map[string]string `mapstructure:"options"`
+	Local       bool              `mapstructure:"local"`
+}
+
+// Startup modes for when Vault is sealed or unreachable at startup
+const (
+	StartupFail     = "fail"
+	StartupWait     = "wait"
+	StartupDegraded = "degraded"
+)
+
+// StartupConfig controls what happens when Vault is not ready at startup:
+// fail right away, wait for it, or wait while the HTTP server answers 503.
+// Waiting retries with exponential backoff up to MaxBackoff and gives up
+// after Timeout; 0 waits indefinitely.
+type StartupConfig struct {
+	Mode       string        `mapstructure:"mode"`
+	Timeout    time.Duration `mapstructure:"timeout"`
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+}
+
+// AccessorAuditConfig controls the periodic audit of token accessors created
+// through hcvapi's auth role.
+type AccessorAuditConfig struct {
+	Enabled   bool          `mapstructure:"enabled"`
+	Interval  time.Duration `mapstructure:"interval"`
+	Role      string        `mapstructure:"role"`
+	Threshold int           `mapstructure:"threshold"`
+}
+
+type KubernetesAuthConfig struct {
+	Role      string `mapstructure:"role"`
+	MountPath string `mapstructure:"mount_path"`
+	TokenPath string `mapstructure:"token_path"`
+}
+
+type AppRoleAuthConfig struct {
+	RoleID    string `mapstructure:"role_id"`
+	SecretID  string `mapstructure:"secret_id"`
+	MountPath string `mapstructure:"mount_path"`
+}
+
+// KVConfig selects the KV secrets engine proxied under /api/v1/kv. Version
+// is the KV version of the mount; v1 mounts keep no versions or metadata.
+type KVConfig struct {
+	MountPath string `mapstructure:"mount_path"`
+	Version   int    `mapstructure:"version"`
+}
+
+// AWSConfig configures the optional AWS secrets engine. Without an access
+// key Vault uses its own AWS credential chain.
+type AWSConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	MountPath  string `mapstructure:"mount_path"`
+	AccessKey  string `mapstructure:"access_key"`
+	SecretKey  string `mapstructure:"secret_key"`
+	Region     string `mapstructure:"region"`
+	DefaultTTL string `mapstructure:"default_ttl"`
+	MaxTTL     string `mapstructure:"max_ttl"`
+}
+
+// DatabaseConfig configures the optional database secrets engine. The
+// connections are written to Vault on startup, keyed by connection name.
+type DatabaseConfig struct {
+	Enabled     bool                                `mapstructure:"enabled"`
+	MountPath   string                              `mapstructure:"mount_path"`
+	Connections map[string]DatabaseConnectionConfig `mapstructure:"connections"`
+}
+
+type DatabaseConnectionConfig struct {
+	PluginName    string   `mapstructure:"plugin_name"`
+	ConnectionURL string   `mapstructure:"connection_url"`
+	Username      string   `mapstructure:"username"`
+	Password      string   `mapstructure:"password"`
+	AllowedRoles  []string `mapstructure:"allowed_roles"`
+}
+
+// PKIConfig configures the optional PKI secrets engine. RootMountPath is the
+// mount whose CA signs intermediate CSRs and defaults to MountPath.
+type PKIConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	MountPath     string `mapstructure:"mount_path"`
+	RootMountPath string `mapstructure:"root_mount_path"`
+}
+
+// SSHConfig configures the optional SSH secrets engine. With GenerateCA,
+// Vault generates a signing CA on startup if the mount has none.
+type SSHConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	MountPath  string `mapstructure:"mount_path"`
+	GenerateCA bool   `mapstructure:"generate_ca"`
+}
+
+// TOTPConfig configures the optional TOTP secrets engine.
+type TOTPConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	MountPath string `mapstructure:"mount_path"`
+}
+
+// ConsulConfig configures the optional Consul secrets engine. Without a
+// token, Vault bootstraps the Consul ACL system on startup.
+type ConsulConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	MountPath string `mapstructure:"mount_path"`
+	Address   string `mapstructure:"address"`
+	Scheme    string `mapstructure:"scheme"`
+	Token     string `mapstructure:"token"`
+}
+
+// RabbitMQConfig configures the optional RabbitMQ secrets engine. Vault
+// creates users through the management API at ConnectionURI.
+type RabbitMQConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	MountPath     string `mapstructure:"mount_path"`
+	ConnectionURI string `mapstructure:"connection_uri"`
+	Username      string `mapstructure:"username"`
+	Password      string `mapstructure:"password"`
+	DefaultTTL    string `mapstructure:"default_ttl"`
+	MaxTTL        string `mapstructure:"max_ttl"`
+}
+
+// LDAPConfig configures the optional LDAP secrets engine and the directory
+// it manages. Schema is openldap, ad or racf.
+type LDAPConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	MountPath    string `mapstructure:"mount_path"`
+	URL          string `mapstructure:"url"`
+	BindDN       string `mapstructure:"bind_dn"`
+	BindPassword string `mapstructure:"bind_password"`
+	UserDN       string `mapstructure:"user_dn"`
+	Schema       string `mapstructure:"schema"`
+	InsecureTLS  bool   `mapstructure:"insecure_tls"`
+}
+
+// NomadConfig configures the optional Nomad secrets engine. Token is a Nomad
+// management token Vault creates ACL tokens with.
+type NomadConfig struct {
+	Enabled    bool   `mapstructure:"enabled"`
+	MountPath  string `mapstructure:"mount_path"`
 	Address    string `mapstructure:"address"`
 	Token      string `mapstructure:"token"`
-	Namespace  string `mapstructure:"namespace"`
-	SkipVerify bool   `mapstructure:"skip_verify"`
+	DefaultTTL string `mapstructure:"default_ttl"`
+	MaxTTL     string `mapstructure:"max_ttl"`
+}
+
+// KubernetesConfig configures the optional Kubernetes secrets engine. Without
+// a host, Vault manages the cluster it runs in.
+type KubernetesConfig struct {
+	Enabled           bool   `mapstructure:"enabled"`
+	MountPath         string `mapstructure:"mount_path"`
+	Host              string `mapstructure:"host"`
+	CACert            string `mapstructure:"ca_cert"`
+	ServiceAccountJWT string `mapstructure:"service_account_jwt"`
+}
+
+// TerraformConfig configures the optional Terraform Cloud secrets engine.
+// Token is a Terraform Cloud API token Vault creates tokens with.
+type TerraformConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	MountPath string `mapstructure:"mount_path"`
+	Address   string `mapstructure:"address"`
+	Token     string `mapstructure:"token"`
+}
+
+// GCPKMSConfig configures the optional Google Cloud KMS secrets engine.
+// ServiceAccountPath defaults to gcp.service_account_path.
+type GCPKMSConfig struct {
+	Enabled            bool   `mapstructure:"enabled"`
+	MountPath          string `mapstructure:"mount_path"`
+	ServiceAccountPath string `mapstructure:"service_account_path"`
+}
+
+// TransitConfig configures the optional Transit secrets engine.
+type TransitConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`
+	MountPath string `mapstructure:"mount_path"`
 }
 
 type GCPConfig struct {
+	MountPath              string `mapstructure:"mount_path"`
 	ProjectID              string `mapstructure:"project_id"`
 	ServiceAccountPath     string `mapstructure:"service_account_path"`
 	DefaultTokenScopes     string `mapstructure:"default_token_scopes"`
 	DefaultTTL             string `mapstructure:"default_ttl"`
 	MaxTTL                 string `mapstructure:"max_ttl"`
 	DisableAutomatedRotation bool `mapstructure:"disable_automated_rotation"`
+	// Cron schedule and window for Vault's own root credential rotation
+	RotationSchedule       string `mapstructure:"rotation_schedule"`
+	RotationWindow         string `mapstructure:"rotation_window"`
+	// Rotate the credentials Vault holds for GCP on this period; 0 disables it
+	RootRotationPeriod     time.Duration `mapstructure:"root_rotation_period"`
+	// Additional GCP secrets engine mounts, keyed by mount path
+	Mounts                 map[string]GCPMountConfig `mapstructure:"mounts"`
+	RolesetDefaults        RolesetDefaultsConfig     `mapstructure:"roleset_defaults"`
+	// Service account key quotas per caller, keyed by roleset name; "*"
+	// applies to rolesets without their own entry
+	KeyQuotas              map[string]KeyQuotaConfig `mapstructure:"key_quotas"`
+}
+
+// KeyQuotaConfig allows each caller Limit service account keys of a roleset
+// per Window.
+type KeyQuotaConfig struct {
+	Limit  int           `mapstructure:"limit"`
+	Window time.Duration `mapstructure:"window"`
+}
+
+// RolesetDefaultsConfig holds values filled into roleset creation requests
+// that omit them.
+type RolesetDefaultsConfig struct {
+	// TTLs keyed by secret_type
+	SecretTypes   map[string]RolesetTTLDefaults `mapstructure:"secret_types"`
+	// Token scopes keyed by GCP project, for access_token rolesets
+	ProjectScopes map[string]string `mapstructure:"project_scopes"`
+}
+
+type RolesetTTLDefaults struct {
+	TTL    string `mapstructure:"ttl"`
+	MaxTTL string `mapstructure:"max_ttl"`
+}
+
+// GCPMountConfig configures an additional GCP secrets engine mount, e.g. one
+// per GCP organization. Unset fields fall back to the top-level gcp settings.
+type GCPMountConfig struct {
+	ServiceAccountPath string `mapstructure:"service_account_path"`
+	DefaultTokenScopes string `mapstructure:"default_token_scopes"`
+	DefaultTTL         string `mapstructure:"default_ttl"`
+	MaxTTL             string `mapstructure:"max_ttl"`
+}
+
+// AuthConfig controls how API consumers are identified.
+type AuthConfig struct {
+	Required bool             `mapstructure:"required"`
+	APIKeys  APIKeyAuthConfig `mapstructure:"api_keys"`
+	JWT      JWTAuthConfig    `mapstructure:"jwt"`
+	OIDC     OIDCAuthConfig   `mapstructure:"oidc"`
+	// ClientCerts maps verified client certificates to callers
+	ClientCerts ClientCertAuthConfig `mapstructure:"client_certs"`
+}
+
+// Configured reports whether any authentication method is configured: API
+// keys, JWT, OIDC, or client certificates verified by the server's TLS.
+func (a AuthConfig) Configured(tls TLSConfig) bool {
+	return len(a.APIKeys.Keys) > 0 ||
+		a.JWT.JWKSURL != "" ||
+		a.OIDC.IssuerURL != "" ||
+		(tls.CertFile != "" && tls.ClientAuth != ClientAuthNone)
+}
+
+// AuditLogConfig sends a record of every secret issuance and roleset change
+// to dedicated sinks, separate from the request logs. Each sink is enabled
+// on its own.
+type AuditLogConfig struct {
+	File       AuditFileSinkConfig   `mapstructure:"file"`
+	Syslog     AuditSyslogSinkConfig `mapstructure:"syslog"`
+	HTTP       AuditHTTPSinkConfig   `mapstructure:"http"`
+	// Events queued for the sinks before new ones are dropped
+	BufferSize int                   `mapstructure:"buffer_size"`
+	// How long a sink may take to write one event
+	Timeout    time.Duration         `mapstructure:"timeout"`
+}
+
+// AuditFileSinkConfig appends events as JSON lines to Path.
+type AuditFileSinkConfig struct {
+	Path string `mapstructure:"path"`
+}
+
+// AuditSyslogSinkConfig sends events to syslog; an empty Network and
+// Address select the local daemon.
+type AuditSyslogSinkConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Network string `mapstructure:"network"`
+	Address string `mapstructure:"address"`
+	Tag     string `mapstructure:"tag"`
+}
+
+// AuditHTTPSinkConfig POSTs each event as JSON to URL with Headers.
+type AuditHTTPSinkConfig struct {
+	URL     string            `mapstructure:"url"`
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// WebhooksConfig POSTs roleset and key issuance events to the configured
+// endpoints, signed with each endpoint's secret. Failed deliveries are
+// retried with exponential backoff up to MaxAttempts times, then logged as
+// dead letters.
+type WebhooksConfig struct {
+	Endpoints      map[string]WebhookEndpointConfig `mapstructure:"endpoints"`
+	MaxAttempts    int                              `mapstructure:"max_attempts"`
+	InitialBackoff time.Duration                    `mapstructure:"initial_backoff"`
+	MaxBackoff     time.Duration                    `mapstructure:"max_backoff"`
+	// How long one delivery attempt may take
+	Timeout        time.Duration                    `mapstructure:"timeout"`
+	// Deliveries queued before new ones are dropped
+	BufferSize     int                              `mapstructure:"buffer_size"`
+	// Deliveries made at once, including those waiting to retry
+	Workers        int                              `mapstructure:"workers"`
+}
+
+// WebhookEndpointConfig receives the listed events, or all of them when
+// Events is empty. Secret keys the HMAC-SHA256 signature of each delivery.
+type WebhookEndpointConfig struct {
+	URL    string   `mapstructure:"url"`
+	Secret string   `mapstructure:"secret"`
+	Events []string `mapstructure:"events"`
+}
+
+// RBACConfig restricts /api/v1 routes to the permissions granted by roles.
+// It is enforced when Enabled is set; callers without a role are refused.
+type RBACConfig struct {
+	Enabled bool                  `mapstructure:"enabled"`
+	Roles   map[string]RoleConfig `mapstructure:"roles"`
+}
+
+// RoleConfig grants permissions to the callers listed by ID and to members
+// of the listed groups. A permission is "<resource>:<action>", where the
+// resource is the first path segment of a route after /api/v1 (e.g. tokens,
+// rolesets, admin) and the action is read or write; either may be "*".
+// Rolesets, when set, limits the role's roleset, token and key routes to
+// rolesets matching one of the patterns, e.g. "team-a-*"; Mounts and
+// Namespaces limit them to matching GCP mounts and Vault namespaces. Roles
+// scoped to rolesets or mounts but not namespaces only apply in the
+// configured namespace.
+type RoleConfig struct {
+	Permissions []string `mapstructure:"permissions"`
+	Callers     []string `mapstructure:"callers"`
+	Groups      []string `mapstructure:"groups"`
+	Rolesets    []string `mapstructure:"rolesets"`
+	Mounts      []string `mapstructure:"mounts"`
+	Namespaces  []string `mapstructure:"namespaces"`
+}
+
+// ClientCertAuthConfig identifies callers by their verified TLS client
+// certificate. The certificate's common name, or else its first DNS, URI or
+// email SAN, is the caller name looked up in Identities. With
+// RequireMapping, certificates whose name is not listed are rejected.
+type ClientCertAuthConfig struct {
+	Identities     map[string]ClientCertIdentity `mapstructure:"identities"`
+	RequireMapping bool                          `mapstructure:"require_mapping"`
+}
+
+// ClientCertIdentity is the tenant, groups and log metadata of a client
+// certificate's caller.
+type ClientCertIdentity struct {
+	Tenant   string            `mapstructure:"tenant"`
+	Groups   []string          `mapstructure:"groups"`
+	Metadata map[string]string `mapstructure:"metadata"`
+}
+
+// OIDCAuthConfig lets human operators log in through an OpenID Connect
+// provider with the authorization code flow. Logged-in callers are
+// identified by a session cookie signed with SessionSecret. It is enabled
+// when IssuerURL is set.
+type OIDCAuthConfig struct {
+	IssuerURL     string        `mapstructure:"issuer_url"`
+	ClientID      string        `mapstructure:"client_id"`
+	ClientSecret  string        `mapstructure:"client_secret"`
+	RedirectURL   string        `mapstructure:"redirect_url"`
+	Scopes        []string      `mapstructure:"scopes"`
+	SessionSecret string        `mapstructure:"session_secret"`
+	SessionTTL    time.Duration `mapstructure:"session_ttl"`
+	JWKSRefresh   time.Duration `mapstructure:"jwks_refresh"`
+	Leeway        time.Duration `mapstructure:"leeway"`
+	TenantClaim   string        `mapstructure:"tenant_claim"`
+	GroupsClaim   string        `mapstructure:"groups_claim"`
+}
+
+// JWTAuthConfig identifies callers by bearer JWTs signed with a key from
+// JWKSURL. It is enabled when JWKSURL is set.
+type JWTAuthConfig struct {
+	Issuer      string        `mapstructure:"issuer"`
+	Audience    string        `mapstructure:"audience"`
+	JWKSURL     string        `mapstructure:"jwks_url"`
+	JWKSRefresh time.Duration `mapstructure:"jwks_refresh"`
+	Leeway      time.Duration `mapstructure:"leeway"`
+	TenantClaim string        `mapstructure:"tenant_claim"`
+	GroupsClaim string        `mapstructure:"groups_claim"`
+}
+
+// APIKeyAuthConfig identifies callers by the API key in Header. Keys are
+// keyed by the caller name they resolve to.
+type APIKeyAuthConfig struct {
+	Header string                  `mapstructure:"header"`
+	Keys   map[string]APIKeyConfig `mapstructure:"keys"`
+}
+
+// APIKeyConfig is an API key, stored as the hex SHA-256 hash of the key so
+// the configuration never holds the key itself. Metadata is added to the
+// log lines of the caller's requests.
+type APIKeyConfig struct {
+	Hash     string            `mapstructure:"hash"`
+	Tenant   string            `mapstructure:"tenant"`
+	Groups   []string          `mapstructure:"groups"`
+	Metadata map[string]string `mapstructure:"metadata"`
+}
+
+type CacheConfig struct {
+	Backend            string        `mapstructure:"backend"`
+	Redis              RedisConfig   `mapstructure:"redis"`
+	Tokens             bool          `mapstructure:"tokens"`
+	TokenRefreshMargin time.Duration `mapstructure:"token_refresh_margin"`
+	HealthTTL          time.Duration `mapstructure:"health_ttl"`
+	WarmRolesets       []string      `mapstructure:"warm_rolesets"`
+	WarmInterval       time.Duration `mapstructure:"warm_interval"`
+}
+
+type RedisConfig struct {
+	Address   string `mapstructure:"address"`
+	Password  string `mapstructure:"password"`
+	DB        int    `mapstructure:"db"`
+	KeyPrefix string `mapstructure:"key_prefix"`
+}
+
+// ReportsConfig controls scheduled usage reports and where they are exported.
+type ReportsConfig struct {
+	Enabled  bool                `mapstructure:"enabled"`
+	Interval time.Duration       `mapstructure:"interval"`
+	Format   string              `mapstructure:"format"`
+	GCS      GCSExportConfig     `mapstructure:"gcs"`
+	Email    EmailExportConfig   `mapstructure:"email"`
+	Stale    StaleRolesetsConfig `mapstructure:"stale_rolesets"`
+}
+
+// StaleRolesetsConfig configures the detection of rolesets nothing has been
+// issued from for a while.
+type StaleRolesetsConfig struct {
+	// After is how long a roleset may go without issuance before it is stale
+	After time.Duration `mapstructure:"after"`
+	// Enabled runs the analyzer every Interval, notifying WebhookURL about
+	// newly stale rolesets
+	Enabled    bool          `mapstructure:"enabled"`
+	Interval   time.Duration `mapstructure:"interval"`
+	WebhookURL string        `mapstructure:"webhook_url"`
+	// ArchivePath is the KV path archived rolesets are saved under
+	ArchivePath string `mapstructure:"archive_path"`
+}
+
+type GCSExportConfig struct {
+	Bucket  string `mapstructure:"bucket"`
+	Prefix  string `mapstructure:"prefix"`
+	Roleset string `mapstructure:"roleset"`
+}
+
+type EmailExportConfig struct {
+	SMTPHost string   `mapstructure:"smtp_host"`
+	SMTPPort int      `mapstructure:"smtp_port"`
+	Username string   `mapstructure:"username"`
+	Password string   `mapstructure:"password"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"`
+}
+
+// TenantConfig holds the guardrails applied to requests made on behalf of a tenant.
+// NotificationChannels name the webhook endpoints receiving the tenant's
+// events; endpoints named by a tenant receive no other tenant's events.
+type TenantConfig struct {
+	MaxTTL               string   `mapstructure:"max_ttl"`
+	AllowedProjects      []string `mapstructure:"allowed_projects"`
+	TokenScopes          string   `mapstructure:"token_scopes"`
+	NotificationChannels []string `mapstructure:"notification_channels"`
 }
 
 func Load() (*Config, error) {
@@ -42,6 +626,7 @@ func Load() (*Config, error) {
 
 	// Set defaults
 	setDefaults()
+	bindEnvs()
 
 	// Allow environment variable overrides
 	viper.AutomaticEnv()
@@ -60,6 +645,175 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if config.Server.ReplayProtection.Enabled && config.Server.ReplayProtection.Secret == "" {
+		return nil, fmt.Errorf("server.replay_protection.secret is required when replay protection is enabled")
+	}
+
+	if _, _, err := config.Server.IPAccess.API.Prefixes(); err != nil {
+		return nil, fmt.Errorf("server.ip_access.api: %w", err)
+	}
+	if _, _, err := config.Server.IPAccess.Admin.Prefixes(); err != nil {
+		return nil, fmt.Errorf("server.ip_access.admin: %w", err)
+	}
+
+	if config.Server.MaxBodySize <= 0 {
+		return nil, fmt.Errorf("server.max_body_size must be positive")
+	}
+
+	for _, origin := range config.Server.CORS.AllowedOrigins {
+		if _, err := path.Match(origin, ""); err != nil {
+			return nil, fmt.Errorf("server.cors.allowed_origins: invalid origin %q", origin)
+		}
+		if origin == "*" && config.Server.CORS.AllowCredentials {
+			return nil, fmt.Errorf("server.cors.allowed_origins cannot be \"*\" when credentials are allowed")
+		}
+	}
+
+	for roleset, quota := range config.GCP.KeyQuotas {
+		if quota.Limit < 1 || quota.Window <= 0 {
+			return nil, fmt.Errorf("gcp.key_quotas.%s needs a positive limit and window", roleset)
+		}
+	}
+
+	if config.AuditLog.BufferSize < 0 || config.AuditLog.Timeout <= 0 {
+		return nil, fmt.Errorf("audit_log needs a non-negative buffer_size and a positive timeout")
+	}
+
+	for name, endpoint := range config.Webhooks.Endpoints {
+		if endpoint.URL == "" || endpoint.Secret == "" {
+			return nil, fmt.Errorf("webhooks.endpoints.%s needs a url and a secret", name)
+		}
+	}
+	for name, tenant := range config.Tenants {
+		for _, channel := range tenant.NotificationChannels {
+			if _, ok := config.Webhooks.Endpoints[channel]; !ok {
+				return nil, fmt.Errorf("tenants.%s.notification_channels names unknown webhook endpoint %q", name, channel)
+			}
+		}
+	}
+	if config.Webhooks.MaxAttempts < 1 || config.Webhooks.InitialBackoff <= 0 || config.Webhooks.MaxBackoff < config.Webhooks.InitialBackoff ||
+		config.Webhooks.Timeout <= 0 || config.Webhooks.BufferSize < 0 || config.Webhooks.Workers < 1 {
+		return nil, fmt.Errorf("webhooks needs a positive max_attempts, initial_backoff, timeout and workers, a max_backoff of at least initial_backoff and a non-negative buffer_size")
+	}
+
+	for name, role := range config.RBAC.Roles {
+		for _, permission := range role.Permissions {
+			resource, action, ok := strings.Cut(permission, ":")
+			if !ok || resource == "" || (action != "read" && action != "write" && action != "*") {
+				return nil, fmt.Errorf("rbac.roles.%s.permissions: %q must be <resource>:read, <resource>:write or <resource>:*", name, permission)
+			}
+		}
+		for field, patterns := range map[string][]string{"rolesets": role.Rolesets, "mounts": role.Mounts, "namespaces": role.Namespaces} {
+			for _, pattern := range patterns {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return nil, fmt.Errorf("rbac.roles.%s.%s: invalid pattern %q", name, field, pattern)
+				}
+			}
+		}
+	}
+
+	if tls := config.Server.TLS; tls.CertFile != "" || tls.KeyFile != "" {
+		if tls.CertFile == "" || tls.KeyFile == "" {
+			return nil, fmt.Errorf("server.tls.cert_file and server.tls.key_file must be set together")
+		}
+		switch tls.ClientAuth {
+		case ClientAuthNone:
+		case ClientAuthOptional, ClientAuthRequired:
+			if tls.ClientCAFile == "" {
+				return nil, fmt.Errorf("server.tls.client_ca_file is required to verify client certificates")
+			}
+		default:
+			return nil, fmt.Errorf("server.tls.client_auth must be none, optional or required")
+		}
+	}
+
+	switch config.Vault.Startup.Mode {
+	case StartupFail, StartupWait, StartupDegraded:
+	default:
+		return nil, fmt.Errorf("vault.startup.mode must be fail, wait or degraded")
+	}
+	if config.Vault.Startup.Mode != StartupFail && config.Vault.Startup.MaxBackoff <= 0 {
+		return nil, fmt.Errorf("vault.startup.max_backoff must be positive")
+	}
+
+	for name, key := range config.Auth.APIKeys.Keys {
+		if hash, err := hex.DecodeString(key.Hash); err != nil || len(hash) != sha256.Size {
+			return nil, fmt.Errorf("auth.api_keys.keys.%s.hash must be a hex SHA-256 hash", name)
+		}
+	}
+
+	// Credentials protect nothing while callers without any get through, so
+	// configuring an authentication method makes authentication required
+	// unless set explicitly
+	if !viper.IsSet("auth.required") && config.Auth.Configured(config.Server.TLS) {
+		config.Auth.Required = true
+	}
+
+	if config.Auth.JWT.JWKSURL != "" && (config.Auth.JWT.Issuer == "" || config.Auth.JWT.Audience == "") {
+		return nil, fmt.Errorf("auth.jwt.issuer and auth.jwt.audience are required when auth.jwt.jwks_url is set")
+	}
+
+	if oidc := config.Auth.OIDC; oidc.IssuerURL != "" {
+		if oidc.ClientID == "" || oidc.RedirectURL == "" {
+			return nil, fmt.Errorf("auth.oidc.client_id and auth.oidc.redirect_url are required when auth.oidc.issuer_url is set")
+		}
+		if len(oidc.SessionSecret) < 32 {
+			return nil, fmt.Errorf("auth.oidc.session_secret must be at least 32 characters")
+		}
+		if oidc.SessionTTL <= 0 {
+			return nil, fmt.Errorf("auth.oidc.session_ttl must be positive")
+		}
+	}
+
+	for path, device := range config.Vault.AuditDevices {
+		switch device.Type {
+		case "file", "syslog", "socket":
+		default:
+			return nil, fmt.Errorf("vault.audit_devices.%s.type must be file, syslog or socket", path)
+		}
+	}
+
+	// Unseal key shares are only accepted on signed admin requests
+	if config.Vault.UnsealProxy && !config.Server.ReplayProtection.Enabled {
+		return nil, fmt.Errorf("vault.unseal_proxy requires server.replay_protection.enabled")
+	}
+
+	if config.KV.Version != 1 && config.KV.Version != 2 {
+		return nil, fmt.Errorf("kv.version must be 1 or 2")
+	}
+
+	if config.AWS.AccessKey != "" && config.AWS.SecretKey == "" {
+		return nil, fmt.Errorf("aws.secret_key is required when aws.access_key is set")
+	}
+
+	if config.Consul.Scheme != "http" && config.Consul.Scheme != "https" {
+		return nil, fmt.Errorf("consul.scheme must be http or https")
+	}
+
+	if config.RabbitMQ.Enabled && (config.RabbitMQ.Username == "" || config.RabbitMQ.Password == "") {
+		return nil, fmt.Errorf("rabbitmq.username and rabbitmq.password are required when rabbitmq is enabled")
+	}
+
+	if config.LDAP.Enabled && (config.LDAP.URL == "" || config.LDAP.BindDN == "" || config.LDAP.BindPassword == "") {
+		return nil, fmt.Errorf("ldap.url, ldap.bind_dn and ldap.bind_password are required when ldap is enabled")
+	}
+
+	if config.Nomad.Enabled && config.Nomad.Token == "" {
+		return nil, fmt.Errorf("nomad.token is required when nomad is enabled")
+	}
+
+	if config.Terraform.Enabled && config.Terraform.Token == "" {
+		return nil, fmt.Errorf("terraform.token is required when terraform is enabled")
+	}
+
+	if config.PKI.RootMountPath == "" {
+		config.PKI.RootMountPath = config.PKI.MountPath
+	}
+
+	if config.GCPKMS.ServiceAccountPath == "" {
+		config.GCPKMS.ServiceAccountPath = config.GCP.ServiceAccountPath
+	}
+
 	return &config, nil
 }
 
@@ -67,14 +821,239 @@ func setDefaults() {
 	// Server defaults
 	viper.SetDefault("server.port", 8080)
 	viper.SetDefault("server.host", "0.0.0.0")
+	viper.SetDefault("server.tenant_header", "X-Tenant-ID")
+	viper.SetDefault("server.mount_header", "X-GCP-Mount")
+	viper.SetDefault("server.namespace_header", "X-Vault-Namespace")
+	viper.SetDefault("server.replay_protection.enabled", false)
+	viper.SetDefault("server.replay_protection.max_skew", "5m")
+	viper.SetDefault("server.tls.client_auth", ClientAuthNone)
+	viper.SetDefault("server.max_body_size", 1<<20)
+	viper.SetDefault("server.cors.allowed_methods", []string{"GET", "POST", "PUT", "PATCH", "DELETE"})
+	viper.SetDefault("server.cors.allowed_headers", []string{"Authorization", "Content-Type", "X-Request-ID"})
+	viper.SetDefault("server.cors.allow_credentials", false)
+	viper.SetDefault("server.cors.max_age", "10m")
 
 	// Vault defaults
 	viper.SetDefault("vault.address", "http://127.0.0.1:8200")
 	viper.SetDefault("vault.skip_verify", false)
+	viper.SetDefault("vault.auth_methods", []string{"token"})
+	viper.SetDefault("vault.revoke_token_on_shutdown", false)
+	viper.SetDefault("vault.min_token_ttl", "5m")
+	viper.SetDefault("vault.unseal_proxy", false)
+	viper.SetDefault("vault.startup.mode", "fail")
+	viper.SetDefault("vault.startup.timeout", "10m")
+	viper.SetDefault("vault.startup.max_backoff", "30s")
+	viper.SetDefault("vault.kubernetes.mount_path", "kubernetes")
+	viper.SetDefault("vault.kubernetes.token_path", "/var/run/secrets/kubernetes.io/serviceaccount/token")
+	viper.SetDefault("vault.approle.mount_path", "approle")
+	viper.SetDefault("vault.accessor_audit.enabled", false)
+	viper.SetDefault("vault.accessor_audit.interval", "15m")
+	viper.SetDefault("vault.accessor_audit.threshold", 100)
+
+	// Auth defaults
+	viper.SetDefault("auth.api_keys.header", "X-API-Key")
+	viper.SetDefault("auth.jwt.jwks_refresh", "1h")
+	viper.SetDefault("auth.jwt.leeway", "1m")
+	viper.SetDefault("auth.jwt.tenant_claim", "tenant")
+	viper.SetDefault("auth.jwt.groups_claim", "groups")
+	viper.SetDefault("auth.client_certs.require_mapping", false)
+	viper.SetDefault("rbac.enabled", false)
+	viper.SetDefault("audit_log.syslog.enabled", false)
+	viper.SetDefault("audit_log.syslog.tag", "hcvapi-audit")
+	viper.SetDefault("audit_log.buffer_size", 1024)
+	viper.SetDefault("audit_log.timeout", "10s")
+	viper.SetDefault("webhooks.max_attempts", 5)
+	viper.SetDefault("webhooks.initial_backoff", "1s")
+	viper.SetDefault("webhooks.max_backoff", "1m")
+	viper.SetDefault("webhooks.timeout", "10s")
+	viper.SetDefault("webhooks.buffer_size", 1024)
+	viper.SetDefault("webhooks.workers", 8)
+	viper.SetDefault("auth.oidc.scopes", []string{"profile", "email"})
+	viper.SetDefault("auth.oidc.session_ttl", "8h")
+	viper.SetDefault("auth.oidc.jwks_refresh", "1h")
+	viper.SetDefault("auth.oidc.leeway", "1m")
+	viper.SetDefault("auth.oidc.tenant_claim", "tenant")
+	viper.SetDefault("auth.oidc.groups_claim", "groups")
+
+	// Cache defaults
+	viper.SetDefault("cache.backend", "memory")
+	viper.SetDefault("cache.redis.key_prefix", "hcvapi:")
+	viper.SetDefault("cache.tokens", false)
+	viper.SetDefault("cache.token_refresh_margin", "5m")
+	viper.SetDefault("cache.health_ttl", "5s")
+	viper.SetDefault("cache.warm_rolesets", []string{})
+	viper.SetDefault("cache.warm_interval", "1m")
+
+	// Reports defaults
+	viper.SetDefault("reports.enabled", false)
+	viper.SetDefault("reports.interval", "720h")
+	viper.SetDefault("reports.format", "json")
+	viper.SetDefault("reports.gcs.prefix", "hcvapi-reports/")
+	viper.SetDefault("reports.email.smtp_port", 587)
+	viper.SetDefault("reports.stale_rolesets.after", "720h")
+	viper.SetDefault("reports.stale_rolesets.enabled", false)
+	viper.SetDefault("reports.stale_rolesets.interval", "24h")
+	viper.SetDefault("reports.stale_rolesets.archive_path", "hcvapi/archived-rolesets")
 
 	// GCP defaults
+	viper.SetDefault("gcp.mount_path", "gcp")
 	viper.SetDefault("gcp.default_token_scopes", "https://www.googleapis.com/auth/cloud-platform")
 	viper.SetDefault("gcp.default_ttl", "3600s")
 	viper.SetDefault("gcp.max_ttl", "7200s")
 	viper.SetDefault("gcp.disable_automated_rotation", false)
+	viper.SetDefault("gcp.root_rotation_period", "0s")
+
+	// KV defaults
+	viper.SetDefault("kv.mount_path", "secret")
+	viper.SetDefault("kv.version", 2)
+
+	// AWS defaults
+	viper.SetDefault("aws.enabled", false)
+	viper.SetDefault("aws.mount_path", "aws")
+	viper.SetDefault("aws.region", "us-east-1")
+	viper.SetDefault("aws.default_ttl", "3600s")
+	viper.SetDefault("aws.max_ttl", "7200s")
+
+	// Database defaults
+	viper.SetDefault("database.enabled", false)
+	viper.SetDefault("database.mount_path", "database")
+
+	// PKI defaults
+	viper.SetDefault("pki.enabled", false)
+	viper.SetDefault("pki.mount_path", "pki")
+
+	// SSH defaults
+	viper.SetDefault("ssh.enabled", false)
+	viper.SetDefault("ssh.mount_path", "ssh")
+	viper.SetDefault("ssh.generate_ca", false)
+
+	// TOTP defaults
+	viper.SetDefault("totp.enabled", false)
+	viper.SetDefault("totp.mount_path", "totp")
+
+	// Consul defaults
+	viper.SetDefault("consul.enabled", false)
+	viper.SetDefault("consul.mount_path", "consul")
+	viper.SetDefault("consul.address", "127.0.0.1:8500")
+	viper.SetDefault("consul.scheme", "http")
+
+	// RabbitMQ defaults
+	viper.SetDefault("rabbitmq.enabled", false)
+	viper.SetDefault("rabbitmq.mount_path", "rabbitmq")
+	viper.SetDefault("rabbitmq.connection_uri", "http://localhost:15672")
+	viper.SetDefault("rabbitmq.default_ttl", "3600s")
+	viper.SetDefault("rabbitmq.max_ttl", "86400s")
+
+	// LDAP defaults
+	viper.SetDefault("ldap.enabled", false)
+	viper.SetDefault("ldap.mount_path", "ldap")
+	viper.SetDefault("ldap.schema", "openldap")
+	viper.SetDefault("ldap.insecure_tls", false)
+
+	// Nomad defaults
+	viper.SetDefault("nomad.enabled", false)
+	viper.SetDefault("nomad.mount_path", "nomad")
+	viper.SetDefault("nomad.address", "http://127.0.0.1:4646")
+	viper.SetDefault("nomad.default_ttl", "3600s")
+	viper.SetDefault("nomad.max_ttl", "86400s")
+
+	// Kubernetes defaults
+	viper.SetDefault("kubernetes.enabled", false)
+	viper.SetDefault("kubernetes.mount_path", "kubernetes")
+
+	// Terraform Cloud defaults
+	viper.SetDefault("terraform.enabled", false)
+	viper.SetDefault("terraform.mount_path", "terraform")
+	viper.SetDefault("terraform.address", "https://app.terraform.io")
+
+	// GCP KMS defaults
+	viper.SetDefault("gcpkms.enabled", false)
+	viper.SetDefault("gcpkms.mount_path", "gcpkms")
+
+	// Transit defaults
+	viper.SetDefault("transit.enabled", false)
+	viper.SetDefault("transit.mount_path", "transit")
+}
+
+// bindEnvs binds the environment variables of keys without a default.
+// AutomaticEnv only applies to keys viper already knows, so without this
+// e.g. SERVER_REPLAY_PROTECTION_SECRET would be ignored.
+func bindEnvs() {
+	keys := []string{
+		// Server
+		"server.replay_protection.secret",
+		"server.tls.cert_file",
+		"server.tls.key_file",
+		"server.tls.client_ca_file",
+		"server.trusted_proxies",
+
+		// Vault
+		"vault.token",
+		"vault.namespace",
+		"vault.kubernetes.role",
+		"vault.approle.role_id",
+		"vault.approle.secret_id",
+		"vault.accessor_audit.role",
+
+		// Auth; auth.required has no default as it depends on the
+		// configured authentication methods
+		"auth.required",
+		"auth.jwt.issuer",
+		"auth.jwt.audience",
+		"auth.jwt.jwks_url",
+		"auth.oidc.issuer_url",
+		"auth.oidc.client_id",
+		"auth.oidc.client_secret",
+		"auth.oidc.redirect_url",
+		"auth.oidc.session_secret",
+
+		// Audit log
+		"audit_log.file.path",
+		"audit_log.syslog.network",
+		"audit_log.syslog.address",
+		"audit_log.http.url",
+
+		// Cache
+		"cache.redis.address",
+		"cache.redis.password",
+		"cache.redis.db",
+
+		// Reports
+		"reports.gcs.bucket",
+		"reports.gcs.roleset",
+		"reports.email.smtp_host",
+		"reports.email.username",
+		"reports.email.password",
+		"reports.email.from",
+		"reports.email.to",
+		"reports.stale_rolesets.webhook_url",
+
+		// GCP
+		"gcp.project_id",
+		"gcp.service_account_path",
+		"gcp.rotation_schedule",
+		"gcp.rotation_window",
+
+		// Optional secrets engines
+		"aws.access_key",
+		"aws.secret_key",
+		"consul.token",
+		"pki.root_mount_path",
+		"rabbitmq.username",
+		"rabbitmq.password",
+		"ldap.url",
+		"ldap.bind_dn",
+		"ldap.bind_password",
+		"ldap.user_dn",
+		"nomad.token",
+		"kubernetes.host",
+		"kubernetes.ca_cert",
+		"kubernetes.service_account_jwt",
+		"terraform.token",
+		"gcpkms.service_account_path",
+	}
+	for _, key := range keys {
+		// BindEnv only fails without a key
+		_ = viper.BindEnv(key)
+	}
 }
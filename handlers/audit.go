@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kalpesh172000/hcvapi/audit"
+	"github.com/kalpesh172000/hcvapi/identity"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+const (
+	auditKindContextKey    = "audit_kind"
+	auditLeaseContextKey   = "audit_lease_id"
+	auditRolesetContextKey = "audit_roleset"
+	auditedContextKey      = "audited"
+)
+
+// Middleware recording the route's request in the audit log once the
+// handler has answered, whether it succeeded or not. Handlers of issuance
+// routes add the kind and lease of the issued secret through recordIssuance.
+func (h *Handler) AuditMiddleware(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(auditedContextKey, true)
+		c.Next()
+
+		h.audit.Log(h.auditEvent(c, action))
+	}
+}
+
+// Middleware recording requests denied before reaching their route's
+// AuditMiddleware, by the address lists, authentication, authorization or
+// roleset scopes, in the audit log with the access action. It must come
+// before the middleware it records.
+func (h *Handler) AuditDeniedMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if c.GetBool(auditedContextKey) || auditOutcome(c.Writer.Status()) != audit.OutcomeDenied {
+			return
+		}
+		h.audit.Log(h.auditEvent(c, audit.ActionAccess))
+	}
+}
+
+// auditEvent describes the answered request for the audit log.
+func (h *Handler) auditEvent(c *gin.Context, action string) *audit.Event {
+	caller := identity.FromContext(c)
+	event := &audit.Event{
+		Time:       time.Now().UTC(),
+		Action:     action,
+		Kind:       c.GetString(auditKindContextKey),
+		Method:     c.Request.Method,
+		Route:      c.FullPath(),
+		Caller:     caller.ID,
+		AuthMethod: caller.Method,
+		Roleset:    c.Param("name"),
+		LeaseID:    c.GetString(auditLeaseContextKey),
+		Outcome:    auditOutcome(c.Writer.Status()),
+		Status:     c.Writer.Status(),
+		RequestID:  c.Writer.Header().Get(requestIDHeader),
+		ClientIP:   c.ClientIP(),
+	}
+	if event.Roleset == "" {
+		// Routes without a roleset in their path, such as unwrap
+		event.Roleset = c.GetString(auditRolesetContextKey)
+	}
+	if t := tenantFrom(c); t != nil {
+		event.Tenant = t.Name
+	}
+	if _, ok := c.Get(mountContextKey); ok {
+		event.Mount = h.mountClient(c).MountPath()
+	}
+	return event
+}
+
+// noteAuditIssuance records the kind and lease of an issued secret for the
+// audit log.
+func noteAuditIssuance(c *gin.Context, kind string, secret interface{}) {
+	c.Set(auditKindContextKey, kind)
+	if leased, ok := secret.(interface{ LeaseInfo() vault.Lease }); ok && leased.LeaseInfo().LeaseID != "" {
+		c.Set(auditLeaseContextKey, leased.LeaseInfo().LeaseID)
+	}
+}
+
+func auditOutcome(status int) string {
+	switch {
+	case status < http.StatusBadRequest:
+		return audit.OutcomeSuccess
+	case status == http.StatusUnauthorized, status == http.StatusForbidden, status == http.StatusTooManyRequests:
+		return audit.OutcomeDenied
+	default:
+		return audit.OutcomeFailure
+	}
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// awsRoutes registers the AWS secrets engine routes on group.
+func (h *Handler) awsRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	aws := group.Group("/aws")
+	{
+		aws.GET("/roles", h.ListAWSRoles)                                                           // GET /api/v1/aws/roles
+		aws.GET("/roles/:name", h.GetAWSRole)                                                       // GET /api/v1/aws/roles/{name}
+		aws.POST("/roles/:name", h.CreateAWSRole)                                                   // POST /api/v1/aws/roles/{name}
+		aws.PUT("/roles/:name", h.UpdateAWSRole)                                                    // PUT /api/v1/aws/roles/{name}
+		aws.DELETE("/roles/:name", h.DeleteAWSRole)                                                 // DELETE /api/v1/aws/roles/{name}
+		aws.POST("/creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetAWSCredentials)  // POST /api/v1/aws/creds/{name}
+		aws.POST("/sts/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetAWSSTSCredentials) // POST /api/v1/aws/sts/{name}
+	}
+}
+
+// Create a new AWS role
+func (h *Handler) CreateAWSRole(c *gin.Context) {
+	h.writeAWSRole(c, http.StatusCreated, "AWS role created successfully")
+}
+
+// Update an existing AWS role
+func (h *Handler) UpdateAWSRole(c *gin.Context) {
+	h.writeAWSRole(c, http.StatusOK, "AWS role updated successfully")
+}
+
+func (h *Handler) writeAWSRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.AWSRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteAWSRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("aws_role", name).Error("Failed to write AWS role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write AWS role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get an AWS role
+func (h *Handler) GetAWSRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetAWSRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "AWS role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("aws_role", name).Error("Failed to get AWS role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get AWS role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "AWS role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all AWS roles
+func (h *Handler) ListAWSRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListAWSRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list AWS roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list AWS roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "AWS roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete an AWS role
+func (h *Handler) DeleteAWSRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteAWSRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("aws_role", name).Error("Failed to delete AWS role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete AWS role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "AWS role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate credentials of the role's credential type
+func (h *Handler) GetAWSCredentials(c *gin.Context) {
+	h.issueAWSCredentials(c, usage.KindAWSCredentials, h.vaultClient.GetAWSCredentials)
+}
+
+// Generate STS credentials
+func (h *Handler) GetAWSSTSCredentials(c *gin.Context) {
+	h.issueAWSCredentials(c, usage.KindAWSSTSCredentials, h.vaultClient.GetAWSSTSCredentials)
+}
+
+func (h *Handler) issueAWSCredentials(c *gin.Context, kind string, issue func(context.Context, string, *vault.AWSCredentialsRequest) (*vault.AWSCredentialsResponse, error)) {
+	name := c.Param("name")
+
+	var req vault.AWSCredentialsRequest
+	// The body is optional, but if present it must be valid
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	credentials, err := issue(ctx, name, &req)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "AWS role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("aws_role", name).Error("Failed to get AWS credentials")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate AWS credentials",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, kind, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "AWS credentials generated successfully",
+		Data:    credentials,
+	})
+}
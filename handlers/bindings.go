@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// validateBindings rejects malformed bindings before they reach Vault, which
+// would only report them as an opaque error. It writes a 400 response listing
+// every invalid field and returns false if bindings are invalid.
+func validateBindings(c *gin.Context, bindings map[string]interface{}) bool {
+	if len(bindings) == 0 {
+		return true
+	}
+
+	errs := vault.ValidateBindings(bindings)
+	if len(errs) == 0 {
+		return true
+	}
+
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":  "Invalid bindings",
+		"fields": errs,
+	})
+	return false
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// consulRoutes registers the Consul secrets engine routes on group.
+func (h *Handler) consulRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	consul := group.Group("/consul")
+	{
+		consul.GET("/roles", h.ListConsulRoles)                                                    // GET /api/v1/consul/roles
+		consul.GET("/roles/:name", h.GetConsulRole)                                                // GET /api/v1/consul/roles/{name}
+		consul.POST("/roles/:name", h.CreateConsulRole)                                            // POST /api/v1/consul/roles/{name}
+		consul.PUT("/roles/:name", h.UpdateConsulRole)                                             // PUT /api/v1/consul/roles/{name}
+		consul.DELETE("/roles/:name", h.DeleteConsulRole)                                          // DELETE /api/v1/consul/roles/{name}
+		consul.POST("/creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetConsulToken) // POST /api/v1/consul/creds/{name}
+	}
+}
+
+// Create a new Consul role
+func (h *Handler) CreateConsulRole(c *gin.Context) {
+	h.writeConsulRole(c, http.StatusCreated, "Consul role created successfully")
+}
+
+// Update an existing Consul role
+func (h *Handler) UpdateConsulRole(c *gin.Context) {
+	h.writeConsulRole(c, http.StatusOK, "Consul role updated successfully")
+}
+
+func (h *Handler) writeConsulRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.ConsulRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if len(req.ConsulPolicies) == 0 && len(req.ConsulRoles) == 0 && len(req.ServiceIdentities) == 0 && len(req.NodeIdentities) == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: "at least one of consul_policies, consul_roles, service_identities or node_identities is required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteConsulRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("consul_role", name).Error("Failed to write Consul role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write Consul role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a Consul role
+func (h *Handler) GetConsulRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetConsulRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Consul role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("consul_role", name).Error("Failed to get Consul role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get Consul role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Consul role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all Consul roles
+func (h *Handler) ListConsulRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListConsulRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list Consul roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list Consul roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Consul roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete a Consul role
+func (h *Handler) DeleteConsulRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteConsulRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("consul_role", name).Error("Failed to delete Consul role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete Consul role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Consul role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate a Consul ACL token for a role
+func (h *Handler) GetConsulToken(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	credentials, err := h.vaultClient.GetConsulToken(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Consul role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("consul_role", name).Error("Failed to get Consul token")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate Consul token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindConsulToken, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Consul token generated successfully",
+		Data:    credentials,
+	})
+}
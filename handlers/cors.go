@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware answering CORS preflight requests and adding CORS headers to
+// responses for allowed origins. It does nothing while no origin is
+// configured. Besides the configured headers, the request headers hcvapi
+// itself reads (tenant, mount, namespace, API key, ...) are always allowed.
+func (h *Handler) CORSMiddleware() gin.HandlerFunc {
+	corsCfg := h.config.Server.CORS
+
+	allowedHeaders := append([]string{}, corsCfg.AllowedHeaders...)
+	for _, header := range []string{
+		h.config.Server.TenantHeader,
+		h.config.Server.MountHeader,
+		h.config.Server.NamespaceHeader,
+		h.config.Auth.APIKeys.Header,
+		cubbyholeTokenHeader,
+		cacheNegotiationHeader,
+	} {
+		if header != "" {
+			allowedHeaders = append(allowedHeaders, header)
+		}
+	}
+
+	methods := strings.Join(corsCfg.AllowedMethods, ", ")
+	headers := strings.Join(allowedHeaders, ", ")
+	maxAge := strconv.Itoa(int(corsCfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if len(corsCfg.AllowedOrigins) == 0 || origin == "" {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+		if !originAllowed(corsCfg.AllowedOrigins, origin) {
+			c.Next()
+			return
+		}
+
+		header.Set("Access-Control-Allow-Origin", origin)
+		if corsCfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+		header.Set("Access-Control-Expose-Headers", strings.Join([]string{requestIDHeader, "Retry-After", cacheNegotiationHeader}, ", "))
+
+		// Preflight requests are answered here, before routing
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			header.Set("Access-Control-Allow-Methods", methods)
+			header.Set("Access-Control-Allow-Headers", headers)
+			header.Set("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// originAllowed matches an Origin header against the allowed origins, which
+// may contain "*" wildcards.
+func originAllowed(allowed []string, origin string) bool {
+	for _, pattern := range allowed {
+		if pattern == "*" || pattern == origin {
+			return true
+		}
+		// Patterns are validated when the configuration is loaded
+		if ok, _ := path.Match(pattern, origin); ok {
+			return true
+		}
+	}
+	return false
+}
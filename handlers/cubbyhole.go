@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/identity"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// cubbyholeTokenHeader carries the token owning the cubbyhole a request
+// operates on.
+const cubbyholeTokenHeader = "X-Cubbyhole-Token"
+
+// Create a cubbyhole token and store data in its cubbyhole
+func (h *Handler) CreateCubbyhole(c *gin.Context) {
+	var req vault.CubbyholeCreateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	caller := identity.FromContext(c).ID
+	token, err := h.vaultClient.CreateCubbyhole(ctx, caller, &req)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to create cubbyhole")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create cubbyhole",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Cubbyhole created successfully",
+		Data:    token,
+	})
+}
+
+// Read a cubbyhole secret, or list the keys of a cubbyhole folder
+func (h *Handler) GetCubbyholeSecret(c *gin.Context) {
+	token, ok := requireCubbyholeToken(c)
+	if !ok {
+		return
+	}
+	path := kvPath(c)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if path == "" || strings.HasSuffix(path, "/") {
+		keys, err := h.vaultClient.ListCubbyhole(ctx, token, path)
+		if err != nil {
+			h.cubbyholeError(c, err, path, "list cubbyhole secrets")
+			return
+		}
+
+		c.JSON(http.StatusOK, SuccessResponse{
+			Message: "Cubbyhole secrets retrieved successfully",
+			Data: map[string]interface{}{
+				"path":  path,
+				"keys":  keys,
+				"count": len(keys),
+			},
+		})
+		return
+	}
+
+	secret, err := h.vaultClient.ReadCubbyhole(ctx, token, path)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Cubbyhole secret not found",
+		})
+		return
+	}
+	if err != nil {
+		h.cubbyholeError(c, err, path, "read cubbyhole secret")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Cubbyhole secret retrieved successfully",
+		Data:    secret,
+	})
+}
+
+// Write a cubbyhole secret
+func (h *Handler) PutCubbyholeSecret(c *gin.Context) {
+	token, ok := requireCubbyholeToken(c)
+	if !ok {
+		return
+	}
+	path, ok := requireCubbyholePath(c)
+	if !ok {
+		return
+	}
+
+	var req vault.CubbyholeWriteRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteCubbyhole(ctx, token, path, req.Data); err != nil {
+		h.cubbyholeError(c, err, path, "write cubbyhole secret")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Cubbyhole secret written successfully",
+		Data: map[string]string{
+			"path": path,
+		},
+	})
+}
+
+// Delete a cubbyhole secret
+func (h *Handler) DeleteCubbyholeSecret(c *gin.Context) {
+	token, ok := requireCubbyholeToken(c)
+	if !ok {
+		return
+	}
+	path, ok := requireCubbyholePath(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteCubbyhole(ctx, token, path); err != nil {
+		h.cubbyholeError(c, err, path, "delete cubbyhole secret")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Cubbyhole secret deleted successfully",
+		Data: map[string]string{
+			"path": path,
+		},
+	})
+}
+
+// requireCubbyholeToken returns the cubbyhole token of the request, answering
+// 401 when it carries none.
+func requireCubbyholeToken(c *gin.Context) (string, bool) {
+	token := c.GetHeader(cubbyholeTokenHeader)
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Cubbyhole token is required",
+			Details: "set the " + cubbyholeTokenHeader + " header",
+		})
+		return "", false
+	}
+	return token, true
+}
+
+// requireCubbyholePath returns the secret path, answering 400 when the route
+// names a folder instead of a secret.
+func requireCubbyholePath(c *gin.Context) (string, bool) {
+	path := kvPath(c)
+	if path == "" || strings.HasSuffix(path, "/") {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Cubbyhole secret path is required",
+		})
+		return "", false
+	}
+	return path, true
+}
+
+// cubbyholeError answers a failed cubbyhole operation, with 403 when Vault
+// rejected the token.
+func (h *Handler) cubbyholeError(c *gin.Context, err error, path, operation string) {
+	if errors.Is(err, vault.ErrCubbyholeToken) {
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "Invalid cubbyhole token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.log(c).WithError(err).WithField("path", path).Error("Failed to " + operation)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Failed to " + operation,
+		Details: err.Error(),
+	})
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// databaseRoutes registers the Database secrets engine routes on group.
+func (h *Handler) databaseRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	database := group.Group("/database")
+	{
+		database.GET("/roles", h.ListDatabaseRoles)                                                          // GET /api/v1/database/roles
+		database.GET("/roles/:name", h.GetDatabaseRole)                                                      // GET /api/v1/database/roles/{name}
+		database.POST("/roles/:name", h.CreateDatabaseRole)                                                  // POST /api/v1/database/roles/{name}
+		database.PUT("/roles/:name", h.UpdateDatabaseRole)                                                   // PUT /api/v1/database/roles/{name}
+		database.DELETE("/roles/:name", h.DeleteDatabaseRole)                                                // DELETE /api/v1/database/roles/{name}
+		database.POST("/creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetDatabaseCredentials) // POST /api/v1/database/creds/{name}
+	}
+}
+
+// Create a new database role
+func (h *Handler) CreateDatabaseRole(c *gin.Context) {
+	h.writeDatabaseRole(c, http.StatusCreated, "Database role created successfully")
+}
+
+// Update an existing database role
+func (h *Handler) UpdateDatabaseRole(c *gin.Context) {
+	h.writeDatabaseRole(c, http.StatusOK, "Database role updated successfully")
+}
+
+func (h *Handler) writeDatabaseRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.DatabaseRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteDatabaseRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("database_role", name).Error("Failed to write database role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write database role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a database role
+func (h *Handler) GetDatabaseRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetDatabaseRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Database role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("database_role", name).Error("Failed to get database role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get database role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Database role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all database roles
+func (h *Handler) ListDatabaseRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListDatabaseRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list database roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list database roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Database roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete a database role
+func (h *Handler) DeleteDatabaseRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteDatabaseRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("database_role", name).Error("Failed to delete database role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete database role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Database role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate database credentials for a role
+func (h *Handler) GetDatabaseCredentials(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	credentials, err := h.vaultClient.GetDatabaseCredentials(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Database role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("database_role", name).Error("Failed to get database credentials")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate database credentials",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindDatabaseCredentials, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Database credentials generated successfully",
+		Data:    credentials,
+	})
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation describes a deprecated route. Sunset and Successor are optional.
+type Deprecation struct {
+	Since     time.Time
+	Sunset    time.Time
+	Successor string
+}
+
+type DeprecatedRoute struct {
+	Route     string     `json:"route"`
+	Since     time.Time  `json:"since"`
+	Sunset    *time.Time `json:"sunset,omitempty"`
+	Successor string     `json:"successor,omitempty"`
+	Hits      int64      `json:"hits"`
+}
+
+// deprecationTracker counts hits on deprecated routes.
+type deprecationTracker struct {
+	mu     sync.Mutex
+	routes map[string]*DeprecatedRoute
+}
+
+func newDeprecationTracker() *deprecationTracker {
+	return &deprecationTracker{
+		routes: make(map[string]*DeprecatedRoute),
+	}
+}
+
+func (t *deprecationTracker) hit(route string, d Deprecation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.routes[route]
+	if !ok {
+		entry = &DeprecatedRoute{
+			Route:     route,
+			Since:     d.Since,
+			Successor: d.Successor,
+		}
+		if !d.Sunset.IsZero() {
+			sunset := d.Sunset
+			entry.Sunset = &sunset
+		}
+		t.routes[route] = entry
+	}
+	entry.Hits++
+}
+
+func (t *deprecationTracker) snapshot() []DeprecatedRoute {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	routes := make([]DeprecatedRoute, 0, len(t.routes))
+	for _, route := range t.routes {
+		routes = append(routes, *route)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Route < routes[j].Route })
+	return routes
+}
+
+// Middleware marking a route as deprecated: it emits Deprecation, Sunset and
+// successor Link headers and counts hits per route
+func (h *Handler) Deprecated(d Deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.Request.Method + " " + c.FullPath()
+
+		header := c.Writer.Header()
+		header.Set("Deprecation", "@"+formatUnix(d.Since))
+		if !d.Sunset.IsZero() {
+			header.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if d.Successor != "" {
+			header.Add("Link", "<"+d.Successor+`>; rel="successor-version"`)
+		}
+
+		h.deprecations.hit(route, d)
+		h.log(c).WithField("route", route).Info("Deprecated route called")
+
+		c.Next()
+	}
+}
+
+// List deprecated routes and how often they were called
+func (h *Handler) ListDeprecations(c *gin.Context) {
+	routes := h.deprecations.snapshot()
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Deprecated route usage retrieved successfully",
+		Data: map[string]interface{}{
+			"routes": routes,
+			"count":  len(routes),
+		},
+	})
+}
+
+func formatUnix(t time.Time) string {
+	return strconv.FormatInt(t.Unix(), 10)
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Read the GCP secrets engine configuration
+func (h *Handler) GetEngineConfig(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	engineConfig, err := h.mountClient(c).GetEngineConfig(ctx)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "GCP engine is not configured",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to read GCP engine config")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to read GCP engine config",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "GCP engine config retrieved successfully",
+		Data:    engineConfig,
+	})
+}
+
+// Update the engine's automated root rotation schedule
+func (h *Handler) UpdateRotationSettings(c *gin.Context) {
+	var settings vault.RotationSettings
+	if err := c.ShouldBindJSON(&settings); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	engineConfig, err := h.mountClient(c).UpdateRotationSettings(ctx, &settings)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to update GCP root rotation settings")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to update GCP root rotation settings",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "GCP root rotation settings updated successfully",
+		Data:    engineConfig,
+	})
+}
+
+// Tune the lease TTLs of the GCP engine mount
+func (h *Handler) TuneMount(c *gin.Context) {
+	var tuning vault.MountTuning
+	if err := c.ShouldBindJSON(&tuning); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if tuning.DefaultLeaseTTL == "" && tuning.MaxLeaseTTL == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "default_lease_ttl or max_lease_ttl is required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	mountTuning, err := h.mountClient(c).TuneMount(ctx, &tuning)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to tune GCP engine mount")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to tune GCP engine mount",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "GCP engine mount tuned successfully",
+		Data:    mountTuning,
+	})
+}
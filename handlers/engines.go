@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Optional secrets engines, enabled in the config.
+const (
+	EngineAWS        = "aws"
+	EngineDatabase   = "database"
+	EnginePKI        = "pki"
+	EngineSSH        = "ssh"
+	EngineTOTP       = "totp"
+	EngineConsul     = "consul"
+	EngineRabbitMQ   = "rabbitmq"
+	EngineLDAP       = "ldap"
+	EngineNomad      = "nomad"
+	EngineKubernetes = "kubernetes"
+	EngineTerraform  = "terraform"
+	EngineGCPKMS     = "gcpkms"
+	EngineTransit    = "transit"
+)
+
+// Engine is an optional secrets engine. Enabled engines are set up in Vault
+// on startup and report on their mount; the routes of every engine are
+// registered and answer 404 while it is disabled.
+type Engine interface {
+	Name() string
+	Enabled() bool
+	// Mount returns the path the engine is mounted at.
+	Mount() string
+	Initialize(ctx context.Context) error
+	Routes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc)
+	// Health returns nil while the engine's mount is in place.
+	Health(ctx context.Context) error
+}
+
+// secretsEngine is an Engine mounted at a single path.
+type secretsEngine struct {
+	name       string
+	enabled    bool
+	mount      string
+	vault      *vault.Client
+	initialize func(ctx context.Context) error
+	routes     func(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc)
+}
+
+func (e *secretsEngine) Name() string { return e.name }
+
+func (e *secretsEngine) Enabled() bool { return e.enabled }
+
+func (e *secretsEngine) Mount() string { return e.mount }
+
+func (e *secretsEngine) Initialize(ctx context.Context) error {
+	return e.initialize(ctx)
+}
+
+func (e *secretsEngine) Routes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	e.routes(group, secretHeaders, issuanceAudit, incidentGuard)
+}
+
+func (e *secretsEngine) Health(ctx context.Context) error {
+	return e.vault.CheckMount(ctx, e.mount)
+}
+
+// newEngines returns the optional secrets engines in the order they are
+// initialized. Each engine's routes live next to its handlers.
+func (h *Handler) newEngines() []Engine {
+	cfg := h.config
+	client := h.vaultClient
+
+	engine := func(name string, enabled bool, mount string, initialize func(context.Context) error, routes func(*gin.RouterGroup, gin.HandlerFunc, gin.HandlerFunc, gin.HandlerFunc)) Engine {
+		return &secretsEngine{
+			name:       name,
+			enabled:    enabled,
+			mount:      mount,
+			vault:      client,
+			initialize: initialize,
+			routes:     routes,
+		}
+	}
+
+	return []Engine{
+		engine(EngineAWS, cfg.AWS.Enabled, cfg.AWS.MountPath, client.InitializeAWS, h.awsRoutes),
+		engine(EngineDatabase, cfg.Database.Enabled, cfg.Database.MountPath, client.InitializeDatabase, h.databaseRoutes),
+		engine(EnginePKI, cfg.PKI.Enabled, cfg.PKI.MountPath, client.InitializePKI, h.pkiRoutes),
+		engine(EngineSSH, cfg.SSH.Enabled, cfg.SSH.MountPath, client.InitializeSSH, h.sshRoutes),
+		engine(EngineTOTP, cfg.TOTP.Enabled, cfg.TOTP.MountPath, client.InitializeTOTP, h.totpRoutes),
+		engine(EngineConsul, cfg.Consul.Enabled, cfg.Consul.MountPath, client.InitializeConsul, h.consulRoutes),
+		engine(EngineRabbitMQ, cfg.RabbitMQ.Enabled, cfg.RabbitMQ.MountPath, client.InitializeRabbitMQ, h.rabbitmqRoutes),
+		engine(EngineLDAP, cfg.LDAP.Enabled, cfg.LDAP.MountPath, client.InitializeLDAP, h.ldapRoutes),
+		engine(EngineNomad, cfg.Nomad.Enabled, cfg.Nomad.MountPath, client.InitializeNomad, h.nomadRoutes),
+		engine(EngineKubernetes, cfg.Kubernetes.Enabled, cfg.Kubernetes.MountPath, client.InitializeKubernetes, h.kubernetesRoutes),
+		engine(EngineTerraform, cfg.Terraform.Enabled, cfg.Terraform.MountPath, client.InitializeTerraform, h.terraformRoutes),
+		engine(EngineGCPKMS, cfg.GCPKMS.Enabled, cfg.GCPKMS.MountPath, client.InitializeGCPKMS, h.gcpkmsRoutes),
+		engine(EngineTransit, cfg.Transit.Enabled, cfg.Transit.MountPath, client.InitializeTransit, h.transitRoutes),
+	}
+}
+
+// Engines returns the optional secrets engines in initialization order.
+func (h *Handler) Engines() []Engine {
+	return h.engines
+}
+
+// Middleware answering 404 for the routes of an optional secrets engine
+// while it is disabled
+func (h *Handler) EngineMiddleware(engine Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !engine.Enabled() {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Secrets engine is not enabled",
+				Details: engine.Name(),
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -2,18 +2,58 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/audit"
+	"github.com/kalpesh172000/hcvapi/cache"
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/incident"
+	"github.com/kalpesh172000/hcvapi/lifecycle"
+	"github.com/kalpesh172000/hcvapi/reports"
+	"github.com/kalpesh172000/hcvapi/usage"
 	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/kalpesh172000/hcvapi/webhook"
 	"github.com/sirupsen/logrus"
 )
 
+// Time CreateRoleset waits for a new roleset with ?wait=true, by default and
+// at most. Both stay well below the server's 30s write timeout, so the
+// answer is still written once the wait gives up.
+const (
+	defaultRolesetWait = 10 * time.Second
+	maxRolesetWait     = 20 * time.Second
+)
+
 type Handler struct {
-	vaultClient *vault.Client
-	logger      *logrus.Logger
+	config       *config.Config
+	vaultClient  *vault.Client
+	cache        cache.Cache
+	usage        *usage.Recorder
+	reports      *reports.Scheduler
+	components   *lifecycle.Manager
+	incidents    *incident.Controller
+	audit        *audit.Logger
+	webhooks     *webhook.Dispatcher
+	logger       *logrus.Logger
+	deprecations *deprecationTracker
+	engines      []Engine
+}
+
+// Services are the backends the handlers delegate to.
+type Services struct {
+	Vault      *vault.Client
+	Cache      cache.Cache
+	Usage      *usage.Recorder
+	Reports    *reports.Scheduler
+	Components *lifecycle.Manager
+	Incidents  *incident.Controller
+	Audit      *audit.Logger
+	Webhooks   *webhook.Dispatcher
 }
 
 type ErrorResponse struct {
@@ -28,13 +68,26 @@ type SuccessResponse struct {
 
 type TokenRequest struct {
 	TTL string `json:"ttl,omitempty"`
+	// WrapTTL returns the token response-wrapped for that long
+	WrapTTL string `json:"wrap_ttl,omitempty"`
 }
 
-func NewHandler(vaultClient *vault.Client, logger *logrus.Logger) *Handler {
-	return &Handler{
-		vaultClient: vaultClient,
-		logger:      logger,
+func NewHandler(cfg *config.Config, services Services, logger *logrus.Logger) *Handler {
+	h := &Handler{
+		config:       cfg,
+		vaultClient:  services.Vault,
+		cache:        services.Cache,
+		usage:        services.Usage,
+		reports:      services.Reports,
+		components:   services.Components,
+		incidents:    services.Incidents,
+		audit:        services.Audit,
+		webhooks:     services.Webhooks,
+		logger:       logger,
+		deprecations: newDeprecationTracker(),
 	}
+	h.engines = h.newEngines()
+	return h
 }
 
 // Health check endpoint
@@ -43,7 +96,7 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 	defer cancel()
 
 	if err := h.vaultClient.HealthCheck(ctx); err != nil {
-		h.logger.WithError(err).Error("Health check failed")
+		h.log(c).WithError(err).Error("Health check failed")
 		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
 			Error:   "Service unavailable",
 			Details: err.Error(),
@@ -56,6 +109,93 @@ func (h *Handler) HealthCheck(c *gin.Context) {
 	})
 }
 
+// Full health of the Vault node, including standby and replication state
+func (h *Handler) VaultHealth(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	health, err := h.vaultClient.VaultHealth(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Vault health check failed")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Vault unavailable",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if !health.Ready() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Vault is not ready",
+			"data":  health,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Vault is healthy",
+		Data:    health,
+	})
+}
+
+// Status of every managed component
+func (h *Handler) HealthDetails(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	statuses, healthy := h.components.Health(ctx)
+	if !healthy {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "One or more components are unhealthy",
+			"data":  statuses,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "All components are healthy",
+		Data:    statuses,
+	})
+}
+
+// Readiness endpoint reflecting Vault health and the remaining TTL of our token
+func (h *Handler) Readiness(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.HealthCheck(ctx); err != nil {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Service not ready",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	status, err := h.vaultClient.TokenStatus(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Readiness check failed")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Service not ready",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if status.Expires && status.TTL < h.config.Vault.MinTokenTTL {
+		h.log(c).WithField("ttl", status.TTL).Warn("Vault token TTL below readiness threshold")
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Vault token TTL below threshold",
+			"data":  status,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Service is ready",
+		Data:    status,
+	})
+}
+
 // Create a new roleset
 func (h *Handler) CreateRoleset(c *gin.Context) {
 	rolesetName := c.Param("name")
@@ -70,22 +210,267 @@ func (h *Handler) CreateRoleset(c *gin.Context) {
 		return
 	}
 
-	// Convert the map in JSON input to string
-	bindingsMap := make(map[string]interface{})
-	if err := json.Unmarshal([]byte(req.Bindings), &bindingsMap); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bindings format"})
+	if !validateBindings(c, req.Bindings) {
 		return
 	}
 
-	bindingsJSON, _ := json.Marshal(bindingsMap)
-	req.Bindings = string(bindingsJSON)
+	if !applyTenantToRoleset(c, &req) {
+		return
+	}
+
+	defaults, ok := h.applyRolesetDefaults(c, &req)
+	if !ok {
+		return
+	}
 
-	if err := h.vaultClient.CreateRoleset(context.Background(), rolesetName, &req); err != nil {
+	// The wait timeout is checked before anything is created
+	wait := c.Query("wait") == "true"
+	timeout := defaultRolesetWait
+	if raw := c.Query("timeout"); wait && raw != "" {
+		parsed, err := parseTTL(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if parsed <= 0 || parsed > maxRolesetWait {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "timeout must be positive and at most " + maxRolesetWait.String()})
+			return
+		}
+		timeout = parsed
+	}
+
+	createCtx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).CreateRoleset(createCtx, rolesetName, &req); err != nil {
+		var createErr *vault.RolesetCreateError
+		if errors.As(err, &createErr) {
+			h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to create roleset")
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"error":       err.Error(),
+				"rolled_back": createErr.RolledBack,
+				"remaining":   createErr.Remaining,
+			})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{"message": "Roleset created successfully"})
+	h.notifyWebhooks(c, &webhook.Event{
+		Type:    webhook.EventRolesetCreated,
+		Roleset: rolesetName,
+		Data: map[string]interface{}{
+			"project":     req.Project,
+			"secret_type": req.SecretType,
+		},
+	})
+
+	// Optionally wait until the roleset's service account is provisioned
+	if wait {
+		waitCtx, cancelWait := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancelWait()
+
+		roleset, err := h.mountClient(c).WaitForRoleset(waitCtx, rolesetName, time.Second)
+		if err != nil {
+			h.log(c).WithError(err).WithField("roleset", rolesetName).Warn("Roleset not ready before timeout")
+			c.JSON(http.StatusAccepted, gin.H{
+				"message":          "Roleset created but not ready yet",
+				"details":          err.Error(),
+				"applied_defaults": defaults,
+			})
+			return
+		}
+
+		c.JSON(http.StatusCreated, gin.H{
+			"message":          "Roleset created successfully",
+			"data":             roleset,
+			"applied_defaults": defaults,
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"message":          "Roleset created successfully",
+		"applied_defaults": defaults,
+	})
+}
+
+// applyTenantToRoleset enforces the tenant's guardrails on a roleset request
+// and fills in tenant defaults. It writes the error response and returns
+// false if the request is not allowed.
+func applyTenantToRoleset(c *gin.Context, req *vault.RolesetRequest) bool {
+	t := tenantFrom(c)
+	if err := t.checkProject(req.Project); err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return false
+	}
+	for _, ttl := range []string{req.TTL, req.MaxTTL} {
+		if err := t.checkTTL(ttl); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return false
+		}
+	}
+	if req.TokenScopes == "" && req.SecretType == "access_token" {
+		req.TokenScopes = t.tokenScopes()
+	}
+	return true
+}
+
+// Replace an existing roleset
+func (h *Handler) UpdateRoleset(c *gin.Context) {
+	rolesetName := c.Param("name")
+
+	var req vault.RolesetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.updateRoleset(c, rolesetName, &req)
+}
+
+// Partially update an existing roleset, merging into its current configuration
+func (h *Handler) PatchRoleset(c *gin.Context) {
+	rolesetName := c.Param("name")
+
+	var patch vault.RolesetPatch
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	req, err := h.mountClient(c).MergeRolesetPatch(ctx, rolesetName, &patch)
+	if err != nil {
+		h.respondRolesetUpdateError(c, rolesetName, err)
+		return
+	}
+
+	h.updateRoleset(c, rolesetName, req)
+}
+
+func (h *Handler) updateRoleset(c *gin.Context, rolesetName string, req *vault.RolesetRequest) {
+	if !validateBindings(c, req.Bindings) {
+		return
+	}
+
+	if !applyTenantToRoleset(c, req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).UpdateRoleset(ctx, rolesetName, req); err != nil {
+		h.respondRolesetUpdateError(c, rolesetName, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Roleset updated successfully",
+		Data: map[string]string{
+			"name": rolesetName,
+		},
+	})
+}
+
+func (h *Handler) respondRolesetUpdateError(c *gin.Context, rolesetName string, err error) {
+	switch {
+	case errors.Is(err, vault.ErrNotFound):
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+	case errors.Is(err, vault.ErrSecretTypeChange):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: err.Error(),
+		})
+	default:
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to update roleset")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to update roleset",
+			Details: err.Error(),
+		})
+	}
+}
+
+// Rotate the service account backing a roleset
+func (h *Handler) RotateRoleset(c *gin.Context) {
+	rolesetName := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).RotateRoleset(ctx, rolesetName); err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to rotate roleset")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to rotate roleset",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Roleset service account rotated successfully",
+		Data: map[string]string{
+			"name": rolesetName,
+		},
+	})
+}
+
+// Rotate the token-signing key of an access_token roleset
+func (h *Handler) RotateRolesetKey(c *gin.Context) {
+	rolesetName := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	roleset, err := h.mountClient(c).GetRoleset(ctx, rolesetName)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+	if err != nil {
+		// Without the roleset its secret type cannot be checked
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to get roleset")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get roleset",
+			Details: err.Error(),
+		})
+		return
+	}
+	if roleset.SecretType != "access_token" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Key rotation is only supported for access_token rolesets",
+		})
+		return
+	}
+
+	if err := h.mountClient(c).RotateRolesetKey(ctx, rolesetName); err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to rotate roleset key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to rotate roleset key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Roleset key rotated successfully",
+		Data: map[string]string{
+			"name": rolesetName,
+		},
+	})
 }
 
 // Generate access token
@@ -102,12 +487,46 @@ func (h *Handler) GetAccessToken(c *gin.Context) {
 	// TTL is optional, so ignore bind errors
 	_ = c.ShouldBindJSON(&tokenReq)
 
+	if err := tenantFrom(c).capTTL(&tokenReq.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+	if !checkWrapTTL(c, tokenReq.WrapTTL) {
+		return
+	}
+	if tokenReq.WrapTTL != "" {
+		h.getWrappedAccessToken(c, rolesetName, &tokenReq)
+		return
+	}
+
+	var minTTL time.Duration
+	if raw := c.Query("min_ttl"); raw != "" {
+		parsed, err := parseTTL(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid min_ttl",
+				Details: err.Error(),
+			})
+			return
+		}
+		minTTL = parsed
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	token, err := h.vaultClient.GetToken(ctx, rolesetName, tokenReq.TTL)
+	token, cacheInfo, err := h.mountClient(c).GetTokenWithMinTTL(ctx, rolesetName, tokenReq.TTL, minTTL)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
 	if err != nil {
-		h.logger.WithError(err).WithField("roleset", rolesetName).Error("Failed to get access token")
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to get access token")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to generate access token",
 			Details: err.Error(),
@@ -115,6 +534,12 @@ func (h *Handler) GetAccessToken(c *gin.Context) {
 		return
 	}
 
+	if c.GetHeader(cacheNegotiationHeader) == "allow" {
+		setTokenCacheHeaders(c, token, cacheInfo)
+	}
+
+	h.recordIssuance(c, usage.KindRolesetToken, rolesetName, token)
+
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Access token generated successfully",
 		Data:    token,
@@ -131,12 +556,53 @@ func (h *Handler) GetServiceAccountKey(c *gin.Context) {
 		return
 	}
 
+	var keyReq vault.KeyRequest
+	// The body is optional, but if present it must be valid
+	if err := c.ShouldBindJSON(&keyReq); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&keyReq.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+	if !checkWrapTTL(c, keyReq.WrapTTL) {
+		return
+	}
+
+	refund, ok := h.reserveKeyQuota(c, rolesetName)
+	if !ok {
+		return
+	}
+
+	if keyReq.WrapTTL != "" {
+		h.getWrappedServiceAccountKey(c, rolesetName, &keyReq, refund)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
 	defer cancel()
 
-	key, err := h.vaultClient.GetServiceAccountKey(ctx, rolesetName)
+	key, err := h.mountClient(c).GetServiceAccountKey(ctx, rolesetName, &keyReq)
 	if err != nil {
-		h.logger.WithError(err).WithField("roleset", rolesetName).Error("Failed to get service account key")
+		// Only issued keys count against the quota
+		refund()
+	}
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to get service account key")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to generate service account key",
 			Details: err.Error(),
@@ -144,6 +610,13 @@ func (h *Handler) GetServiceAccountKey(c *gin.Context) {
 		return
 	}
 
+	h.recordIssuance(c, usage.KindRolesetKey, rolesetName, key)
+	h.notifyWebhooks(c, &webhook.Event{
+		Type:    webhook.EventKeyIssued,
+		Roleset: rolesetName,
+		Data:    keyEventData(key),
+	})
+
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Service account key generated successfully",
 		Data:    key,
@@ -155,9 +628,28 @@ func (h *Handler) ListRolesets(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
 	defer cancel()
 
-	rolesets, err := h.vaultClient.ListRolesets(ctx)
+	mount := h.mountClient(c).MountPath()
+	opts := vault.RolesetListOptions{
+		Prefix: c.Query("prefix"),
+		After:  c.Query("after"),
+		// Rolesets outside the caller's scope are left out
+		Filter: func(name string) bool { return h.rolesetAllowed(c, mount, name) },
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid limit",
+				Details: "limit must be a positive integer",
+			})
+			return
+		}
+		opts.Limit = limit
+	}
+
+	page, err := h.mountClient(c).ListRolesetPage(ctx, opts)
 	if err != nil {
-		h.logger.WithError(err).Error("Failed to list rolesets")
+		h.log(c).WithError(err).Error("Failed to list rolesets")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to list rolesets",
 			Details: err.Error(),
@@ -165,12 +657,52 @@ func (h *Handler) ListRolesets(c *gin.Context) {
 		return
 	}
 
+	data := map[string]interface{}{
+		"rolesets": page.Rolesets,
+		"count":    len(page.Rolesets),
+	}
+	if page.Next != "" {
+		data["next"] = page.Next
+	}
+
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Rolesets retrieved successfully",
-		Data: map[string]interface{}{
-			"rolesets": rolesets,
-			"count":    len(rolesets),
-		},
+		Data:    data,
+	})
+}
+
+// Get roleset details
+func (h *Handler) GetRoleset(c *gin.Context) {
+	rolesetName := c.Param("name")
+	if rolesetName == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Roleset name is required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roleset, err := h.mountClient(c).GetRoleset(ctx, rolesetName)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to get roleset")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get roleset",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Roleset retrieved successfully",
+		Data:    roleset,
 	})
 }
 
@@ -187,8 +719,8 @@ func (h *Handler) DeleteRoleset(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
 	defer cancel()
 
-	if err := h.vaultClient.DeleteRoleset(ctx, rolesetName); err != nil {
-		h.logger.WithError(err).WithField("roleset", rolesetName).Error("Failed to delete roleset")
+	if err := h.mountClient(c).DeleteRoleset(ctx, rolesetName); err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to delete roleset")
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error:   "Failed to delete roleset",
 			Details: err.Error(),
@@ -196,6 +728,11 @@ func (h *Handler) DeleteRoleset(c *gin.Context) {
 		return
 	}
 
+	h.notifyWebhooks(c, &webhook.Event{
+		Type:    webhook.EventRolesetDeleted,
+		Roleset: rolesetName,
+	})
+
 	c.JSON(http.StatusOK, SuccessResponse{
 		Message: "Roleset deleted successfully",
 		Data: map[string]string{
@@ -204,6 +741,46 @@ func (h *Handler) DeleteRoleset(c *gin.Context) {
 	})
 }
 
+// Save a roleset's definition to KV and delete the roleset
+func (h *Handler) ArchiveRoleset(c *gin.Context) {
+	rolesetName := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	path, err := h.mountClient(c).ArchiveRoleset(ctx, rolesetName, h.config.Reports.Stale.ArchivePath)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to archive roleset")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to archive roleset",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.notifyWebhooks(c, &webhook.Event{
+		Type:    webhook.EventRolesetDeleted,
+		Roleset: rolesetName,
+		Data: map[string]interface{}{
+			"archived_to": path,
+		},
+	})
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Roleset archived successfully",
+		Data: map[string]string{
+			"name":    rolesetName,
+			"kv_path": path,
+		},
+	})
+}
+
 // Middleware for logging requests
 func (h *Handler) LoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -211,6 +788,11 @@ func (h *Handler) LoggingMiddleware() gin.HandlerFunc {
 		path := c.Request.URL.Path
 		raw := c.Request.URL.RawQuery
 
+		// Correlate every log line of the request through its id
+		id := requestID(c)
+		c.Header(requestIDHeader, id)
+		withLogEntry(c, h.logger.WithField("request_id", id))
+
 		// Process request
 		c.Next()
 
@@ -218,7 +800,7 @@ func (h *Handler) LoggingMiddleware() gin.HandlerFunc {
 		duration := time.Since(start)
 
 		// Build log entry
-		entry := h.logger.WithFields(logrus.Fields{
+		entry := h.log(c).WithFields(logrus.Fields{
 			"status":     c.Writer.Status(),
 			"method":     c.Request.Method,
 			"path":       path,
@@ -239,10 +821,32 @@ func (h *Handler) LoggingMiddleware() gin.HandlerFunc {
 // Middleware for error handling
 func (h *Handler) ErrorHandlingMiddleware() gin.HandlerFunc {
 	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
-		h.logger.WithField("panic", recovered).Error("Request panic recovered")
+		h.log(c).WithField("panic", recovered).Error("Request panic recovered")
 
 		c.JSON(http.StatusInternalServerError, ErrorResponse{
 			Error: "Internal server error",
 		})
 	})
 }
+
+// startupRoutes answer before every component has started, so a Vault
+// still sealed at startup can be inspected and unsealed through hcvapi
+var startupRoutes = map[string]bool{
+	"/api/v1/admin/unseal":       true,
+	"/api/v1/system/seal-status": true,
+}
+
+// Middleware answering 503 until every component has started, so the API
+// can listen in degraded mode while Vault is still sealed or unreachable
+func (h *Handler) StartupMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !h.components.Started() && !startupRoutes[c.FullPath()] {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, ErrorResponse{
+				Error:   "Service unavailable",
+				Details: "waiting for Vault to become ready",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/sirupsen/logrus"
+)
+
+// gcpkmsRoutes registers the GCP KMS secrets engine routes on group.
+func (h *Handler) gcpkmsRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	gcpkms := group.Group("/gcpkms")
+	{
+		gcpkms.GET("/keys", h.ListGCPKMSKeys)                         // GET /api/v1/gcpkms/keys
+		gcpkms.GET("/keys/:name", h.GetGCPKMSKey)                     // GET /api/v1/gcpkms/keys/{name}
+		gcpkms.POST("/keys/:name", h.RegisterGCPKMSKey)               // POST /api/v1/gcpkms/keys/{name}
+		gcpkms.DELETE("/keys/:name", h.DeregisterGCPKMSKey)           // DELETE /api/v1/gcpkms/keys/{name}
+		gcpkms.POST("/encrypt/:name", h.GCPKMSEncrypt)                // POST /api/v1/gcpkms/encrypt/{name}
+		gcpkms.POST("/decrypt/:name", secretHeaders, h.GCPKMSDecrypt) // POST /api/v1/gcpkms/decrypt/{name}
+		gcpkms.POST("/reencrypt/:name", h.GCPKMSReencrypt)            // POST /api/v1/gcpkms/reencrypt/{name}
+		gcpkms.POST("/sign/:name", h.GCPKMSSign)                      // POST /api/v1/gcpkms/sign/{name}
+		gcpkms.POST("/verify/:name", h.GCPKMSVerify)                  // POST /api/v1/gcpkms/verify/{name}
+	}
+}
+
+// Register an existing Cloud KMS crypto key
+func (h *Handler) RegisterGCPKMSKey(c *gin.Context) {
+	name := c.Param("name")
+
+	var req vault.GCPKMSRegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.RegisterGCPKMSKey(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("gcpkms_key", name).Error("Failed to register GCP KMS key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to register GCP KMS key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "GCP KMS key registered successfully",
+		Data: map[string]string{
+			"name":       name,
+			"crypto_key": req.CryptoKey,
+		},
+	})
+}
+
+// Get a registered GCP KMS key
+func (h *Handler) GetGCPKMSKey(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	key, err := h.vaultClient.GetGCPKMSKey(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "GCP KMS key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("gcpkms_key", name).Error("Failed to get GCP KMS key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get GCP KMS key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "GCP KMS key retrieved successfully",
+		Data:    key,
+	})
+}
+
+// List all registered GCP KMS keys
+func (h *Handler) ListGCPKMSKeys(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	keys, err := h.vaultClient.ListGCPKMSKeys(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list GCP KMS keys")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list GCP KMS keys",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "GCP KMS keys retrieved successfully",
+		Data: map[string]interface{}{
+			"keys":  keys,
+			"count": len(keys),
+		},
+	})
+}
+
+// Deregister a GCP KMS key, leaving it in Cloud KMS
+func (h *Handler) DeregisterGCPKMSKey(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeregisterGCPKMSKey(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("gcpkms_key", name).Error("Failed to deregister GCP KMS key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to deregister GCP KMS key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "GCP KMS key deregistered successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Encrypt plaintext with a GCP KMS key
+func (h *Handler) GCPKMSEncrypt(c *gin.Context) {
+	var req vault.GCPKMSEncryptRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.runGCPKMSOperation(c, "encrypt", "Plaintext encrypted successfully", func(ctx context.Context, name string) (interface{}, error) {
+		return h.vaultClient.GCPKMSEncrypt(ctx, name, &req)
+	})
+}
+
+// Decrypt ciphertext with a GCP KMS key
+func (h *Handler) GCPKMSDecrypt(c *gin.Context) {
+	var req vault.GCPKMSDecryptRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.runGCPKMSOperation(c, "decrypt", "Ciphertext decrypted successfully", func(ctx context.Context, name string) (interface{}, error) {
+		plaintext, err := h.vaultClient.GCPKMSDecrypt(ctx, name, &req)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"plaintext": plaintext}, nil
+	})
+}
+
+// Re-encrypt ciphertext with the primary version of a GCP KMS key
+func (h *Handler) GCPKMSReencrypt(c *gin.Context) {
+	var req vault.GCPKMSDecryptRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.runGCPKMSOperation(c, "reencrypt", "Ciphertext re-encrypted successfully", func(ctx context.Context, name string) (interface{}, error) {
+		return h.vaultClient.GCPKMSReencrypt(ctx, name, &req)
+	})
+}
+
+// Sign a digest with a GCP KMS key
+func (h *Handler) GCPKMSSign(c *gin.Context) {
+	var req vault.GCPKMSSignRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.runGCPKMSOperation(c, "sign", "Digest signed successfully", func(ctx context.Context, name string) (interface{}, error) {
+		signature, err := h.vaultClient.GCPKMSSign(ctx, name, &req)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{"signature": signature}, nil
+	})
+}
+
+// Verify a signature with a GCP KMS key
+func (h *Handler) GCPKMSVerify(c *gin.Context) {
+	var req vault.GCPKMSVerifyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.runGCPKMSOperation(c, "verify", "Signature verified successfully", func(ctx context.Context, name string) (interface{}, error) {
+		valid, err := h.vaultClient.GCPKMSVerify(ctx, name, &req)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]bool{"valid": valid}, nil
+	})
+}
+
+// bindJSON binds a required JSON body, answering 400 if it is invalid
+func bindJSON(c *gin.Context, req interface{}) bool {
+	if err := c.ShouldBindJSON(req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+func (h *Handler) runGCPKMSOperation(c *gin.Context, operation, message string, run func(context.Context, string) (interface{}, error)) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := run(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "GCP KMS key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithFields(logrus.Fields{
+			"gcpkms_key": name,
+			"operation":  operation,
+		}).Error("GCP KMS operation failed")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "GCP KMS " + operation + " failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: message,
+		Data:    result,
+	})
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware setting strict headers on secret-bearing responses so that
+// intermediaries never cache tokens or keys
+func (h *Handler) SecretHeadersMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("Cache-Control", "no-store")
+		header.Set("Pragma", "no-cache")
+		header.Set("X-Content-Type-Options", "nosniff")
+
+		for name, value := range h.config.Server.SecretHeaders {
+			header.Set(name, value)
+		}
+
+		c.Next()
+	}
+}
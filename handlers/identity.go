@@ -0,0 +1,375 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Create a new identity entity
+func (h *Handler) CreateIdentityEntity(c *gin.Context) {
+	h.writeIdentityEntity(c, http.StatusCreated, "Identity entity created successfully")
+}
+
+// Update an existing identity entity
+func (h *Handler) UpdateIdentityEntity(c *gin.Context) {
+	h.writeIdentityEntity(c, http.StatusOK, "Identity entity updated successfully")
+}
+
+func (h *Handler) writeIdentityEntity(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.IdentityEntityRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteIdentityEntity(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("entity", name).Error("Failed to write identity entity")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write identity entity",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get an identity entity
+func (h *Handler) GetIdentityEntity(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	entity, err := h.vaultClient.GetIdentityEntity(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Identity entity not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("entity", name).Error("Failed to get identity entity")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get identity entity",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity entity retrieved successfully",
+		Data:    entity,
+	})
+}
+
+// List all identity entities
+func (h *Handler) ListIdentityEntities(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	entities, err := h.vaultClient.ListIdentityEntities(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list identity entities")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list identity entities",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity entities retrieved successfully",
+		Data: map[string]interface{}{
+			"entities": entities,
+			"count":    len(entities),
+		},
+	})
+}
+
+// Delete an identity entity
+func (h *Handler) DeleteIdentityEntity(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteIdentityEntity(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("entity", name).Error("Failed to delete identity entity")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete identity entity",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity entity deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Create a new identity entity alias
+func (h *Handler) CreateIdentityAlias(c *gin.Context) {
+	var req vault.IdentityAliasRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	id, err := h.vaultClient.CreateIdentityAlias(ctx, &req)
+	if err != nil {
+		h.log(c).WithError(err).WithField("entity_id", req.CanonicalID).Error("Failed to create identity entity alias")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create identity entity alias",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Identity entity alias created successfully",
+		Data: map[string]string{
+			"id": id,
+		},
+	})
+}
+
+// Update an existing identity entity alias
+func (h *Handler) UpdateIdentityAlias(c *gin.Context) {
+	id := c.Param("id")
+
+	var req vault.IdentityAliasRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.UpdateIdentityAlias(ctx, id, &req); err != nil {
+		h.log(c).WithError(err).WithField("alias_id", id).Error("Failed to update identity entity alias")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to update identity entity alias",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity entity alias updated successfully",
+		Data: map[string]string{
+			"id": id,
+		},
+	})
+}
+
+// Get an identity entity alias
+func (h *Handler) GetIdentityAlias(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	alias, err := h.vaultClient.GetIdentityAlias(ctx, id)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Identity entity alias not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("alias_id", id).Error("Failed to get identity entity alias")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get identity entity alias",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity entity alias retrieved successfully",
+		Data:    alias,
+	})
+}
+
+// List all identity entity aliases
+func (h *Handler) ListIdentityAliases(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	ids, err := h.vaultClient.ListIdentityAliases(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list identity entity aliases")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list identity entity aliases",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity entity aliases retrieved successfully",
+		Data: map[string]interface{}{
+			"ids":   ids,
+			"count": len(ids),
+		},
+	})
+}
+
+// Delete an identity entity alias
+func (h *Handler) DeleteIdentityAlias(c *gin.Context) {
+	id := c.Param("id")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteIdentityAlias(ctx, id); err != nil {
+		h.log(c).WithError(err).WithField("alias_id", id).Error("Failed to delete identity entity alias")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete identity entity alias",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity entity alias deleted successfully",
+		Data: map[string]string{
+			"id": id,
+		},
+	})
+}
+
+// Create a new identity group
+func (h *Handler) CreateIdentityGroup(c *gin.Context) {
+	h.writeIdentityGroup(c, http.StatusCreated, "Identity group created successfully")
+}
+
+// Update an existing identity group
+func (h *Handler) UpdateIdentityGroup(c *gin.Context) {
+	h.writeIdentityGroup(c, http.StatusOK, "Identity group updated successfully")
+}
+
+func (h *Handler) writeIdentityGroup(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.IdentityGroupRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteIdentityGroup(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("group", name).Error("Failed to write identity group")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write identity group",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get an identity group
+func (h *Handler) GetIdentityGroup(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	group, err := h.vaultClient.GetIdentityGroup(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Identity group not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("group", name).Error("Failed to get identity group")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get identity group",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity group retrieved successfully",
+		Data:    group,
+	})
+}
+
+// List all identity groups
+func (h *Handler) ListIdentityGroups(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	groups, err := h.vaultClient.ListIdentityGroups(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list identity groups")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list identity groups",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity groups retrieved successfully",
+		Data: map[string]interface{}{
+			"groups": groups,
+			"count":  len(groups),
+		},
+	})
+}
+
+// Delete an identity group
+func (h *Handler) DeleteIdentityGroup(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteIdentityGroup(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("group", name).Error("Failed to delete identity group")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete identity group",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Identity group deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
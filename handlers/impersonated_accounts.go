@@ -0,0 +1,180 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Create a new impersonated account
+func (h *Handler) CreateImpersonatedAccount(c *gin.Context) {
+	h.writeImpersonatedAccount(c, http.StatusCreated, "Impersonated account created successfully")
+}
+
+// Update an existing impersonated account
+func (h *Handler) UpdateImpersonatedAccount(c *gin.Context) {
+	h.writeImpersonatedAccount(c, http.StatusOK, "Impersonated account updated successfully")
+}
+
+func (h *Handler) writeImpersonatedAccount(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Impersonated account name is required",
+		})
+		return
+	}
+
+	var req vault.ImpersonatedAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	t := tenantFrom(c)
+	if err := t.capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+	if req.TokenScopes == "" {
+		req.TokenScopes = t.tokenScopes()
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).WriteImpersonatedAccount(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("impersonated_account", name).Error("Failed to write impersonated account")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write impersonated account",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get an impersonated account
+func (h *Handler) GetImpersonatedAccount(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	account, err := h.mountClient(c).GetImpersonatedAccount(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Impersonated account not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("impersonated_account", name).Error("Failed to get impersonated account")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get impersonated account",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Impersonated account retrieved successfully",
+		Data:    account,
+	})
+}
+
+// List all impersonated accounts
+func (h *Handler) ListImpersonatedAccounts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	accounts, err := h.mountClient(c).ListImpersonatedAccounts(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list impersonated accounts")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list impersonated accounts",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	secretTypes := make(map[string]string, len(accounts))
+	for _, account := range accounts {
+		secretTypes[account] = vault.ImpersonatedAccountSecretType
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Impersonated accounts retrieved successfully",
+		Data: map[string]interface{}{
+			"impersonated_accounts": accounts,
+			"secret_types":          secretTypes,
+			"count":                 len(accounts),
+		},
+	})
+}
+
+// Delete an impersonated account
+func (h *Handler) DeleteImpersonatedAccount(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).DeleteImpersonatedAccount(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("impersonated_account", name).Error("Failed to delete impersonated account")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete impersonated account",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Impersonated account deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate access token for an impersonated account
+func (h *Handler) GetImpersonatedAccountToken(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	token, err := h.mountClient(c).GetImpersonatedAccountToken(ctx, name)
+	if err != nil {
+		h.log(c).WithError(err).WithField("impersonated_account", name).Error("Failed to get access token")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate access token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindImpersonatedToken, name, token)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Access token generated successfully",
+		Data:    token,
+	})
+}
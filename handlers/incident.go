@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/incident"
+)
+
+// Declare an incident, pausing issuance and queuing revocations
+func (h *Handler) DeclareIncident(c *gin.Context) {
+	var spec incident.Spec
+	if err := c.ShouldBindJSON(&spec); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	status, err := h.incidents.Declare(spec)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid incident",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.log(c).WithField("reason", spec.Reason).Warn("Incident declared")
+
+	c.JSON(http.StatusAccepted, SuccessResponse{
+		Message: "Incident declared",
+		Data:    status,
+	})
+}
+
+// Report the incident state and revocation progress
+func (h *Handler) GetIncident(c *gin.Context) {
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Incident status retrieved successfully",
+		Data:    h.incidents.Status(),
+	})
+}
+
+// Resolve the incident, resuming issuance
+func (h *Handler) ResolveIncident(c *gin.Context) {
+	status := h.incidents.Resolve()
+	h.log(c).Warn("Incident resolved")
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Incident resolved",
+		Data:    status,
+	})
+}
+
+// Middleware refusing to issue secrets for rolesets and tenants paused by an
+// incident
+func (h *Handler) IncidentGuard() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tenantName := ""
+		if t := tenantFrom(c); t != nil {
+			tenantName = t.Name
+		}
+
+		roleset := ""
+		if isRolesetRoute(c.FullPath()) {
+			roleset = c.Param("name")
+		}
+
+		if h.incidents.Paused(tenantName, h.mountClient(c).MountPath(), roleset) {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, ErrorResponse{
+				Error:   "Issuance paused",
+				Details: "an incident is in progress",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"net/netip"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// Middleware applying the API address lists to every /api/v1 request
+func (h *Handler) APIAccessMiddleware() gin.HandlerFunc {
+	return h.ipAccessMiddleware(h.config.Server.IPAccess.API)
+}
+
+// Middleware guarding the admin routes: it applies the admin address lists,
+// on top of the API lists, and admits only authenticated callers holding the
+// admin permission, see adminAllowed
+func (h *Handler) AdminAccessMiddleware() gin.HandlerFunc {
+	// Lists are validated when the configuration is loaded
+	allow, deny, _ := h.config.Server.IPAccess.Admin.Prefixes()
+
+	return func(c *gin.Context) {
+		if h.clientAllowed(c, allow, deny) && h.adminAllowed(c) {
+			c.Next()
+		}
+	}
+}
+
+// ipAccessMiddleware refuses clients whose address is denied by list, or
+// missing from its allow list when one is set.
+func (h *Handler) ipAccessMiddleware(list config.IPAccessListConfig) gin.HandlerFunc {
+	// Lists are validated when the configuration is loaded
+	allow, deny, _ := list.Prefixes()
+
+	return func(c *gin.Context) {
+		if h.clientAllowed(c, allow, deny) {
+			c.Next()
+		}
+	}
+}
+
+// clientAllowed checks the client address against the allow and deny
+// lists. The client address is taken from forwarding headers only when the
+// request comes from a trusted proxy. It aborts the request and returns
+// false if the address is not allowed.
+func (h *Handler) clientAllowed(c *gin.Context, allow, deny []netip.Prefix) bool {
+	if len(allow) == 0 && len(deny) == 0 {
+		return true
+	}
+
+	addr, err := netip.ParseAddr(c.ClientIP())
+	if err != nil || !ipAllowed(addr.Unmap(), allow, deny) {
+		h.log(c).WithFields(logrus.Fields{
+			"ip":   c.ClientIP(),
+			"path": c.Request.URL.Path,
+		}).Warn("Rejected request from disallowed address")
+		c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+			Error: "Client address not allowed",
+		})
+		return false
+	}
+	return true
+}
+
+func ipAllowed(addr netip.Addr, allow, deny []netip.Prefix) bool {
+	for _, prefix := range deny {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	if len(allow) == 0 {
+		return true
+	}
+	for _, prefix := range allow {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// kubernetesRoutes registers the Kubernetes secrets engine routes on group.
+func (h *Handler) kubernetesRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	group.POST("/k8s-tokens/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetKubernetesToken) // POST /api/v1/k8s-tokens/{name}
+}
+
+// Generate a Kubernetes service account token for a role
+func (h *Handler) GetKubernetesToken(c *gin.Context) {
+	role := c.Param("name")
+
+	var req vault.KubernetesTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	token, err := h.vaultClient.GetKubernetesToken(ctx, role, &req)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Kubernetes role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("kubernetes_role", role).Error("Failed to get Kubernetes token")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate Kubernetes token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindKubernetesToken, role, token)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Kubernetes token generated successfully",
+		Data:    token,
+	})
+}
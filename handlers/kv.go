@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+type kvUndeleteRequest struct {
+	Versions []int `json:"versions" binding:"required,min=1"`
+}
+
+// Read a KV secret, its metadata, or the keys of a folder
+func (h *Handler) GetKVSecret(c *gin.Context) {
+	path := kvPath(c)
+
+	// Folders, including the mount root, are listed
+	if path == "" || strings.HasSuffix(path, "/") {
+		h.listKVSecrets(c, path)
+		return
+	}
+	if c.Query("metadata") == "true" {
+		h.getKVMetadata(c, path)
+		return
+	}
+
+	version := 0
+	if raw := c.Query("version"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid version",
+				Details: "version must be a positive integer",
+			})
+			return
+		}
+		version = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	secret, err := h.vaultClient.ReadKV(ctx, path, version)
+	if kvUnsupported(c, err) {
+		return
+	}
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "KV secret not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to read KV secret")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to read KV secret",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	noteAuditIssuance(c, usage.KindKVSecret, secret)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "KV secret retrieved successfully",
+		Data:    secret,
+	})
+}
+
+func (h *Handler) getKVMetadata(c *gin.Context, path string) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	metadata, err := h.vaultClient.GetKVMetadata(ctx, path)
+	if kvUnsupported(c, err) {
+		return
+	}
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "KV secret not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to read KV metadata")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to read KV metadata",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "KV metadata retrieved successfully",
+		Data:    metadata,
+	})
+}
+
+func (h *Handler) listKVSecrets(c *gin.Context, path string) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	keys, err := h.vaultClient.ListKV(ctx, path)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "KV folder not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to list KV secrets")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list KV secrets",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "KV secrets retrieved successfully",
+		Data: map[string]interface{}{
+			"path":  path,
+			"keys":  keys,
+			"count": len(keys),
+		},
+	})
+}
+
+// Write a new version of a KV secret
+func (h *Handler) PutKVSecret(c *gin.Context) {
+	path, ok := requireKVSecretPath(c)
+	if !ok {
+		return
+	}
+
+	var req vault.KVWriteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	version, err := h.vaultClient.WriteKV(ctx, path, &req)
+	if kvUnsupported(c, err) {
+		return
+	}
+	if errors.Is(err, vault.ErrCheckAndSet) {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "KV secret was modified",
+			Details: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to write KV secret")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write KV secret",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "KV secret written successfully",
+		Data:    version,
+	})
+}
+
+// Soft-delete versions of a KV secret
+func (h *Handler) DeleteKVSecret(c *gin.Context) {
+	path, ok := requireKVSecretPath(c)
+	if !ok {
+		return
+	}
+
+	var versions []int
+	if raw := c.Query("versions"); raw != "" {
+		parsed, err := parseVersions(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid versions",
+				Details: err.Error(),
+			})
+			return
+		}
+		versions = parsed
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.DeleteKV(ctx, path, versions)
+	if kvUnsupported(c, err) {
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to delete KV secret")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete KV secret",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "KV secret deleted successfully",
+	})
+}
+
+// Restore soft-deleted versions of a KV secret
+func (h *Handler) UndeleteKVSecret(c *gin.Context) {
+	path, ok := requireKVSecretPath(c)
+	if !ok {
+		return
+	}
+
+	var req kvUndeleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.UndeleteKV(ctx, path, req.Versions)
+	if kvUnsupported(c, err) {
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to undelete KV secret")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to undelete KV secret",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "KV secret undeleted successfully",
+	})
+}
+
+// kvPath returns the secret path of a /kv/*path route without its leading
+// slash.
+func kvPath(c *gin.Context) string {
+	return strings.TrimPrefix(c.Param("path"), "/")
+}
+
+// requireKVSecretPath returns the secret path, answering 400 when the route
+// names a folder instead of a secret.
+func requireKVSecretPath(c *gin.Context) (string, bool) {
+	path := kvPath(c)
+	if path == "" || strings.HasSuffix(path, "/") {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "KV secret path is required",
+		})
+		return "", false
+	}
+	return path, true
+}
+
+// kvUnsupported answers 400 when err is a versioning operation on a KV v1
+// mount.
+func kvUnsupported(c *gin.Context, err error) bool {
+	if !errors.Is(err, vault.ErrKVVersioning) {
+		return false
+	}
+
+	c.JSON(http.StatusBadRequest, ErrorResponse{
+		Error:   "Not supported by the KV mount",
+		Details: err.Error(),
+	})
+	return true
+}
+
+// parseVersions parses a comma-separated list of secret versions.
+func parseVersions(raw string) ([]int, error) {
+	var versions []int
+	for _, part := range strings.Split(raw, ",") {
+		version, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid version %q", part)
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
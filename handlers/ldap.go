@@ -0,0 +1,373 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// ldapRoutes registers the LDAP secrets engine routes on group.
+func (h *Handler) ldapRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	ldap := group.Group("/ldap")
+	{
+		ldap.GET("/roles", h.ListLDAPRoles)                                                          // GET /api/v1/ldap/roles
+		ldap.GET("/roles/:name", h.GetLDAPRole)                                                      // GET /api/v1/ldap/roles/{name}
+		ldap.POST("/roles/:name", h.CreateLDAPRole)                                                  // POST /api/v1/ldap/roles/{name}
+		ldap.PUT("/roles/:name", h.UpdateLDAPRole)                                                   // PUT /api/v1/ldap/roles/{name}
+		ldap.DELETE("/roles/:name", h.DeleteLDAPRole)                                                // DELETE /api/v1/ldap/roles/{name}
+		ldap.POST("/creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetLDAPCredentials) // POST /api/v1/ldap/creds/{name}
+
+		ldap.GET("/static-roles", h.ListLDAPStaticRoles)                                                         // GET /api/v1/ldap/static-roles
+		ldap.GET("/static-roles/:name", h.GetLDAPStaticRole)                                                     // GET /api/v1/ldap/static-roles/{name}
+		ldap.POST("/static-roles/:name", h.CreateLDAPStaticRole)                                                 // POST /api/v1/ldap/static-roles/{name}
+		ldap.PUT("/static-roles/:name", h.UpdateLDAPStaticRole)                                                  // PUT /api/v1/ldap/static-roles/{name}
+		ldap.DELETE("/static-roles/:name", h.DeleteLDAPStaticRole)                                               // DELETE /api/v1/ldap/static-roles/{name}
+		ldap.POST("/static-roles/:name/rotate", h.RotateLDAPStaticRole)                                          // POST /api/v1/ldap/static-roles/{name}/rotate
+		ldap.GET("/static-creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetLDAPStaticCredentials) // GET /api/v1/ldap/static-creds/{name}
+
+		ldap.GET("/library", h.ListLDAPLibraries)                                                                 // GET /api/v1/ldap/library
+		ldap.GET("/library/:name", h.GetLDAPLibrary)                                                              // GET /api/v1/ldap/library/{name}
+		ldap.POST("/library/:name", h.CreateLDAPLibrary)                                                          // POST /api/v1/ldap/library/{name}
+		ldap.PUT("/library/:name", h.UpdateLDAPLibrary)                                                           // PUT /api/v1/ldap/library/{name}
+		ldap.DELETE("/library/:name", h.DeleteLDAPLibrary)                                                        // DELETE /api/v1/ldap/library/{name}
+		ldap.GET("/library/:name/status", h.GetLDAPLibraryStatus)                                                 // GET /api/v1/ldap/library/{name}/status
+		ldap.POST("/library/:name/check-out", secretHeaders, issuanceAudit, incidentGuard, h.CheckOutLDAPAccount) // POST /api/v1/ldap/library/{name}/check-out
+		ldap.POST("/library/:name/check-in", h.CheckInLDAPAccounts)                                               // POST /api/v1/ldap/library/{name}/check-in
+	}
+}
+
+// Create a new dynamic LDAP role
+func (h *Handler) CreateLDAPRole(c *gin.Context) {
+	h.writeLDAPRole(c, http.StatusCreated, "LDAP role created successfully")
+}
+
+// Update an existing dynamic LDAP role
+func (h *Handler) UpdateLDAPRole(c *gin.Context) {
+	h.writeLDAPRole(c, http.StatusOK, "LDAP role updated successfully")
+}
+
+func (h *Handler) writeLDAPRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.LDAPRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteLDAPRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("ldap_role", name).Error("Failed to write LDAP role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write LDAP role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a dynamic LDAP role
+func (h *Handler) GetLDAPRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetLDAPRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_role", name).Error("Failed to get LDAP role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get LDAP role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all dynamic LDAP roles
+func (h *Handler) ListLDAPRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListLDAPRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list LDAP roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list LDAP roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete a dynamic LDAP role
+func (h *Handler) DeleteLDAPRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteLDAPRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("ldap_role", name).Error("Failed to delete LDAP role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete LDAP role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate LDAP credentials for a dynamic role
+func (h *Handler) GetLDAPCredentials(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	credentials, err := h.vaultClient.GetLDAPCredentials(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_role", name).Error("Failed to get LDAP credentials")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate LDAP credentials",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindLDAPCredentials, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP credentials generated successfully",
+		Data:    credentials,
+	})
+}
+
+// Create a new static LDAP role
+func (h *Handler) CreateLDAPStaticRole(c *gin.Context) {
+	h.writeLDAPStaticRole(c, http.StatusCreated, "LDAP static role created successfully")
+}
+
+// Update an existing static LDAP role
+func (h *Handler) UpdateLDAPStaticRole(c *gin.Context) {
+	h.writeLDAPStaticRole(c, http.StatusOK, "LDAP static role updated successfully")
+}
+
+func (h *Handler) writeLDAPStaticRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.LDAPStaticRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteLDAPStaticRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("ldap_static_role", name).Error("Failed to write LDAP static role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write LDAP static role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a static LDAP role
+func (h *Handler) GetLDAPStaticRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetLDAPStaticRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP static role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_static_role", name).Error("Failed to get LDAP static role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get LDAP static role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP static role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all static LDAP roles
+func (h *Handler) ListLDAPStaticRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListLDAPStaticRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list LDAP static roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list LDAP static roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP static roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete a static LDAP role
+func (h *Handler) DeleteLDAPStaticRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteLDAPStaticRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("ldap_static_role", name).Error("Failed to delete LDAP static role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete LDAP static role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP static role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get the current password of a static LDAP role's account
+func (h *Handler) GetLDAPStaticCredentials(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	credentials, err := h.vaultClient.GetLDAPStaticCredentials(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP static role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_static_role", name).Error("Failed to get LDAP static credentials")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get LDAP static credentials",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindLDAPStaticCredentials, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP static credentials retrieved successfully",
+		Data:    credentials,
+	})
+}
+
+// Rotate a static LDAP role's password right away
+func (h *Handler) RotateLDAPStaticRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.RotateLDAPStaticRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP static role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_static_role", name).Error("Failed to rotate LDAP static role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to rotate LDAP static role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP static role rotated successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+type CheckInLDAPAccountsRequest struct {
+	ServiceAccountNames []string `json:"service_account_names,omitempty"`
+}
+
+// Create a new LDAP library set
+func (h *Handler) CreateLDAPLibrary(c *gin.Context) {
+	h.writeLDAPLibrary(c, http.StatusCreated, "LDAP library set created successfully")
+}
+
+// Update an existing LDAP library set
+func (h *Handler) UpdateLDAPLibrary(c *gin.Context) {
+	h.writeLDAPLibrary(c, http.StatusOK, "LDAP library set updated successfully")
+}
+
+func (h *Handler) writeLDAPLibrary(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.LDAPLibraryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteLDAPLibrary(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("ldap_library", name).Error("Failed to write LDAP library set")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write LDAP library set",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get an LDAP library set
+func (h *Handler) GetLDAPLibrary(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	library, err := h.vaultClient.GetLDAPLibrary(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP library set not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_library", name).Error("Failed to get LDAP library set")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get LDAP library set",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP library set retrieved successfully",
+		Data:    library,
+	})
+}
+
+// List all LDAP library sets
+func (h *Handler) ListLDAPLibraries(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	libraries, err := h.vaultClient.ListLDAPLibraries(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list LDAP library sets")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list LDAP library sets",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP library sets retrieved successfully",
+		Data: map[string]interface{}{
+			"libraries": libraries,
+			"count":     len(libraries),
+		},
+	})
+}
+
+// Delete an LDAP library set
+func (h *Handler) DeleteLDAPLibrary(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteLDAPLibrary(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("ldap_library", name).Error("Failed to delete LDAP library set")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete LDAP library set",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP library set deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Check out an available service account of an LDAP library set
+func (h *Handler) CheckOutLDAPAccount(c *gin.Context) {
+	name := c.Param("name")
+
+	var req vault.LDAPCheckOutRequest
+	// The body is optional, but if present it must be valid
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	account, err := h.vaultClient.CheckOutLDAPAccount(ctx, name, &req)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP library set not found",
+		})
+		return
+	}
+	if errors.Is(err, vault.ErrNoAccountsAvailable) {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "No service accounts available",
+			Details: "every service account of the library set is checked out",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_library", name).Error("Failed to check out LDAP service account")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to check out LDAP service account",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindLDAPCheckOut, name, account)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP service account checked out successfully",
+		Data:    account,
+	})
+}
+
+// Check service accounts back in to an LDAP library set
+func (h *Handler) CheckInLDAPAccounts(c *gin.Context) {
+	name := c.Param("name")
+	force := c.Query("force") == "true"
+
+	var req CheckInLDAPAccountsRequest
+	// The body is optional, but if present it must be valid
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	checkedIn, err := h.vaultClient.CheckInLDAPAccounts(ctx, name, req.ServiceAccountNames, force)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP library set not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_library", name).Error("Failed to check in LDAP service accounts")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to check in LDAP service accounts",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP service accounts checked in successfully",
+		Data: map[string]interface{}{
+			"check_ins": checkedIn,
+		},
+	})
+}
+
+// Get which service accounts of an LDAP library set are available
+func (h *Handler) GetLDAPLibraryStatus(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	statuses, err := h.vaultClient.GetLDAPLibraryStatus(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "LDAP library set not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ldap_library", name).Error("Failed to get LDAP library set status")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get LDAP library set status",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "LDAP library set status retrieved successfully",
+		Data: map[string]interface{}{
+			"accounts": statuses,
+		},
+	})
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+type RevokeLeaseRequest struct {
+	LeaseID string `json:"lease_id" binding:"required"`
+}
+
+// Look up the issue time, expiry and renewability of a lease
+func (h *Handler) LookupLease(c *gin.Context) {
+	leaseID := strings.TrimPrefix(c.Param("lease_id"), "/")
+
+	if !h.servesLease(leaseID) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Lease was not issued through hcvapi",
+			Details: leaseID,
+		})
+		return
+	}
+	if !h.leaseInScope(c, leaseID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	lease, err := h.mountClient(c).LookupLease(ctx, leaseID)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Lease not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("lease_id", leaseID).Error("Failed to look up lease")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to look up lease",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Lease retrieved successfully",
+		Data:    lease,
+	})
+}
+
+// Revoke a lease of a secret issued through hcvapi before it expires
+func (h *Handler) RevokeLease(c *gin.Context) {
+	var req RevokeLeaseRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if !h.servesLease(req.LeaseID) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Lease was not issued through hcvapi",
+			Details: req.LeaseID,
+		})
+		return
+	}
+	if !h.leaseInScope(c, req.LeaseID) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).RevokeLease(ctx, req.LeaseID); err != nil {
+		h.log(c).WithError(err).WithField("lease_id", req.LeaseID).Error("Failed to revoke lease")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to revoke lease",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Lease revoked successfully",
+		Data: map[string]string{
+			"lease_id": req.LeaseID,
+		},
+	})
+}
+
+// List the outstanding leases of the keys and tokens issued for a roleset
+func (h *Handler) ListRolesetLeases(c *gin.Context) {
+	rolesetName := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if _, err := h.mountClient(c).GetRoleset(ctx, rolesetName); errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+
+	leases, err := h.mountClient(c).ListRolesetLeases(ctx, rolesetName)
+	if err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to list roleset leases")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list roleset leases",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Roleset leases retrieved successfully",
+		Data: map[string]interface{}{
+			"roleset": rolesetName,
+			"leases":  leases,
+			"count":   len(leases),
+		},
+	})
+}
+
+// Revoke every outstanding lease of a roleset, e.g. to cut off all
+// credentials issued from it during an incident
+func (h *Handler) RevokeRolesetLeases(c *gin.Context) {
+	rolesetName := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 60*time.Second)
+	defer cancel()
+
+	if _, err := h.mountClient(c).GetRoleset(ctx, rolesetName); errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+
+	if err := h.mountClient(c).RevokeRolesetLeases(ctx, rolesetName); err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to revoke roleset leases")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to revoke roleset leases",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Roleset leases revoked successfully",
+		Data: map[string]string{
+			"roleset": rolesetName,
+		},
+	})
+}
+
+// servesLease reports whether a lease belongs to a mount hcvapi serves, so
+// the lease routes cannot reach leases issued elsewhere in Vault.
+func (h *Handler) servesLease(leaseID string) bool {
+	for _, mount := range h.managedMounts() {
+		if strings.HasPrefix(leaseID, mount+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// leaseInScope applies the caller's roleset scope to leases of GCP keys and
+// tokens, the same way the roleset routes do. It writes the error response
+// and returns false if the roleset is outside the scope.
+func (h *Handler) leaseInScope(c *gin.Context, leaseID string) bool {
+	mount, name, ok := h.leaseRoleset(leaseID)
+	if !ok || h.rolesetAllowed(c, mount, name) {
+		return true
+	}
+
+	h.log(c).WithField("lease_id", leaseID).Warn("Lease of a roleset outside the caller's scope")
+	c.JSON(http.StatusForbidden, ErrorResponse{
+		Error:   "Permission denied",
+		Details: "roleset " + name + " is outside the caller's scope",
+	})
+	return false
+}
+
+// leaseRoleset returns the mount and roleset of a GCP key or token lease,
+// whose ID is {mount}/key/{roleset}/{id} or {mount}/token/{roleset}/{id}.
+func (h *Handler) leaseRoleset(leaseID string) (string, string, bool) {
+	for _, mount := range h.vaultClient.MountPaths() {
+		rest, ok := strings.CutPrefix(leaseID, mount+"/")
+		if !ok {
+			continue
+		}
+		segments := strings.Split(rest, "/")
+		if len(segments) >= 3 && (segments[0] == "key" || segments[0] == "token") {
+			return mount, segments[1], true
+		}
+	}
+	return "", "", false
+}
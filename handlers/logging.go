@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/identity"
+	"github.com/kalpesh172000/hcvapi/logging"
+	"github.com/sirupsen/logrus"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// log returns the log entry of the request, carrying its correlation fields.
+func (h *Handler) log(c *gin.Context) *logrus.Entry {
+	return logging.FromContext(c.Request.Context(), h.logger)
+}
+
+// withLogEntry attaches entry to the request context, where both handlers and
+// the Vault client pick it up.
+func withLogEntry(c *gin.Context, entry *logrus.Entry) {
+	c.Request = c.Request.WithContext(logging.WithEntry(c.Request.Context(), entry))
+}
+
+// requestID returns the request id sent by the client, or a new one.
+func requestID(c *gin.Context) string {
+	if id := c.GetHeader(requestIDHeader); id != "" {
+		return id
+	}
+
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(buf)
+}
+
+// Middleware adding the resolved caller, tenant, mount and roleset to the
+// request log entry. It runs after identity and tenant resolution.
+func (h *Handler) RequestContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		caller := identity.FromContext(c)
+		fields := logrus.Fields{
+			"caller":      caller.ID,
+			"auth_method": caller.Method,
+		}
+		if len(caller.Metadata) > 0 {
+			fields["caller_metadata"] = caller.Metadata
+		}
+
+		if t := tenantFrom(c); t != nil {
+			fields["tenant"] = t.Name
+		}
+		if mount := c.Param("mount"); mount != "" {
+			fields["mount"] = mount
+		} else if mount := c.GetHeader(h.config.Server.MountHeader); mount != "" {
+			fields["mount"] = mount
+		}
+		if name := c.Param("name"); name != "" && isRolesetRoute(c.FullPath()) {
+			fields["roleset"] = name
+		}
+
+		withLogEntry(c, h.log(c).WithFields(fields))
+		c.Next()
+	}
+}
+
+// isRolesetRoute reports whether the :name parameter of a route is a roleset.
+func isRolesetRoute(route string) bool {
+	for _, prefix := range []string{"/rolesets/", "/tokens/", "/keys/"} {
+		if strings.Contains(route, prefix) {
+			return true
+		}
+	}
+	return false
+}
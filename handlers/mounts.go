@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+const mountContextKey = "gcp_mount"
+
+// Middleware selecting the GCP mount a request operates on, from the :mount
+// path parameter or else the mount header, and the Vault namespace from the
+// namespace header. Requests naming neither use the default mount in the
+// configured namespace.
+func (h *Handler) MountMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Param("mount")
+		if path == "" {
+			path = c.GetHeader(h.config.Server.MountHeader)
+		}
+		namespace := strings.Trim(c.GetHeader(h.config.Server.NamespaceHeader), "/")
+		if path == "" && namespace == "" {
+			c.Next()
+			return
+		}
+
+		mountClient, ok := h.vaultClient.Mount(path)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusNotFound, ErrorResponse{
+				Error:   "Unknown GCP mount",
+				Details: path,
+			})
+			return
+		}
+
+		if namespace != "" {
+			// Requests may only reach the configured namespace and its children
+			parent := h.vaultClient.Namespace()
+			if parent != "" && namespace != parent && !strings.HasPrefix(namespace, parent+"/") {
+				c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+					Error:   "Namespace outside the configured namespace",
+					Details: namespace,
+				})
+				return
+			}
+			mountClient = mountClient.WithNamespace(namespace)
+		}
+
+		c.Set(mountContextKey, mountClient)
+		c.Next()
+	}
+}
+
+// mountClient returns the Vault client for the mount selected for the request.
+func (h *Handler) mountClient(c *gin.Context) *vault.Client {
+	if value, ok := c.Get(mountContextKey); ok {
+		return value.(*vault.Client)
+	}
+	return h.vaultClient
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// nomadRoutes registers the Nomad secrets engine routes on group.
+func (h *Handler) nomadRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	nomad := group.Group("/nomad")
+	{
+		nomad.GET("/roles", h.ListNomadRoles)                                                    // GET /api/v1/nomad/roles
+		nomad.GET("/roles/:name", h.GetNomadRole)                                                // GET /api/v1/nomad/roles/{name}
+		nomad.POST("/roles/:name", h.CreateNomadRole)                                            // POST /api/v1/nomad/roles/{name}
+		nomad.PUT("/roles/:name", h.UpdateNomadRole)                                             // PUT /api/v1/nomad/roles/{name}
+		nomad.DELETE("/roles/:name", h.DeleteNomadRole)                                          // DELETE /api/v1/nomad/roles/{name}
+		nomad.POST("/creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetNomadToken) // POST /api/v1/nomad/creds/{name}
+	}
+}
+
+// Create a new Nomad role
+func (h *Handler) CreateNomadRole(c *gin.Context) {
+	h.writeNomadRole(c, http.StatusCreated, "Nomad role created successfully")
+}
+
+// Update an existing Nomad role
+func (h *Handler) UpdateNomadRole(c *gin.Context) {
+	h.writeNomadRole(c, http.StatusOK, "Nomad role updated successfully")
+}
+
+func (h *Handler) writeNomadRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.NomadRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if req.Type != "management" && len(req.Policies) == 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: "policies are required for client tokens",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteNomadRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("nomad_role", name).Error("Failed to write Nomad role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write Nomad role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a Nomad role
+func (h *Handler) GetNomadRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetNomadRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Nomad role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("nomad_role", name).Error("Failed to get Nomad role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get Nomad role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Nomad role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all Nomad roles
+func (h *Handler) ListNomadRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListNomadRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list Nomad roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list Nomad roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Nomad roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete a Nomad role
+func (h *Handler) DeleteNomadRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteNomadRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("nomad_role", name).Error("Failed to delete Nomad role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete Nomad role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Nomad role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate a Nomad ACL token for a role
+func (h *Handler) GetNomadToken(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	credentials, err := h.vaultClient.GetNomadToken(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Nomad role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("nomad_role", name).Error("Failed to get Nomad token")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate Nomad token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindNomadToken, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Nomad token generated successfully",
+		Data:    credentials,
+	})
+}
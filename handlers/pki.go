@@ -0,0 +1,312 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// pkiRoutes registers the PKI secrets engine routes on group.
+func (h *Handler) pkiRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	pki := group.Group("/pki")
+	{
+		pki.GET("/roles", h.ListPKIRoles)                                                         // GET /api/v1/pki/roles
+		pki.GET("/roles/:name", h.GetPKIRole)                                                     // GET /api/v1/pki/roles/{name}
+		pki.POST("/roles/:name", h.CreatePKIRole)                                                 // POST /api/v1/pki/roles/{name}
+		pki.PUT("/roles/:name", h.UpdatePKIRole)                                                  // PUT /api/v1/pki/roles/{name}
+		pki.DELETE("/roles/:name", h.DeletePKIRole)                                               // DELETE /api/v1/pki/roles/{name}
+		pki.POST("/issue/:name", secretHeaders, issuanceAudit, incidentGuard, h.IssueCertificate) // POST /api/v1/pki/issue/{name}
+		pki.GET("/crl", h.GetPKICRL)                                                              // GET /api/v1/pki/crl
+		pki.POST("/intermediate/generate", h.GeneratePKIIntermediateCSR)                          // POST /api/v1/pki/intermediate/generate
+		pki.POST("/intermediate/sign", h.SignPKIIntermediate)                                     // POST /api/v1/pki/intermediate/sign
+		pki.POST("/intermediate/set-signed", h.SetPKIIntermediateSigned)                          // POST /api/v1/pki/intermediate/set-signed
+	}
+}
+
+// Issue a TLS certificate and private key for a PKI role
+func (h *Handler) IssueCertificate(c *gin.Context) {
+	role := c.Param("name")
+
+	var req vault.PKIIssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	certificate, err := h.vaultClient.IssueCertificate(ctx, role, &req)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "PKI role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("pki_role", role).Error("Failed to issue certificate")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to issue certificate",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindPKICertificate, role, certificate)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Certificate issued successfully",
+		Data:    certificate,
+	})
+}
+
+// List all PKI roles
+func (h *Handler) ListPKIRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListPKIRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list PKI roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list PKI roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "PKI roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Create a new PKI role
+func (h *Handler) CreatePKIRole(c *gin.Context) {
+	h.writePKIRole(c, http.StatusCreated, "PKI role created successfully")
+}
+
+// Update an existing PKI role
+func (h *Handler) UpdatePKIRole(c *gin.Context) {
+	h.writePKIRole(c, http.StatusOK, "PKI role updated successfully")
+}
+
+func (h *Handler) writePKIRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.PKIRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if len(req.AllowedDomains) == 0 && !req.AllowAnyName {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: "allowed_domains is required unless allow_any_name is set",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WritePKIRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("pki_role", name).Error("Failed to write PKI role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write PKI role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a PKI role
+func (h *Handler) GetPKIRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetPKIRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "PKI role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("pki_role", name).Error("Failed to get PKI role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get PKI role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "PKI role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// Delete a PKI role
+func (h *Handler) DeletePKIRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeletePKIRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("pki_role", name).Error("Failed to delete PKI role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete PKI role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "PKI role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get the PKI mount's certificate revocation list as PEM
+func (h *Handler) GetPKICRL(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	crl, err := h.vaultClient.GetPKICRL(ctx)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "PKI CRL not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to get PKI CRL")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get PKI CRL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/x-pem-file", []byte(crl))
+}
+
+// Generate an intermediate CA key and CSR
+func (h *Handler) GeneratePKIIntermediateCSR(c *gin.Context) {
+	var req vault.PKIIntermediateCSRRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	csr, err := h.vaultClient.GeneratePKIIntermediateCSR(ctx, &req)
+	if err != nil {
+		h.log(c).WithError(err).WithField("common_name", req.CommonName).Error("Failed to generate intermediate CSR")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate intermediate CSR",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Intermediate CSR generated successfully",
+		Data:    csr,
+	})
+}
+
+// Sign an intermediate CSR with the root CA
+func (h *Handler) SignPKIIntermediate(c *gin.Context) {
+	var req vault.PKISignIntermediateRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	certificate, err := h.vaultClient.SignPKIIntermediate(ctx, &req)
+	if err != nil {
+		h.log(c).WithError(err).WithField("common_name", req.CommonName).Error("Failed to sign intermediate CSR")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to sign intermediate CSR",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Intermediate CSR signed successfully",
+		Data:    certificate,
+	})
+}
+
+// Import a signed intermediate certificate into the PKI mount
+func (h *Handler) SetPKIIntermediateSigned(c *gin.Context) {
+	var req vault.PKISetSignedRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.SetPKIIntermediateSigned(ctx, &req); err != nil {
+		h.log(c).WithError(err).Error("Failed to set signed intermediate certificate")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to set signed intermediate certificate",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Signed intermediate certificate set successfully",
+	})
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Create a new Vault ACL policy
+func (h *Handler) CreatePolicy(c *gin.Context) {
+	h.writePolicy(c, http.StatusCreated, "Policy created successfully")
+}
+
+// Update an existing Vault ACL policy
+func (h *Handler) UpdatePolicy(c *gin.Context) {
+	h.writePolicy(c, http.StatusOK, "Policy updated successfully")
+}
+
+func (h *Handler) writePolicy(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.PolicyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WritePolicy(ctx, name, &req); err != nil {
+		h.policyError(c, err, name, "write policy")
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a Vault ACL policy
+func (h *Handler) GetPolicy(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	policy, err := h.vaultClient.GetPolicy(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Policy not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("policy", name).Error("Failed to get policy")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get policy",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Policy retrieved successfully",
+		Data:    policy,
+	})
+}
+
+// List all Vault ACL policies
+func (h *Handler) ListPolicies(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	policies, err := h.vaultClient.ListPolicies(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list policies")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list policies",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Policies retrieved successfully",
+		Data: map[string]interface{}{
+			"policies": policies,
+			"count":    len(policies),
+		},
+	})
+}
+
+// Delete a Vault ACL policy
+func (h *Handler) DeletePolicy(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeletePolicy(ctx, name); err != nil {
+		h.policyError(c, err, name, "delete policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Policy deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// policyError answers a failed policy change, with 400 when Vault rejected
+// the policy itself.
+func (h *Handler) policyError(c *gin.Context, err error, name, operation string) {
+	if errors.Is(err, vault.ErrPolicyRejected) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Policy rejected by Vault",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.log(c).WithError(err).WithField("policy", name).Error("Failed to " + operation)
+	c.JSON(http.StatusInternalServerError, ErrorResponse{
+		Error:   "Failed to " + operation,
+		Details: err.Error(),
+	})
+}
@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/identity"
+)
+
+// reserveKeyQuota counts one service account key of a roleset against the
+// caller's quota, answering 429 when the quota of the current window is used
+// up. Windows are fixed, starting at multiples of the window length. The
+// returned refund gives the reservation back when issuance fails.
+func (h *Handler) reserveKeyQuota(c *gin.Context, roleset string) (refund func(), ok bool) {
+	quota, found := h.config.GCP.KeyQuotas[roleset]
+	if !found {
+		quota, found = h.config.GCP.KeyQuotas["*"]
+	}
+	if !found {
+		return func() {}, true
+	}
+
+	caller := identity.FromContext(c)
+	now := time.Now()
+	windowStart := now.Truncate(quota.Window)
+	key := fmt.Sprintf("quota:keys:%s:%s:%s:%d", h.mountClient(c).MountPath(), roleset, caller.ID, windowStart.Unix())
+
+	count, err := h.cache.Increment(c.Request.Context(), key, 1, quota.Window)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to count service account key against quota")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error: "Key quota unavailable",
+		})
+		return nil, false
+	}
+
+	refund = func() {
+		if _, err := h.cache.Increment(c.Request.Context(), key, -1, quota.Window); err != nil {
+			h.log(c).WithError(err).Warn("Failed to refund service account key quota")
+		}
+	}
+
+	if count > int64(quota.Limit) {
+		refund()
+
+		resetAt := windowStart.Add(quota.Window)
+		h.log(c).WithFields(logrus.Fields{
+			"roleset": roleset,
+			"limit":   quota.Limit,
+		}).Warn("Service account key quota exceeded")
+		c.Header("Retry-After", strconv.Itoa(int(time.Until(resetAt).Seconds())+1))
+		c.JSON(http.StatusTooManyRequests, ErrorResponse{
+			Error: "Service account key quota exceeded",
+			Details: fmt.Sprintf("%s may generate %d keys for roleset %s per %s; the quota resets at %s",
+				caller.ID, quota.Limit, roleset, quota.Window, resetAt.UTC().Format(time.RFC3339)),
+		})
+		return nil, false
+	}
+
+	return refund, true
+}
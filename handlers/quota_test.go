@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/identity"
+)
+
+func TestReserveKeyQuota(t *testing.T) {
+	tests := []struct {
+		name string
+		// Whether each request's issuance fails, refunding its reservation
+		failures []bool
+		want     []int
+	}{
+		{
+			name:     "within quota",
+			failures: []bool{false, false},
+			want:     []int{http.StatusOK, http.StatusOK},
+		},
+		{
+			name:     "quota used up",
+			failures: []bool{false, false, false},
+			want:     []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+		},
+		{
+			name:     "failed issuance is refunded",
+			failures: []bool{true, true, false, false},
+			want:     []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK, http.StatusOK},
+		},
+		{
+			name:     "rejected requests do not use up the quota",
+			failures: []bool{false, false, false, false},
+			want:     []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests, http.StatusTooManyRequests},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.GCP.KeyQuotas = map[string]config.KeyQuotaConfig{"*": {Limit: 2, Window: time.Hour}}
+			h := newTestHandler(t, cfg)
+
+			var fail bool
+			router := gin.New()
+			router.POST("/api/v1/keys/:name", withCaller(&identity.Caller{ID: "ci", Method: "test"}), func(c *gin.Context) {
+				refund, ok := h.reserveKeyQuota(c, c.Param("name"))
+				if !ok {
+					return
+				}
+				if fail {
+					refund()
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				c.Status(http.StatusOK)
+			})
+
+			for i, failure := range tt.failures {
+				fail = failure
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/keys/my-roleset", nil))
+				if w.Code != tt.want[i] {
+					t.Fatalf("request %d: got %d, want %d", i+1, w.Code, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReserveKeyQuotaIsPerCallerAndRoleset(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.GCP.KeyQuotas = map[string]config.KeyQuotaConfig{
+		"scarce": {Limit: 1, Window: time.Hour},
+	}
+	h := newTestHandler(t, cfg)
+
+	reserve := func(caller, roleset string) int {
+		router := gin.New()
+		router.POST("/api/v1/keys/:name", withCaller(&identity.Caller{ID: caller, Method: "test"}), func(c *gin.Context) {
+			if _, ok := h.reserveKeyQuota(c, c.Param("name")); ok {
+				c.Status(http.StatusOK)
+			}
+		})
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/keys/"+roleset, nil))
+		return w.Code
+	}
+
+	steps := []struct {
+		caller  string
+		roleset string
+		want    int
+	}{
+		{caller: "ci", roleset: "scarce", want: http.StatusOK},
+		{caller: "ci", roleset: "scarce", want: http.StatusTooManyRequests},
+		{caller: "deployer", roleset: "scarce", want: http.StatusOK},
+		// Rolesets without a quota and no "*" fallback are unlimited
+		{caller: "ci", roleset: "plentiful", want: http.StatusOK},
+		{caller: "ci", roleset: "plentiful", want: http.StatusOK},
+	}
+	for i, step := range steps {
+		if got := reserve(step.caller, step.roleset); got != step.want {
+			t.Fatalf("step %d (%s on %s): got %d, want %d", i+1, step.caller, step.roleset, got, step.want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// rabbitmqRoutes registers the RabbitMQ secrets engine routes on group.
+func (h *Handler) rabbitmqRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	rabbitmq := group.Group("/rabbitmq")
+	{
+		rabbitmq.GET("/roles", h.ListRabbitMQRoles)                                                          // GET /api/v1/rabbitmq/roles
+		rabbitmq.GET("/roles/:name", h.GetRabbitMQRole)                                                      // GET /api/v1/rabbitmq/roles/{name}
+		rabbitmq.POST("/roles/:name", h.CreateRabbitMQRole)                                                  // POST /api/v1/rabbitmq/roles/{name}
+		rabbitmq.PUT("/roles/:name", h.UpdateRabbitMQRole)                                                   // PUT /api/v1/rabbitmq/roles/{name}
+		rabbitmq.DELETE("/roles/:name", h.DeleteRabbitMQRole)                                                // DELETE /api/v1/rabbitmq/roles/{name}
+		rabbitmq.POST("/creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetRabbitMQCredentials) // POST /api/v1/rabbitmq/creds/{name}
+	}
+}
+
+// Create a new RabbitMQ role
+func (h *Handler) CreateRabbitMQRole(c *gin.Context) {
+	h.writeRabbitMQRole(c, http.StatusCreated, "RabbitMQ role created successfully")
+}
+
+// Update an existing RabbitMQ role
+func (h *Handler) UpdateRabbitMQRole(c *gin.Context) {
+	h.writeRabbitMQRole(c, http.StatusOK, "RabbitMQ role updated successfully")
+}
+
+func (h *Handler) writeRabbitMQRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.RabbitMQRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteRabbitMQRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("rabbitmq_role", name).Error("Failed to write RabbitMQ role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write RabbitMQ role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a RabbitMQ role
+func (h *Handler) GetRabbitMQRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetRabbitMQRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "RabbitMQ role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("rabbitmq_role", name).Error("Failed to get RabbitMQ role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get RabbitMQ role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "RabbitMQ role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all RabbitMQ roles
+func (h *Handler) ListRabbitMQRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListRabbitMQRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list RabbitMQ roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list RabbitMQ roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "RabbitMQ roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete a RabbitMQ role
+func (h *Handler) DeleteRabbitMQRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteRabbitMQRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("rabbitmq_role", name).Error("Failed to delete RabbitMQ role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete RabbitMQ role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "RabbitMQ role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate RabbitMQ credentials for a role
+func (h *Handler) GetRabbitMQCredentials(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	credentials, err := h.vaultClient.GetRabbitMQCredentials(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "RabbitMQ role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("rabbitmq_role", name).Error("Failed to get RabbitMQ credentials")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate RabbitMQ credentials",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindRabbitMQCredentials, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "RabbitMQ credentials generated successfully",
+		Data:    credentials,
+	})
+}
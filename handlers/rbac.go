@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/identity"
+)
+
+// Middleware enforcing the permissions granted by RBAC roles. The permission
+// a route requires is derived from its path and method, see routePermission.
+// It runs after identity resolution and does nothing while RBAC is disabled.
+func (h *Handler) AuthorizationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !h.config.RBAC.Enabled {
+			c.Next()
+			return
+		}
+
+		resource, action := routePermission(c)
+		if resource == "" {
+			// Unmatched routes answer 404 on their own
+			c.Next()
+			return
+		}
+
+		caller := identity.FromContext(c)
+		roles := callerRoles(h.config.RBAC, caller)
+		for _, role := range roles {
+			if role.grants(resource, action) {
+				c.Next()
+				return
+			}
+		}
+
+		h.log(c).WithFields(logrus.Fields{
+			"permission": resource + ":" + action,
+			"roles":      len(roles),
+		}).Warn("Permission denied")
+		c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+			Error:   "Permission denied",
+			Details: "requires " + resource + ":" + action,
+		})
+	}
+}
+
+// adminAllowed admits authenticated callers holding admin:read, for GET and
+// HEAD, or admin:write through one of their roles. Roles count here even
+// while RBAC is disabled, so admin routes are never open to anonymous
+// callers or to every authenticated one. It aborts the request and returns
+// false otherwise.
+func (h *Handler) adminAllowed(c *gin.Context) bool {
+	caller := identity.FromContext(c)
+	if caller == identity.Anonymous {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, ErrorResponse{
+			Error:   "Authentication required",
+			Details: "admin routes need an authenticated caller",
+		})
+		return false
+	}
+
+	action := "write"
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		action = "read"
+	}
+	for _, role := range callerRoles(h.config.RBAC, caller) {
+		if role.grants("admin", action) {
+			return true
+		}
+	}
+
+	h.log(c).WithField("permission", "admin:"+action).Warn("Admin permission denied")
+	c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+		Error:   "Permission denied",
+		Details: "requires admin:" + action,
+	})
+	return false
+}
+
+// Middleware restricting roleset, token and key routes to the rolesets the
+// caller's roles are scoped to. A route on a roleset is allowed when one of
+// the caller's roles grants the route's permission and is either unscoped
+// or scoped to the roleset, its mount and its namespace, see role.scopes.
+func (h *Handler) RolesetScopeMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		name := c.Param("name")
+		if !h.config.RBAC.Enabled || name == "" {
+			c.Next()
+			return
+		}
+
+		if !h.rolesetAllowed(c, h.mountClient(c).MountPath(), name) {
+			h.log(c).Warn("Roleset outside the caller's scope")
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+				Error:   "Permission denied",
+				Details: "roleset " + name + " is outside the caller's scope",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rolesetAllowed reports whether the caller may use the route's permission
+// on the named roleset of mount, in the namespace the request operates in.
+// It is always true while RBAC is disabled.
+func (h *Handler) rolesetAllowed(c *gin.Context, mount, name string) bool {
+	if !h.config.RBAC.Enabled {
+		return true
+	}
+
+	resource, action := routePermission(c)
+	namespace := h.mountClient(c).Namespace()
+	for _, role := range callerRoles(h.config.RBAC, identity.FromContext(c)) {
+		if role.grants(resource, action) && role.scopes(namespace, mount, name) && role.pinned(namespace, h.vaultClient.Namespace()) {
+			return true
+		}
+	}
+	return false
+}
+
+// routePermission returns the resource and action a request needs. The
+// resource is the first segment of the route after /api/v1, skipping an
+// explicit /mounts/{mount} prefix, and the action is read for GET and HEAD
+// and write otherwise.
+func routePermission(c *gin.Context) (string, string) {
+	route := strings.TrimPrefix(c.FullPath(), "/api/v1/")
+	if route == c.FullPath() {
+		return "", ""
+	}
+
+	segments := strings.Split(route, "/")
+	if segments[0] == "mounts" && len(segments) > 2 {
+		segments = segments[2:]
+	}
+
+	action := "write"
+	if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+		action = "read"
+	}
+	return segments[0], action
+}
+
+type role config.RoleConfig
+
+// callerRoles returns the roles granted to the caller by ID or by group.
+func callerRoles(cfg config.RBACConfig, caller *identity.Caller) []role {
+	var roles []role
+	for _, candidate := range cfg.Roles {
+		if containsFold(candidate.Callers, caller.ID) || intersects(candidate.Groups, caller.Groups) {
+			roles = append(roles, role(candidate))
+		}
+	}
+	return roles
+}
+
+func (r role) grants(resource, action string) bool {
+	for _, permission := range r.Permissions {
+		// Permissions are validated when the configuration is loaded
+		grantedResource, grantedAction, _ := strings.Cut(permission, ":")
+		if (grantedResource == "*" || grantedResource == resource) && (grantedAction == "*" || grantedAction == action) {
+			return true
+		}
+	}
+	return false
+}
+
+// scopes reports whether the role covers the named roleset of mount in
+// namespace. Unset scopes cover everything.
+func (r role) scopes(namespace, mount, name string) bool {
+	return matchesAny(r.Namespaces, namespace) && matchesAny(r.Mounts, mount) && matchesAny(r.Rolesets, name)
+}
+
+// pinned reports whether a role scoped to rolesets or mounts, but not to
+// namespaces, is used in the configured namespace: the same names may
+// belong to other teams in child namespaces.
+func (r role) pinned(namespace, configured string) bool {
+	if len(r.Namespaces) > 0 || (len(r.Rolesets) == 0 && len(r.Mounts) == 0) {
+		return true
+	}
+	return namespace == configured
+}
+
+// matchesAny reports whether value matches one of the patterns, or there are
+// none.
+func matchesAny(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		// Patterns are validated when the configuration is loaded
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether values holds value, ignoring case since
+// configuration keys such as API key names are lowercased when loaded.
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func intersects(a, b []string) bool {
+	for _, value := range a {
+		for _, other := range b {
+			if value == other {
+				return true
+			}
+		}
+	}
+	return false
+}
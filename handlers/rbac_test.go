@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/cache"
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/identity"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestHandler creates a handler for cfg backed by an in-memory cache. The
+// Vault client is never logged in, so only routes not calling Vault work.
+func newTestHandler(t *testing.T, cfg *config.Config) *Handler {
+	t.Helper()
+
+	if cfg.GCP.MountPath == "" {
+		cfg.GCP.MountPath = "gcp"
+	}
+	cfg.Server.NamespaceHeader = "X-Vault-Namespace"
+	cfg.Server.MountHeader = "X-GCP-Mount"
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	store := cache.NewMemory()
+	client, err := vault.NewClient(cfg, store, logger)
+	if err != nil {
+		t.Fatalf("failed to create vault client: %v", err)
+	}
+	return NewHandler(cfg, Services{Vault: client, Cache: store}, logger)
+}
+
+// withCaller sets caller as the request's identity, as the identity chain
+// would.
+func withCaller(caller *identity.Caller) gin.HandlerFunc {
+	return identity.NewChain(false, logrus.New(), callerResolver{caller}).Middleware()
+}
+
+type callerResolver struct {
+	caller *identity.Caller
+}
+
+func (r callerResolver) Name() string { return "test" }
+
+func (r callerResolver) Resolve(c *gin.Context) (*identity.Caller, error) {
+	return r.caller, nil
+}
+
+func TestRoutePermission(t *testing.T) {
+	tests := []struct {
+		route    string
+		method   string
+		resource string
+		action   string
+	}{
+		{route: "/api/v1/tokens/:name", method: http.MethodPost, resource: "tokens", action: "write"},
+		{route: "/api/v1/rolesets", method: http.MethodGet, resource: "rolesets", action: "read"},
+		{route: "/api/v1/rolesets/:name", method: http.MethodHead, resource: "rolesets", action: "read"},
+		{route: "/api/v1/rolesets/:name", method: http.MethodDelete, resource: "rolesets", action: "write"},
+		{route: "/api/v1/mounts/:mount/keys/:name", method: http.MethodPost, resource: "keys", action: "write"},
+		{route: "/api/v1/admin/incident", method: http.MethodGet, resource: "admin", action: "read"},
+		{route: "/health", method: http.MethodGet},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.route, func(t *testing.T) {
+			var resource, action string
+			router := gin.New()
+			router.Handle(tt.method, tt.route, func(c *gin.Context) {
+				resource, action = routePermission(c)
+			})
+
+			path := strings.NewReplacer(":name", "my-roleset", ":mount", "gcp").Replace(tt.route)
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, path, nil))
+
+			if resource != tt.resource || action != tt.action {
+				t.Fatalf("got %q:%q, want %q:%q", resource, action, tt.resource, tt.action)
+			}
+		})
+	}
+}
+
+func TestRoleScopes(t *testing.T) {
+	const configured = "admin"
+
+	tests := []struct {
+		name      string
+		role      config.RoleConfig
+		namespace string
+		mount     string
+		roleset   string
+		want      bool
+	}{
+		{name: "unscoped", role: config.RoleConfig{}, namespace: "admin/team-b", mount: "gcp", roleset: "anything", want: true},
+		{name: "matching roleset", role: config.RoleConfig{Rolesets: []string{"team-a-*"}}, namespace: configured, mount: "gcp", roleset: "team-a-ci", want: true},
+		{name: "other roleset", role: config.RoleConfig{Rolesets: []string{"team-a-*"}}, namespace: configured, mount: "gcp", roleset: "team-b-ci", want: false},
+		{name: "roleset scope in a child namespace", role: config.RoleConfig{Rolesets: []string{"team-a-*"}}, namespace: "admin/team-b", mount: "gcp", roleset: "team-a-ci", want: false},
+		{name: "mount scope in a child namespace", role: config.RoleConfig{Mounts: []string{"gcp"}}, namespace: "admin/team-b", mount: "gcp", roleset: "ci", want: false},
+		{name: "pinned namespace", role: config.RoleConfig{Rolesets: []string{"team-a-*"}, Namespaces: []string{"admin/team-a"}}, namespace: "admin/team-a", mount: "gcp", roleset: "team-a-ci", want: true},
+		{name: "other namespace", role: config.RoleConfig{Rolesets: []string{"team-a-*"}, Namespaces: []string{"admin/team-a"}}, namespace: "admin/team-b", mount: "gcp", roleset: "team-a-ci", want: false},
+		{name: "matching mount", role: config.RoleConfig{Mounts: []string{"gcp-*"}}, namespace: configured, mount: "gcp-team-a", roleset: "ci", want: true},
+		{name: "other mount", role: config.RoleConfig{Mounts: []string{"gcp-*"}}, namespace: configured, mount: "gcp", roleset: "ci", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := role(tt.role)
+			if got := r.scopes(tt.namespace, tt.mount, tt.roleset) && r.pinned(tt.namespace, configured); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRolesetScopeMiddlewareAppliesNamespaceHeader(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Vault.Namespace = "admin"
+	cfg.RBAC = config.RBACConfig{
+		Enabled: true,
+		Roles: map[string]config.RoleConfig{
+			"team-a": {Permissions: []string{"tokens:write"}, Callers: []string{"ci"}, Rolesets: []string{"team-a-*"}},
+		},
+	}
+	h := newTestHandler(t, cfg)
+
+	router := gin.New()
+	router.POST("/api/v1/tokens/:name", withCaller(&identity.Caller{ID: "ci", Method: "test"}), h.MountMiddleware(), h.RolesetScopeMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name      string
+		roleset   string
+		namespace string
+		want      int
+	}{
+		{name: "own roleset", roleset: "team-a-ci", want: http.StatusOK},
+		{name: "other roleset", roleset: "team-b-ci", want: http.StatusForbidden},
+		{name: "own roleset name in a child namespace", roleset: "team-a-ci", namespace: "admin/team-b", want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/tokens/"+tt.roleset, nil)
+			if tt.namespace != "" {
+				req.Header.Set("X-Vault-Namespace", tt.namespace)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminAccessMiddleware(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.RBAC.Roles = map[string]config.RoleConfig{
+		"operators": {Permissions: []string{"admin:*"}, Groups: []string{"platform-team"}},
+		"auditors":  {Permissions: []string{"admin:read"}, Callers: []string{"auditor"}},
+	}
+	h := newTestHandler(t, cfg)
+
+	tests := []struct {
+		name   string
+		caller *identity.Caller
+		method string
+		want   int
+	}{
+		{name: "anonymous", caller: identity.Anonymous, method: http.MethodGet, want: http.StatusUnauthorized},
+		{name: "no admin role", caller: &identity.Caller{ID: "ci", Method: "test"}, method: http.MethodGet, want: http.StatusForbidden},
+		{name: "admin group", caller: &identity.Caller{ID: "alice", Groups: []string{"platform-team"}, Method: "test"}, method: http.MethodPost, want: http.StatusOK},
+		{name: "read-only admin reading", caller: &identity.Caller{ID: "auditor", Method: "test"}, method: http.MethodGet, want: http.StatusOK},
+		{name: "read-only admin writing", caller: &identity.Caller{ID: "auditor", Method: "test"}, method: http.MethodPost, want: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Handle(tt.method, "/api/v1/admin/incident", withCaller(tt.caller), h.AdminAccessMiddleware(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, "/api/v1/admin/incident", nil))
+			if w.Code != tt.want {
+				t.Fatalf("got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
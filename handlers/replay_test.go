@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+const testReplaySecret = "replay-secret"
+
+// signedRequest builds a request signed the way admin clients sign them.
+func signedRequest(method, path, body, nonce string, at time.Time, secret string) *http.Request {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	bodyHash := sha256.Sum256([]byte(body))
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{method, path, timestamp, nonce, hex.EncodeToString(bodyHash[:])}, "\n")))
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(timestampHeader, timestamp)
+	req.Header.Set(nonceHeader, nonce)
+	req.Header.Set(signatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestReplayProtectionMiddleware(t *testing.T) {
+	const path = "/api/v1/admin/rotate"
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		req  func() *http.Request
+		want int
+	}{
+		{
+			name: "valid signature",
+			req: func() *http.Request {
+				return signedRequest(http.MethodPost, path, `{}`, "n-valid", now, testReplaySecret)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "missing headers",
+			req:  func() *http.Request { return httptest.NewRequest(http.MethodPost, path, nil) },
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "wrong secret",
+			req:  func() *http.Request { return signedRequest(http.MethodPost, path, `{}`, "n-secret", now, "guessed") },
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "tampered body",
+			req: func() *http.Request {
+				req := signedRequest(http.MethodPost, path, `{}`, "n-body", now, testReplaySecret)
+				req.Body = httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"force":true}`)).Body
+				return req
+			},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "within skew",
+			req: func() *http.Request {
+				return signedRequest(http.MethodPost, path, `{}`, "n-skew", now.Add(-4*time.Minute), testReplaySecret)
+			},
+			want: http.StatusOK,
+		},
+		{
+			name: "too old",
+			req: func() *http.Request {
+				return signedRequest(http.MethodPost, path, `{}`, "n-old", now.Add(-6*time.Minute), testReplaySecret)
+			},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "too far in the future",
+			req: func() *http.Request {
+				return signedRequest(http.MethodPost, path, `{}`, "n-future", now.Add(6*time.Minute), testReplaySecret)
+			},
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid timestamp",
+			req: func() *http.Request {
+				req := signedRequest(http.MethodPost, path, `{}`, "n-timestamp", now, testReplaySecret)
+				req.Header.Set(timestampHeader, "yesterday")
+				return req
+			},
+			want: http.StatusUnauthorized,
+		},
+	}
+
+	cfg := &config.Config{}
+	cfg.Server.ReplayProtection = config.ReplayProtectionConfig{Enabled: true, Secret: testReplaySecret, MaxSkew: 5 * time.Minute}
+	h := newTestHandler(t, cfg)
+
+	router := gin.New()
+	router.POST(path, h.ReplayProtectionMiddleware(), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, tt.req())
+			if w.Code != tt.want {
+				t.Fatalf("got %d, want %d: %s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("reused nonce", func(t *testing.T) {
+		for i, want := range []int{http.StatusOK, http.StatusUnauthorized} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, signedRequest(http.MethodPost, path, `{}`, "n-reused", now, testReplaySecret))
+			if w.Code != want {
+				t.Fatalf("attempt %d: got %d, want %d", i+1, w.Code, want)
+			}
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// recordIssuance counts an issued secret for usage reports and notes it for
+// the audit log. secret is the response carrying the secret, if any.
+func (h *Handler) recordIssuance(c *gin.Context, kind, name string, secret interface{}) {
+	noteAuditIssuance(c, kind, secret)
+
+	tenantName := ""
+	if t := tenantFrom(c); t != nil {
+		tenantName = t.Name
+	}
+	mount := ""
+	if _, ok := c.Get(mountContextKey); ok {
+		mount = h.mountClient(c).MountPath()
+	}
+	h.usage.Record(tenantName, kind, mount, name)
+}
+
+// Generate a usage report of the current period on demand
+func (h *Handler) RunReport(c *gin.Context) {
+	report := h.reports.Generate(false)
+
+	if c.Query("export") != "true" {
+		c.JSON(http.StatusOK, SuccessResponse{
+			Message: "Report generated successfully",
+			Data:    report,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	results, err := h.reports.Export(ctx, report)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to export report")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to export report",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Report generated successfully",
+		Data: map[string]interface{}{
+			"report":  report,
+			"exports": results,
+		},
+	})
+}
+
+// Export the access review of all rolesets as JSON or CSV
+func (h *Handler) GetAccessReview(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid format",
+			Details: "format must be json or csv",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	review, err := h.reports.AccessReview(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to build access review")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to build access review",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if format == "json" {
+		c.JSON(http.StatusOK, SuccessResponse{
+			Message: "Access review generated successfully",
+			Data:    review,
+		})
+		return
+	}
+
+	body, contentType, err := review.Encode(format)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to encode access review")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to encode access review",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", review.Filename(format)))
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// List rolesets nothing has been issued from for the configured period
+func (h *Handler) GetStaleRolesets(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	report, err := h.reports.StaleRolesets(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to find stale rolesets")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to find stale rolesets",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Stale rolesets retrieved successfully",
+		Data:    report,
+	})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware rejecting requests before they reach handlers when their body
+// is larger than server.max_body_size (413), when a write request carries a
+// body that is not declared as JSON (415), or when that body is not a single
+// well-formed JSON value (400). Requests without a body pass, since several
+// write endpoints take an optional one.
+func (h *Handler) RequestValidationMiddleware() gin.HandlerFunc {
+	maxBodySize := h.config.Server.MaxBodySize
+
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBodySize)
+
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error:   "Request body too large",
+				Details: fmt.Sprintf("request bodies are limited to %d bytes", maxBodySize),
+			})
+			return
+		}
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Failed to read request body",
+				Details: err.Error(),
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if len(bytes.TrimSpace(body)) == 0 {
+			c.Next()
+			return
+		}
+
+		if mediaType, _, _ := mime.ParseMediaType(c.ContentType()); mediaType != "application/json" {
+			c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, ErrorResponse{
+				Error:   "Unsupported content type",
+				Details: "request bodies must be sent as Content-Type: application/json",
+			})
+			return
+		}
+
+		if err := checkJSON(body); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Malformed JSON",
+				Details: err.Error(),
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// checkJSON reports why body is not a single JSON value, with the byte
+// offset of a syntax error.
+func checkJSON(body []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+
+	var value json.RawMessage
+	if err := dec.Decode(&value); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return fmt.Errorf("%s at byte %d", syntaxErr, syntaxErr.Offset)
+		}
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return errors.New("unexpected end of JSON input")
+		}
+		return err
+	}
+
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after JSON value at byte %d", dec.InputOffset())
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// applyRolesetDefaults fills fields omitted from a roleset creation request
+// from the configured defaults and returns the values it applied. Tenant
+// defaults, already applied, take precedence, and defaulted TTLs are held to
+// the tenant's limit like explicit ones. It writes the error response and
+// returns false if a default is not allowed.
+func (h *Handler) applyRolesetDefaults(c *gin.Context, req *vault.RolesetRequest) (map[string]string, bool) {
+	defaults := h.config.GCP.RolesetDefaults
+	applied := map[string]string{}
+
+	ttls := defaults.SecretTypes[req.SecretType]
+	if req.TTL == "" && ttls.TTL != "" {
+		req.TTL = ttls.TTL
+		applied["ttl"] = ttls.TTL
+	}
+	if req.MaxTTL == "" && ttls.MaxTTL != "" {
+		req.MaxTTL = ttls.MaxTTL
+		applied["max_ttl"] = ttls.MaxTTL
+	}
+
+	if req.SecretType == "access_token" && req.TokenScopes == "" {
+		if scopes := defaults.ProjectScopes[req.Project]; scopes != "" {
+			req.TokenScopes = scopes
+			applied["token_scopes"] = scopes
+		}
+	}
+
+	t := tenantFrom(c)
+	for _, field := range []string{"ttl", "max_ttl"} {
+		if ttl, ok := applied[field]; ok {
+			if err := t.checkTTL(ttl); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "default " + field + ": " + err.Error()})
+				return nil, false
+			}
+		}
+	}
+
+	return applied, true
+}
@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Rotate the GCP credentials held by Vault
+func (h *Handler) RotateRootCredentials(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	rotation, err := h.mountClient(c).RotateRootCredentials(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to rotate GCP root credentials")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to rotate GCP root credentials",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "GCP root credentials rotated successfully",
+		Data:    rotation,
+	})
+}
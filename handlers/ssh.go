@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// sshRoutes registers the SSH secrets engine routes on group.
+func (h *Handler) sshRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	ssh := group.Group("/ssh")
+	{
+		ssh.POST("/sign/:name", secretHeaders, issuanceAudit, incidentGuard, h.SignSSHKey) // POST /api/v1/ssh/sign/{name}
+	}
+}
+
+// Sign an SSH public key with a role of the SSH engine
+func (h *Handler) SignSSHKey(c *gin.Context) {
+	role := c.Param("name")
+
+	var req vault.SSHSignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&req.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	certificate, err := h.vaultClient.SignSSHKey(ctx, role, &req)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "SSH role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("ssh_role", role).Error("Failed to sign SSH key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to sign SSH key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindSSHCertificate, role, certificate)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "SSH key signed successfully",
+		Data:    certificate,
+	})
+}
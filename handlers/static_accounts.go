@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/kalpesh172000/hcvapi/webhook"
+)
+
+// Create a new static account
+func (h *Handler) CreateStaticAccount(c *gin.Context) {
+	h.writeStaticAccount(c, http.StatusCreated, "Static account created successfully")
+}
+
+// Update an existing static account
+func (h *Handler) UpdateStaticAccount(c *gin.Context) {
+	h.writeStaticAccount(c, http.StatusOK, "Static account updated successfully")
+}
+
+func (h *Handler) writeStaticAccount(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+	if name == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Static account name is required",
+		})
+		return
+	}
+
+	var req vault.StaticAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if !validateBindings(c, req.Bindings) {
+		return
+	}
+
+	if req.TokenScopes == "" && req.SecretType == "access_token" {
+		req.TokenScopes = tenantFrom(c).tokenScopes()
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).WriteStaticAccount(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("static_account", name).Error("Failed to write static account")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write static account",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a static account
+func (h *Handler) GetStaticAccount(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	account, err := h.mountClient(c).GetStaticAccount(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Static account not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("static_account", name).Error("Failed to get static account")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get static account",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Static account retrieved successfully",
+		Data:    account,
+	})
+}
+
+// List all static accounts
+func (h *Handler) ListStaticAccounts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	accounts, err := h.mountClient(c).ListStaticAccounts(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list static accounts")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list static accounts",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Static accounts retrieved successfully",
+		Data: map[string]interface{}{
+			"static_accounts": accounts,
+			"secret_types":    h.mountClient(c).StaticAccountSecretTypes(ctx, accounts),
+			"count":           len(accounts),
+		},
+	})
+}
+
+// Delete a static account
+func (h *Handler) DeleteStaticAccount(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.mountClient(c).DeleteStaticAccount(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("static_account", name).Error("Failed to delete static account")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete static account",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Static account deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate access token for a static account
+func (h *Handler) GetStaticAccountToken(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	token, err := h.mountClient(c).GetStaticAccountToken(ctx, name)
+	if err != nil {
+		h.log(c).WithError(err).WithField("static_account", name).Error("Failed to get access token")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate access token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindStaticToken, name, token)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Access token generated successfully",
+		Data:    token,
+	})
+}
+
+// Generate service account key for a static account
+func (h *Handler) GetStaticAccountKey(c *gin.Context) {
+	name := c.Param("name")
+
+	var keyReq vault.KeyRequest
+	// The body is optional, but if present it must be valid
+	if err := c.ShouldBindJSON(&keyReq); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if err := tenantFrom(c).capTTL(&keyReq.TTL); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid TTL",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	key, err := h.mountClient(c).GetStaticAccountKey(ctx, name, &keyReq)
+	if err != nil {
+		h.log(c).WithError(err).WithField("static_account", name).Error("Failed to get service account key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate service account key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindStaticKey, name, key)
+	h.notifyWebhooks(c, &webhook.Event{
+		Type:          webhook.EventKeyIssued,
+		StaticAccount: name,
+		Data:          keyEventData(key),
+	})
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Service account key generated successfully",
+		Data:    key,
+	})
+}
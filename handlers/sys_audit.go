@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// List the audit devices enabled in Vault
+func (h *Handler) ListAuditDevices(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	devices, err := h.vaultClient.ListAuditDevices(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list audit devices")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list audit devices",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Audit devices retrieved successfully",
+		Data: map[string]interface{}{
+			"audit_devices": devices,
+			"count":         len(devices),
+		},
+	})
+}
+
+// Enable an audit device at a path
+func (h *Handler) EnableAuditDevice(c *gin.Context) {
+	path, ok := requireAuditPath(c)
+	if !ok {
+		return
+	}
+
+	var req vault.AuditDeviceRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.EnableAuditDevice(ctx, path, &req)
+	if errors.Is(err, vault.ErrAuditDeviceExists) {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Path is already in use",
+			Details: path,
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to enable audit device")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to enable audit device",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Audit device enabled successfully",
+		Data: map[string]string{
+			"path": path,
+			"type": req.Type,
+		},
+	})
+}
+
+// Disable the audit device at a path. Devices hcvapi enables at startup
+// cannot be disabled.
+func (h *Handler) DisableAuditDevice(c *gin.Context) {
+	path, ok := requireAuditPath(c)
+	if !ok {
+		return
+	}
+
+	for configured := range h.config.Vault.AuditDevices {
+		if strings.Trim(configured, "/") == path {
+			c.JSON(http.StatusConflict, ErrorResponse{
+				Error:   "Audit device is managed by hcvapi",
+				Details: path,
+			})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.DisableAuditDevice(ctx, path)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Audit device not found",
+			Details: path,
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to disable audit device")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to disable audit device",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Audit device disabled successfully",
+		Data: map[string]string{
+			"path": path,
+		},
+	})
+}
+
+// requireAuditPath returns the device path of an /audit/*path route,
+// answering 400 when it is empty.
+func requireAuditPath(c *gin.Context) (string, bool) {
+	path := strings.Trim(c.Param("path"), "/")
+	if path == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Audit device path is required",
+		})
+		return "", false
+	}
+	return path, true
+}
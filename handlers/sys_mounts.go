@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// List the secrets engines mounted in Vault
+func (h *Handler) ListMounts(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	mounts, err := h.vaultClient.ListMounts(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list mounts")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list mounts",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Mounts retrieved successfully",
+		Data: map[string]interface{}{
+			"mounts": mounts,
+			"count":  len(mounts),
+		},
+	})
+}
+
+// Enable a secrets engine at a path
+func (h *Handler) EnableMount(c *gin.Context) {
+	path, ok := requireMountPath(c)
+	if !ok {
+		return
+	}
+
+	var req vault.MountRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.EnableMount(ctx, path, &req)
+	if errors.Is(err, vault.ErrMountExists) {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Path is already in use",
+			Details: path,
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to enable secrets engine")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to enable secrets engine",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Secrets engine enabled successfully",
+		Data: map[string]string{
+			"path": path,
+			"type": req.Type,
+		},
+	})
+}
+
+// Change the lease TTLs of a secrets engine mount
+func (h *Handler) TuneMountPath(c *gin.Context) {
+	path, ok := requireMountPath(c)
+	if !ok {
+		return
+	}
+
+	var tuning vault.MountTuning
+	if !bindJSON(c, &tuning) {
+		return
+	}
+
+	if tuning.DefaultLeaseTTL == "" && tuning.MaxLeaseTTL == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "default_lease_ttl or max_lease_ttl is required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	mountTuning, err := h.vaultClient.TuneMountPath(ctx, path, &tuning)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Mount not found",
+			Details: path,
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to tune secrets engine mount")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to tune secrets engine mount",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Secrets engine mount tuned successfully",
+		Data:    mountTuning,
+	})
+}
+
+// Disable the secrets engine at a path, revoking its leases and deleting its
+// data. Mounts hcvapi itself serves cannot be disabled.
+func (h *Handler) DisableMount(c *gin.Context) {
+	path, ok := requireMountPath(c)
+	if !ok {
+		return
+	}
+
+	if h.managedMount(path) {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Mount is managed by hcvapi",
+			Details: path,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.DisableMount(ctx, path)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error:   "Mount not found",
+			Details: path,
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("path", path).Error("Failed to disable secrets engine")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to disable secrets engine",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Secrets engine disabled successfully",
+		Data: map[string]string{
+			"path": path,
+		},
+	})
+}
+
+// managedMount reports whether hcvapi serves the mount at path.
+func (h *Handler) managedMount(path string) bool {
+	for _, mount := range h.managedMounts() {
+		if mount == path {
+			return true
+		}
+	}
+	return false
+}
+
+// managedMounts returns the paths of the mounts hcvapi serves: the GCP
+// mounts, the KV mount and the mounts of enabled optional engines.
+func (h *Handler) managedMounts() []string {
+	managed := append(h.vaultClient.MountPaths(), strings.Trim(h.config.KV.MountPath, "/"))
+	for _, engine := range h.engines {
+		if engine.Enabled() {
+			managed = append(managed, strings.Trim(engine.Mount(), "/"))
+		}
+	}
+	return managed
+}
+
+// requireMountPath returns the mount path of a /mounts/*path route, answering
+// 400 when it is empty.
+func requireMountPath(c *gin.Context) (string, bool) {
+	path := strings.Trim(c.Param("path"), "/")
+	if path == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Mount path is required",
+		})
+		return "", false
+	}
+	return path, true
+}
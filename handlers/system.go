@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// UnsealRequest submits one unseal key share. Reset discards the shares
+// submitted so far instead.
+type UnsealRequest struct {
+	Key   string `json:"key"`
+	Reset bool   `json:"reset,omitempty"`
+}
+
+// Report token accessors created through hcvapi's auth role
+func (h *Handler) GetTokenAccessors(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+	defer cancel()
+
+	report := h.vaultClient.AccessorReport(ctx)
+	if report.Error != "" {
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Token accessor audit failed",
+			Details: report.Error,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Token accessor audit retrieved successfully",
+		Data:    report,
+	})
+}
+
+// List child Vault namespaces and the GCP mounts configured in each
+func (h *Handler) DiscoverNamespaces(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), time.Minute)
+	defer cancel()
+
+	namespaces, err := h.vaultClient.DiscoverNamespaces(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to discover namespaces")
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to discover namespaces",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Namespaces retrieved successfully",
+		Data: map[string]interface{}{
+			"namespaces": namespaces,
+			"count":      len(namespaces),
+		},
+	})
+}
+
+// Create a child namespace of the configured Vault namespace
+func (h *Handler) CreateNamespace(c *gin.Context) {
+	name := c.Param("name")
+
+	var req vault.NamespaceRequest
+	// The body is optional
+	_ = c.ShouldBindJSON(&req)
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	namespace, err := h.vaultClient.CreateNamespace(ctx, name, &req)
+	if err != nil {
+		h.log(c).WithError(err).WithField("namespace", name).Error("Failed to create namespace")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create namespace",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Namespace created successfully",
+		Data:    namespace,
+	})
+}
+
+// Delete a child namespace of the configured Vault namespace
+func (h *Handler) DeleteNamespace(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	err := h.vaultClient.DeleteNamespace(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Namespace not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("namespace", name).Error("Failed to delete namespace")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete namespace",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Namespace deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Report Vault's seal status, version and cluster
+func (h *Handler) GetSealStatus(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	status, err := h.vaultClient.SealStatus(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to read seal status")
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to read seal status",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Seal status retrieved successfully",
+		Data:    status,
+	})
+}
+
+// Submit an unseal key share to Vault, when the unseal proxy is enabled
+func (h *Handler) Unseal(c *gin.Context) {
+	if !h.config.Vault.UnsealProxy {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Unseal proxy is disabled",
+		})
+		return
+	}
+
+	var req UnsealRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if req.Key == "" && !req.Reset {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error: "Unseal key share is required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	status, err := h.vaultClient.Unseal(ctx, req.Key, req.Reset)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to unseal Vault")
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to unseal Vault",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	message := "Unseal key share accepted"
+	if !status.Sealed {
+		message = "Vault unsealed successfully"
+	}
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: message,
+		Data:    status,
+	})
+}
+
+// Check the capabilities hcvapi's Vault token has on the given paths
+func (h *Handler) CheckCapabilities(c *gin.Context) {
+	var req vault.CapabilitiesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	capabilities, err := h.vaultClient.CapabilitiesSelf(ctx, req.Paths)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to check token capabilities")
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to check token capabilities",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Token capabilities retrieved successfully",
+		Data: map[string]interface{}{
+			"capabilities": capabilities,
+		},
+	})
+}
+
+// Report which Vault node is active and whether hcvapi talks to it
+func (h *Handler) GetLeader(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	leader, err := h.vaultClient.Leader(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to read leader status")
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to read leader status",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Leader status retrieved successfully",
+		Data:    leader,
+	})
+}
+
+// Report the term, install time and use of Vault's barrier key
+func (h *Handler) GetKeyStatus(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	status, err := h.vaultClient.KeyStatus(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to read key status")
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to read key status",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Key status retrieved successfully",
+		Data:    status,
+	})
+}
+
+// Rotate Vault's barrier key
+func (h *Handler) RotateBarrierKey(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	status, err := h.vaultClient.RotateBarrierKey(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to rotate barrier key")
+		c.JSON(http.StatusBadGateway, ErrorResponse{
+			Error:   "Failed to rotate barrier key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Barrier key rotated successfully",
+		Data:    status,
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/identity"
+)
+
+const tenantContextKey = "tenant"
+
+type tenant struct {
+	Name   string
+	Config config.TenantConfig
+}
+
+// Middleware resolving the tenant of a request and its configured overrides
+func (h *Handler) TenantMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// Authenticated callers carry their tenant; the header is only
+		// consulted for callers without one, and never when authentication
+		// is required, so callers cannot pick their own guardrails
+		name := identity.FromContext(c).Tenant
+		if name == "" && !h.config.Auth.Required {
+			name = c.GetHeader(h.config.Server.TenantHeader)
+		}
+		if name == "" {
+			c.Next()
+			return
+		}
+
+		tenantCfg, ok := h.config.Tenants[name]
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, ErrorResponse{
+				Error:   "Unknown tenant",
+				Details: name,
+			})
+			return
+		}
+
+		c.Set(tenantContextKey, &tenant{Name: name, Config: tenantCfg})
+		c.Next()
+	}
+}
+
+// tenantFrom returns the tenant resolved for the request, or nil if none.
+func tenantFrom(c *gin.Context) *tenant {
+	value, ok := c.Get(tenantContextKey)
+	if !ok {
+		return nil
+	}
+	return value.(*tenant)
+}
+
+func (t *tenant) checkProject(project string) error {
+	if t == nil || len(t.Config.AllowedProjects) == 0 {
+		return nil
+	}
+
+	for _, allowed := range t.Config.AllowedProjects {
+		if allowed == project {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("project %q is not allowed for tenant %q", project, t.Name)
+}
+
+// capTTL checks the TTL requested for a secret against the tenant's max_ttl,
+// and fills in the max_ttl when none was requested, so omitting the TTL
+// cannot escape the cap.
+func (t *tenant) capTTL(ttl *string) error {
+	if t == nil || t.Config.MaxTTL == "" {
+		return nil
+	}
+	if *ttl == "" {
+		*ttl = t.Config.MaxTTL
+	}
+	return t.checkTTL(*ttl)
+}
+
+func (t *tenant) checkTTL(ttl string) error {
+	if t == nil || t.Config.MaxTTL == "" || ttl == "" {
+		return nil
+	}
+
+	requested, err := parseTTL(ttl)
+	if err != nil {
+		return err
+	}
+
+	limit, err := parseTTL(t.Config.MaxTTL)
+	if err != nil {
+		return fmt.Errorf("invalid max_ttl for tenant %q: %w", t.Name, err)
+	}
+
+	if requested > limit {
+		return fmt.Errorf("ttl %s exceeds the maximum of %s for tenant %q", ttl, t.Config.MaxTTL, t.Name)
+	}
+
+	return nil
+}
+
+func (t *tenant) tokenScopes() string {
+	if t == nil {
+		return ""
+	}
+	return t.Config.TokenScopes
+}
+
+// parseTTL accepts the same TTL formats as Vault: plain seconds or a Go duration string.
+func parseTTL(ttl string) (time.Duration, error) {
+	if seconds, err := strconv.ParseInt(ttl, 10, 64); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ttl %q", ttl)
+	}
+	return d, nil
+}
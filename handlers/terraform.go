@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// terraformRoutes registers the Terraform Cloud secrets engine routes on group.
+func (h *Handler) terraformRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	terraform := group.Group("/terraform")
+	{
+		terraform.GET("/roles", h.ListTerraformRoles)                                                    // GET /api/v1/terraform/roles
+		terraform.GET("/roles/:name", h.GetTerraformRole)                                                // GET /api/v1/terraform/roles/{name}
+		terraform.POST("/roles/:name", h.CreateTerraformRole)                                            // POST /api/v1/terraform/roles/{name}
+		terraform.PUT("/roles/:name", h.UpdateTerraformRole)                                             // PUT /api/v1/terraform/roles/{name}
+		terraform.DELETE("/roles/:name", h.DeleteTerraformRole)                                          // DELETE /api/v1/terraform/roles/{name}
+		terraform.POST("/creds/:name", secretHeaders, issuanceAudit, incidentGuard, h.GetTerraformToken) // POST /api/v1/terraform/creds/{name}
+	}
+}
+
+// Create a new Terraform role
+func (h *Handler) CreateTerraformRole(c *gin.Context) {
+	h.writeTerraformRole(c, http.StatusCreated, "Terraform role created successfully")
+}
+
+// Update an existing Terraform role
+func (h *Handler) UpdateTerraformRole(c *gin.Context) {
+	h.writeTerraformRole(c, http.StatusOK, "Terraform role updated successfully")
+}
+
+func (h *Handler) writeTerraformRole(c *gin.Context, status int, message string) {
+	name := c.Param("name")
+
+	var req vault.TerraformRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if req.Organization == "" && req.UserID == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: "organization or user_id is required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.WriteTerraformRole(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("terraform_role", name).Error("Failed to write Terraform role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to write Terraform role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(status, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a Terraform role
+func (h *Handler) GetTerraformRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	role, err := h.vaultClient.GetTerraformRole(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Terraform role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("terraform_role", name).Error("Failed to get Terraform role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get Terraform role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Terraform role retrieved successfully",
+		Data:    role,
+	})
+}
+
+// List all Terraform roles
+func (h *Handler) ListTerraformRoles(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	roles, err := h.vaultClient.ListTerraformRoles(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list Terraform roles")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list Terraform roles",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Terraform roles retrieved successfully",
+		Data: map[string]interface{}{
+			"roles": roles,
+			"count": len(roles),
+		},
+	})
+}
+
+// Delete a Terraform role
+func (h *Handler) DeleteTerraformRole(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteTerraformRole(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("terraform_role", name).Error("Failed to delete Terraform role")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete Terraform role",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Terraform role deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate a Terraform Cloud token for a role
+func (h *Handler) GetTerraformToken(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	credentials, err := h.vaultClient.GetTerraformToken(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Terraform role not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("terraform_role", name).Error("Failed to get Terraform token")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate Terraform token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindTerraformToken, name, credentials)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Terraform token generated successfully",
+		Data:    credentials,
+	})
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+const (
+	// Clients opt in to the cache headers by sending this header set to "allow"
+	cacheNegotiationHeader = "X-HCVAPI-Cache"
+	tokenTTLHeader         = "X-HCVAPI-Token-TTL"
+)
+
+// setTokenCacheHeaders reports whether a token came from the cache, how long
+// it has been cached (Age) and how many seconds it remains valid.
+func setTokenCacheHeaders(c *gin.Context, token *vault.TokenResponse, info *vault.TokenCacheInfo) {
+	if info.Hit {
+		c.Header(cacheNegotiationHeader, "hit")
+		c.Header("Age", strconv.FormatInt(int64(time.Since(info.CachedAt)/time.Second), 10))
+	} else {
+		c.Header(cacheNegotiationHeader, "miss")
+		c.Header("Age", "0")
+	}
+
+	if token.ExpiresAtSeconds > 0 {
+		remaining := time.Until(time.Unix(token.ExpiresAtSeconds, 0)) / time.Second
+		c.Header(tokenTTLHeader, strconv.FormatInt(int64(remaining), 10))
+	}
+}
@@ -0,0 +1,226 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// totpRoutes registers the TOTP secrets engine routes on group.
+func (h *Handler) totpRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	totp := group.Group("/totp")
+	{
+		totp.GET("/keys", h.ListTOTPKeys)                                                        // GET /api/v1/totp/keys
+		totp.GET("/keys/:name", h.GetTOTPKey)                                                    // GET /api/v1/totp/keys/{name}
+		totp.POST("/keys/:name", secretHeaders, h.CreateTOTPKey)                                 // POST /api/v1/totp/keys/{name}
+		totp.DELETE("/keys/:name", h.DeleteTOTPKey)                                              // DELETE /api/v1/totp/keys/{name}
+		totp.GET("/code/:name", secretHeaders, issuanceAudit, incidentGuard, h.GenerateTOTPCode) // GET /api/v1/totp/code/{name}
+		totp.POST("/code/:name", h.ValidateTOTPCode)                                             // POST /api/v1/totp/code/{name}
+	}
+}
+
+type ValidateTOTPCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// Create a TOTP key, generated by Vault or imported from a URL or key
+func (h *Handler) CreateTOTPKey(c *gin.Context) {
+	name := c.Param("name")
+
+	var req vault.TOTPKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	if req.Generate && (req.Issuer == "" || req.AccountName == "") {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: "issuer and account_name are required when generate is true",
+		})
+		return
+	}
+	if !req.Generate && req.URL == "" && req.Key == "" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: "url or key is required when generate is false",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	key, err := h.vaultClient.CreateTOTPKey(ctx, name, &req)
+	if err != nil {
+		h.log(c).WithError(err).WithField("totp_key", name).Error("Failed to create TOTP key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create TOTP key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "TOTP key created successfully",
+		Data:    key,
+	})
+}
+
+// Get a TOTP key
+func (h *Handler) GetTOTPKey(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	key, err := h.vaultClient.GetTOTPKey(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "TOTP key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("totp_key", name).Error("Failed to get TOTP key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get TOTP key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "TOTP key retrieved successfully",
+		Data:    key,
+	})
+}
+
+// List all TOTP keys
+func (h *Handler) ListTOTPKeys(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	keys, err := h.vaultClient.ListTOTPKeys(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list TOTP keys")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list TOTP keys",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "TOTP keys retrieved successfully",
+		Data: map[string]interface{}{
+			"keys":  keys,
+			"count": len(keys),
+		},
+	})
+}
+
+// Delete a TOTP key
+func (h *Handler) DeleteTOTPKey(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.DeleteTOTPKey(ctx, name); err != nil {
+		h.log(c).WithError(err).WithField("totp_key", name).Error("Failed to delete TOTP key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to delete TOTP key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "TOTP key deleted successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Generate the current code of a TOTP key
+func (h *Handler) GenerateTOTPCode(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	code, err := h.vaultClient.GenerateTOTPCode(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "TOTP key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("totp_key", name).Error("Failed to generate TOTP code")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate TOTP code",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindTOTPCode, name, nil)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "TOTP code generated successfully",
+		Data: map[string]string{
+			"code": code,
+		},
+	})
+}
+
+// Validate a code against a TOTP key
+func (h *Handler) ValidateTOTPCode(c *gin.Context) {
+	name := c.Param("name")
+
+	var req ValidateTOTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	valid, err := h.vaultClient.ValidateTOTPCode(ctx, name, req.Code)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "TOTP key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("totp_key", name).Error("Failed to validate TOTP code")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to validate TOTP code",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "TOTP code validated successfully",
+		Data: map[string]bool{
+			"valid": valid,
+		},
+	})
+}
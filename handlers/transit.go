@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/sirupsen/logrus"
+)
+
+// transitRoutes registers the Transit secrets engine routes on group.
+func (h *Handler) transitRoutes(group *gin.RouterGroup, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	transit := group.Group("/transit")
+	{
+		transit.GET("/keys", h.ListTransitKeys)                                    // GET /api/v1/transit/keys
+		transit.GET("/keys/:name", h.GetTransitKey)                                // GET /api/v1/transit/keys/{name}
+		transit.POST("/keys/:name", h.CreateTransitKey)                            // POST /api/v1/transit/keys/{name}
+		transit.DELETE("/keys/:name", h.DeleteTransitKey)                          // DELETE /api/v1/transit/keys/{name}?confirm=true
+		transit.POST("/keys/:name/rotate", h.RotateTransitKey)                     // POST /api/v1/transit/keys/{name}/rotate
+		transit.POST("/keys/:name/config", h.ConfigureTransitKey)                  // POST /api/v1/transit/keys/{name}/config
+		transit.GET("/keys/:name/export/:type", secretHeaders, h.ExportTransitKey) // GET /api/v1/transit/keys/{name}/export/{type}
+
+		transit.POST("/sign/:name", h.TransitSign)     // POST /api/v1/transit/sign/{name}
+		transit.POST("/verify/:name", h.TransitVerify) // POST /api/v1/transit/verify/{name}
+		transit.POST("/hmac/:name", h.TransitHMAC)     // POST /api/v1/transit/hmac/{name}
+	}
+}
+
+// Sign input with a Transit key
+func (h *Handler) TransitSign(c *gin.Context) {
+	var req vault.TransitSignRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.runTransitOperation(c, "sign", "Input signed successfully", func(ctx context.Context, name string) (interface{}, error) {
+		return h.vaultClient.TransitSign(ctx, name, &req)
+	})
+}
+
+// Verify a signature or HMAC with a Transit key
+func (h *Handler) TransitVerify(c *gin.Context) {
+	var req vault.TransitVerifyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	if (req.Signature == "") == (req.HMAC == "") {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid request body",
+			Details: "exactly one of signature or hmac is required",
+		})
+		return
+	}
+
+	h.runTransitOperation(c, "verify", "Input verified successfully", func(ctx context.Context, name string) (interface{}, error) {
+		valid, err := h.vaultClient.TransitVerify(ctx, name, &req)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]bool{"valid": valid}, nil
+	})
+}
+
+// Compute the HMAC of input with a Transit key
+func (h *Handler) TransitHMAC(c *gin.Context) {
+	var req vault.TransitHMACRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.runTransitOperation(c, "hmac", "HMAC generated successfully", func(ctx context.Context, name string) (interface{}, error) {
+		return h.vaultClient.TransitHMAC(ctx, name, &req)
+	})
+}
+
+func (h *Handler) runTransitOperation(c *gin.Context, operation, message string, run func(context.Context, string) (interface{}, error)) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	result, err := run(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Transit key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithFields(logrus.Fields{
+			"transit_key": name,
+			"operation":   operation,
+		}).Error("Transit operation failed")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Transit " + operation + " failed",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: message,
+		Data:    result,
+	})
+}
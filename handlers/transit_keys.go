@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Create a Transit key
+func (h *Handler) CreateTransitKey(c *gin.Context) {
+	name := c.Param("name")
+
+	var req vault.TransitKeyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := h.vaultClient.CreateTransitKey(ctx, name, &req); err != nil {
+		h.log(c).WithError(err).WithField("transit_key", name).Error("Failed to create Transit key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to create Transit key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, SuccessResponse{
+		Message: "Transit key created successfully",
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
+
+// Get a Transit key
+func (h *Handler) GetTransitKey(c *gin.Context) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	key, err := h.vaultClient.GetTransitKey(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Transit key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("transit_key", name).Error("Failed to get Transit key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to get Transit key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Transit key retrieved successfully",
+		Data:    key,
+	})
+}
+
+// List all Transit keys
+func (h *Handler) ListTransitKeys(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	keys, err := h.vaultClient.ListTransitKeys(ctx)
+	if err != nil {
+		h.log(c).WithError(err).Error("Failed to list Transit keys")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to list Transit keys",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Transit keys retrieved successfully",
+		Data: map[string]interface{}{
+			"keys":  keys,
+			"count": len(keys),
+		},
+	})
+}
+
+// Rotate a Transit key to a new version
+func (h *Handler) RotateTransitKey(c *gin.Context) {
+	h.updateTransitKey(c, "rotate", "Transit key rotated successfully", h.vaultClient.RotateTransitKey)
+}
+
+// Update the settings of a Transit key, such as min_decryption_version
+func (h *Handler) ConfigureTransitKey(c *gin.Context) {
+	var req vault.TransitKeyConfigRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	h.updateTransitKey(c, "configure", "Transit key configured successfully", func(ctx context.Context, name string) error {
+		return h.vaultClient.ConfigureTransitKey(ctx, name, &req)
+	})
+}
+
+// Delete a Transit key. Requires confirm=true, as data encrypted with the
+// key can no longer be decrypted.
+func (h *Handler) DeleteTransitKey(c *gin.Context) {
+	if c.Query("confirm") != "true" {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Deletion not confirmed",
+			Details: "deleting a Transit key makes everything encrypted with it undecryptable; pass confirm=true",
+		})
+		return
+	}
+
+	h.updateTransitKey(c, "delete", "Transit key deleted successfully", h.vaultClient.DeleteTransitKey)
+}
+
+// Export key material of a Transit key where its policy allows
+func (h *Handler) ExportTransitKey(c *gin.Context) {
+	name := c.Param("name")
+	keyType := c.Param("type")
+
+	switch keyType {
+	case "encryption-key", "signing-key", "hmac-key", "public-key":
+	default:
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid key type",
+			Details: "type must be encryption-key, signing-key, hmac-key or public-key",
+		})
+		return
+	}
+
+	version := c.Query("version")
+	if version != "" && version != "latest" {
+		if n, err := strconv.Atoi(version); err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{
+				Error:   "Invalid version",
+				Details: fmt.Sprintf("version must be a positive number or latest, got %q", version),
+			})
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	keys, err := h.vaultClient.ExportTransitKey(ctx, name, keyType, version)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Transit key not found",
+		})
+		return
+	}
+	if errors.Is(err, vault.ErrKeyNotExportable) {
+		c.JSON(http.StatusConflict, ErrorResponse{
+			Error:   "Transit key is not exportable",
+			Details: "only public keys can be exported from keys created without exportable",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("transit_key", name).Error("Failed to export Transit key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to export Transit key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.log(c).WithField("transit_key", name).WithField("type", keyType).Warn("Transit key material exported")
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Transit key exported successfully",
+		Data: map[string]interface{}{
+			"name": name,
+			"type": keyType,
+			"keys": keys,
+		},
+	})
+}
+
+func (h *Handler) updateTransitKey(c *gin.Context, action, message string, update func(context.Context, string) error) {
+	name := c.Param("name")
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	err := update(ctx, name)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Transit key not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("transit_key", name).Errorf("Failed to %s Transit key", action)
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   fmt.Sprintf("Failed to %s Transit key", action),
+			Details: err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: message,
+		Data: map[string]string{
+			"name": name,
+		},
+	})
+}
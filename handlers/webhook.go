@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/kalpesh172000/hcvapi/identity"
+	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/kalpesh172000/hcvapi/webhook"
+)
+
+// notifyWebhooks completes event with the request's caller, tenant, mount
+// and request ID and sends it to the subscribed webhook endpoints.
+func (h *Handler) notifyWebhooks(c *gin.Context, event *webhook.Event) {
+	event.Caller = identity.FromContext(c).ID
+	event.RequestID = c.Writer.Header().Get(requestIDHeader)
+	if t := tenantFrom(c); t != nil {
+		event.Tenant = t.Name
+	}
+	if _, ok := c.Get(mountContextKey); ok {
+		event.Mount = h.mountClient(c).MountPath()
+	}
+
+	h.webhooks.Notify(event)
+}
+
+// keyEventData describes an issued service account key without its private
+// key data.
+func keyEventData(key *vault.ServiceAccountKeyResponse) map[string]interface{} {
+	return map[string]interface{}{
+		"key_id":         key.KeyID,
+		"key_algorithm":  key.KeyAlgorithm,
+		"lease_id":       key.LeaseID,
+		"lease_duration": key.LeaseDuration,
+	}
+}
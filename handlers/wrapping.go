@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/kalpesh172000/hcvapi/webhook"
+)
+
+type UnwrapRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// checkWrapTTL validates an optional wrap_ttl. It writes the error response
+// and returns false if it is invalid.
+func checkWrapTTL(c *gin.Context, wrapTTL string) bool {
+	if wrapTTL == "" {
+		return true
+	}
+	if ttl, err := parseTTL(wrapTTL); err != nil || ttl <= 0 {
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Invalid wrap_ttl",
+			Details: "wrap_ttl must be a positive number of seconds or a duration",
+		})
+		return false
+	}
+	return true
+}
+
+// getWrappedAccessToken answers a token request with a wrapping token in
+// place of the access token.
+func (h *Handler) getWrappedAccessToken(c *gin.Context, rolesetName string, req *TokenRequest) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	wrapped, err := h.mountClient(c).GetWrappedToken(ctx, rolesetName, req.TTL, req.WrapTTL)
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to get wrapped access token")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate access token",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindRolesetToken, rolesetName, wrapped)
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Wrapped access token generated successfully",
+		Data:    wrapped,
+	})
+}
+
+// getWrappedServiceAccountKey answers a key request with a wrapping token in
+// place of the key. refund gives back the key quota reserved for the request
+// if no key is issued.
+func (h *Handler) getWrappedServiceAccountKey(c *gin.Context, rolesetName string, req *vault.KeyRequest, refund func()) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	wrapped, err := h.mountClient(c).GetWrappedServiceAccountKey(ctx, rolesetName, req, req.WrapTTL)
+	if err != nil {
+		// Only issued keys count against the quota
+		refund()
+	}
+	if errors.Is(err, vault.ErrNotFound) {
+		c.JSON(http.StatusNotFound, ErrorResponse{
+			Error: "Roleset not found",
+		})
+		return
+	}
+	if err != nil {
+		h.log(c).WithError(err).WithField("roleset", rolesetName).Error("Failed to get wrapped service account key")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to generate service account key",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	h.recordIssuance(c, usage.KindRolesetKey, rolesetName, wrapped)
+	h.notifyWebhooks(c, &webhook.Event{
+		Type:    webhook.EventKeyIssued,
+		Roleset: rolesetName,
+		Data: map[string]interface{}{
+			"wrapped":           true,
+			"wrapping_accessor": wrapped.WrappingAccessor,
+		},
+	})
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Wrapped service account key generated successfully",
+		Data:    wrapped,
+	})
+}
+
+// Unwrap a wrapped access token or service account key
+func (h *Handler) Unwrap(c *gin.Context) {
+	var req UnwrapRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	client := h.mountClient(c)
+	wrapped, err := client.LookupWrapped(ctx, req.Token)
+	if err != nil {
+		h.unwrapError(c, err)
+		return
+	}
+
+	// The roleset is only known from the wrapping token, so the incident
+	// guard could not check it
+	c.Set(auditRolesetContextKey, wrapped.Roleset)
+	tenantName := ""
+	if t := tenantFrom(c); t != nil {
+		tenantName = t.Name
+	}
+	if h.incidents.Paused(tenantName, wrapped.Mount, wrapped.Roleset) {
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{
+			Error:   "Issuance paused",
+			Details: "an incident is in progress",
+		})
+		return
+	}
+
+	secret, err := client.Unwrap(ctx, req.Token, wrapped)
+	if err != nil {
+		h.unwrapError(c, err)
+		return
+	}
+
+	if secret.Type == vault.WrappedAccessToken {
+		noteAuditIssuance(c, usage.KindRolesetToken, secret.Token)
+	} else {
+		noteAuditIssuance(c, usage.KindRolesetKey, secret.Key)
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{
+		Message: "Secret unwrapped successfully",
+		Data:    secret,
+	})
+}
+
+// unwrapError answers an unwrap request that failed with err.
+func (h *Handler) unwrapError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, vault.ErrWrappingToken):
+		// A token unwrapped before may have been intercepted on its way here
+		h.log(c).Warn("Invalid, expired or already used wrapping token")
+		c.JSON(http.StatusForbidden, ErrorResponse{
+			Error:   "Invalid wrapping token",
+			Details: err.Error(),
+		})
+	case errors.Is(err, vault.ErrWrappedSecretType):
+		c.JSON(http.StatusBadRequest, ErrorResponse{
+			Error:   "Unsupported wrapping token",
+			Details: err.Error(),
+		})
+	default:
+		h.log(c).WithError(err).Error("Failed to unwrap secret")
+		c.JSON(http.StatusInternalServerError, ErrorResponse{
+			Error:   "Failed to unwrap secret",
+			Details: err.Error(),
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package identity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// APIKeyResolver identifies callers by an API key header. Only the SHA-256
+// hashes of the keys are kept.
+type APIKeyResolver struct {
+	header  string
+	callers map[[sha256.Size]byte]*Caller
+}
+
+func NewAPIKeyResolver(cfg config.APIKeyAuthConfig) *APIKeyResolver {
+	resolver := &APIKeyResolver{
+		header:  cfg.Header,
+		callers: make(map[[sha256.Size]byte]*Caller, len(cfg.Keys)),
+	}
+
+	for name, key := range cfg.Keys {
+		// Hashes are validated when the configuration is loaded
+		decoded, _ := hex.DecodeString(key.Hash)
+
+		var hash [sha256.Size]byte
+		copy(hash[:], decoded)
+		resolver.callers[hash] = &Caller{
+			ID:       name,
+			Tenant:   key.Tenant,
+			Groups:   key.Groups,
+			Method:   "api_key",
+			Metadata: key.Metadata,
+		}
+	}
+
+	return resolver
+}
+
+func (r *APIKeyResolver) Name() string { return "api_key" }
+
+func (r *APIKeyResolver) Resolve(c *gin.Context) (*Caller, error) {
+	key := strings.TrimSpace(c.GetHeader(r.header))
+	if key == "" {
+		return nil, nil
+	}
+
+	caller, ok := r.callers[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, errors.New("invalid API key")
+	}
+	return caller, nil
+}
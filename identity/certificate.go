@@ -0,0 +1,78 @@
+package identity
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// CertificateResolver identifies callers by the TLS client certificate the
+// server verified during the handshake.
+type CertificateResolver struct {
+	cfg config.ClientCertAuthConfig
+}
+
+func NewCertificateResolver(cfg config.ClientCertAuthConfig) *CertificateResolver {
+	return &CertificateResolver{cfg: cfg}
+}
+
+func (r *CertificateResolver) Name() string { return "client_cert" }
+
+func (r *CertificateResolver) Resolve(c *gin.Context) (*Caller, error) {
+	state := c.Request.TLS
+	// Only chains verified against the client CA bundle count
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+	cert := state.VerifiedChains[0][0]
+
+	name := certificateName(cert)
+	if name == "" {
+		return nil, fmt.Errorf("client certificate %s has no common name or SAN", cert.SerialNumber)
+	}
+
+	caller := &Caller{
+		ID:     name,
+		Method: "client_cert",
+		Metadata: map[string]string{
+			"cert_serial": cert.SerialNumber.String(),
+			"cert_issuer": cert.Issuer.CommonName,
+		},
+	}
+
+	// Configuration keys are lowercased when loaded
+	identity, ok := r.cfg.Identities[strings.ToLower(name)]
+	if !ok {
+		if r.cfg.RequireMapping {
+			return nil, fmt.Errorf("client certificate %q is not mapped to an identity", name)
+		}
+		return caller, nil
+	}
+
+	caller.Tenant = identity.Tenant
+	caller.Groups = identity.Groups
+	for key, value := range identity.Metadata {
+		caller.Metadata[key] = value
+	}
+	return caller, nil
+}
+
+// certificateName is the common name of a certificate, or else its first DNS,
+// URI or email SAN.
+func certificateName(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	}
+	return ""
+}
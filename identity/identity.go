@@ -0,0 +1,95 @@
+package identity
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+const callerContextKey = "caller"
+
+// Caller is the normalized identity of whoever made a request, whichever
+// authentication method produced it.
+type Caller struct {
+	ID     string   `json:"id"`
+	Tenant string   `json:"tenant,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	Method string   `json:"method"`
+	// Metadata describes the caller in the request logs, e.g. its owner
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Claims are the claims of the caller's token, for token-based methods
+	Claims map[string]interface{} `json:"claims,omitempty"`
+}
+
+// Anonymous is the caller of requests no resolver recognized.
+var Anonymous = &Caller{ID: "anonymous", Method: "none"}
+
+// Resolver identifies the caller of a request from the credentials it
+// understands. It returns nil, nil when the request carries none of them so
+// the next resolver in the chain can try, and an error when credentials are
+// present but invalid.
+type Resolver interface {
+	Name() string
+	Resolve(c *gin.Context) (*Caller, error)
+}
+
+// Chain tries resolvers in order and stores the first resolved caller in the
+// request context.
+type Chain struct {
+	resolvers []Resolver
+	required  bool
+	logger    *logrus.Logger
+}
+
+// NewChain creates a resolver chain. When required is set, requests no
+// resolver recognizes are rejected instead of proceeding anonymously.
+func NewChain(required bool, logger *logrus.Logger, resolvers ...Resolver) *Chain {
+	return &Chain{
+		resolvers: resolvers,
+		required:  required,
+		logger:    logger,
+	}
+}
+
+// Middleware resolving the caller of every request
+func (ch *Chain) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, resolver := range ch.resolvers {
+			caller, err := resolver.Resolve(c)
+			if err != nil {
+				ch.logger.WithError(err).WithField("resolver", resolver.Name()).Warn("Authentication failed")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error":   "Authentication failed",
+					"details": err.Error(),
+				})
+				return
+			}
+			if caller != nil {
+				c.Set(callerContextKey, caller)
+				c.Next()
+				return
+			}
+		}
+
+		if ch.required {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "Authentication required",
+			})
+			return
+		}
+
+		c.Set(callerContextKey, Anonymous)
+		c.Next()
+	}
+}
+
+// FromContext returns the caller resolved for the request, or Anonymous.
+func FromContext(c *gin.Context) *Caller {
+	if value, ok := c.Get(callerContextKey); ok {
+		if caller, ok := value.(*Caller); ok {
+			return caller
+		}
+	}
+	return Anonymous
+}
@@ -0,0 +1,380 @@
+package identity
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func testLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// serveChain answers req through chain, with a handler reporting the
+// resolved caller's ID.
+func serveChain(chain *Chain, req *http.Request) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.GET("/api/v1/test", chain.Middleware(), func(c *gin.Context) {
+		c.String(http.StatusOK, FromContext(c).ID)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestJWTOnlyConfigRequiresAuthentication(t *testing.T) {
+	t.Setenv("AUTH_JWT_JWKS_URL", "http://127.0.0.1:1/jwks")
+	t.Setenv("AUTH_JWT_ISSUER", "https://issuer.example.com")
+	t.Setenv("AUTH_JWT_AUDIENCE", "hcvapi")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	if !cfg.Auth.Required {
+		t.Fatal("auth.required was not enabled by the JWT configuration")
+	}
+
+	chain := NewChain(cfg.Auth.Required, testLogger(), NewJWTResolver(cfg.Auth.JWT))
+	w := serveChain(chain, httptest.NewRequest(http.MethodGet, "/api/v1/test", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("request without credentials answered %d, want 401", w.Code)
+	}
+}
+
+func TestExplicitAuthRequiredFalseAllowsAnonymous(t *testing.T) {
+	t.Setenv("AUTH_JWT_JWKS_URL", "http://127.0.0.1:1/jwks")
+	t.Setenv("AUTH_JWT_ISSUER", "https://issuer.example.com")
+	t.Setenv("AUTH_JWT_AUDIENCE", "hcvapi")
+	t.Setenv("AUTH_REQUIRED", "false")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	chain := NewChain(cfg.Auth.Required, testLogger(), NewJWTResolver(cfg.Auth.JWT))
+	w := serveChain(chain, httptest.NewRequest(http.MethodGet, "/api/v1/test", nil))
+	if w.Code != http.StatusOK || w.Body.String() != Anonymous.ID {
+		t.Fatalf("got %d %q, want 200 %q", w.Code, w.Body.String(), Anonymous.ID)
+	}
+}
+
+// serveResolver answers req with the caller r resolves, as "id tenant", or
+// with the chain's error status.
+func serveResolver(r Resolver, req *http.Request) *httptest.ResponseRecorder {
+	router := gin.New()
+	router.GET("/api/v1/test", NewChain(true, testLogger(), r).Middleware(), func(c *gin.Context) {
+		caller := FromContext(c)
+		c.String(http.StatusOK, caller.ID+" "+caller.Tenant)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyResolver(t *testing.T) {
+	hash := sha256.Sum256([]byte("s3cret"))
+	resolver := NewAPIKeyResolver(config.APIKeyAuthConfig{
+		Header: "X-API-Key",
+		Keys: map[string]config.APIKeyConfig{
+			"ci-pipeline": {Hash: hex.EncodeToString(hash[:]), Tenant: "team-a"},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		key    string
+		status int
+		body   string
+	}{
+		{name: "valid key", key: "s3cret", status: http.StatusOK, body: "ci-pipeline team-a"},
+		{name: "surrounding whitespace", key: " s3cret ", status: http.StatusOK, body: "ci-pipeline team-a"},
+		{name: "unknown key", key: "guess", status: http.StatusUnauthorized},
+		{name: "no key", status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+			if tt.key != "" {
+				req.Header.Set("X-API-Key", tt.key)
+			}
+
+			w := serveResolver(resolver, req)
+			if w.Code != tt.status {
+				t.Fatalf("got %d, want %d", w.Code, tt.status)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Fatalf("got caller %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+func TestCertificateResolver(t *testing.T) {
+	verified := func(cert *x509.Certificate) *tls.ConnectionState {
+		return &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	}
+	mapped := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "Deploy-Bot"}}
+	unmapped := &x509.Certificate{SerialNumber: big.NewInt(2), DNSNames: []string{"worker.example.com"}}
+	nameless := &x509.Certificate{SerialNumber: big.NewInt(3)}
+
+	tests := []struct {
+		name           string
+		state          *tls.ConnectionState
+		requireMapping bool
+		status         int
+		body           string
+	}{
+		{name: "mapped common name", state: verified(mapped), status: http.StatusOK, body: "Deploy-Bot team-a"},
+		{name: "unmapped DNS SAN", state: verified(unmapped), status: http.StatusOK, body: "worker.example.com "},
+		{name: "unmapped with mapping required", state: verified(unmapped), requireMapping: true, status: http.StatusUnauthorized},
+		{name: "no name", state: verified(nameless), status: http.StatusUnauthorized},
+		{name: "unverified chain", state: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{mapped}}, status: http.StatusUnauthorized},
+		{name: "plain HTTP", status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewCertificateResolver(config.ClientCertAuthConfig{
+				Identities:     map[string]config.ClientCertIdentity{"deploy-bot": {Tenant: "team-a"}},
+				RequireMapping: tt.requireMapping,
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+			req.TLS = tt.state
+
+			w := serveResolver(resolver, req)
+			if w.Code != tt.status {
+				t.Fatalf("got %d, want %d", w.Code, tt.status)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Fatalf("got caller %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+// testJWKS serves the public half of key as a JWKS.
+type testJWKS struct {
+	key *rsa.PrivateKey
+}
+
+func (j *testJWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: &j.key.PublicKey, KeyID: "test-key", Algorithm: string(jose.RS256), Use: "sig"},
+	}})
+}
+
+func signJWT(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.Claims, custom map[string]interface{}) string {
+	t.Helper()
+
+	signer, err := jose.NewSigner(jose.SigningKey{
+		Algorithm: jose.RS256,
+		Key:       jose.JSONWebKey{Key: key, KeyID: kid},
+	}, (&jose.SignerOptions{}).WithType("JWT"))
+	if err != nil {
+		t.Fatalf("failed to create signer: %v", err)
+	}
+	raw, err := jwt.Signed(signer).Claims(claims).Claims(custom).CompactSerialize()
+	if err != nil {
+		t.Fatalf("failed to sign JWT: %v", err)
+	}
+	return raw
+}
+
+func TestJWTResolver(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	server := httptest.NewServer(&testJWKS{key: key})
+	t.Cleanup(server.Close)
+
+	resolver := NewJWTResolver(config.JWTAuthConfig{
+		Issuer:      "https://issuer.example.com",
+		Audience:    "hcvapi",
+		JWKSURL:     server.URL,
+		JWKSRefresh: time.Hour,
+		TenantClaim: "tenant",
+		GroupsClaim: "groups",
+	})
+
+	now := time.Now()
+	valid := jwt.Claims{
+		Issuer:   "https://issuer.example.com",
+		Audience: jwt.Audience{"hcvapi"},
+		Subject:  "alice",
+		IssuedAt: jwt.NewNumericDate(now),
+		Expiry:   jwt.NewNumericDate(now.Add(time.Hour)),
+	}
+	with := func(change func(*jwt.Claims)) jwt.Claims {
+		claims := valid
+		change(&claims)
+		return claims
+	}
+	tenant := map[string]interface{}{"tenant": "team-a", "groups": []string{"engineering"}}
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+		body   string
+	}{
+		{name: "valid token", token: signJWT(t, key, "test-key", valid, tenant), status: http.StatusOK, body: "alice team-a"},
+		{name: "wrong issuer", token: signJWT(t, key, "test-key", with(func(c *jwt.Claims) { c.Issuer = "https://evil.example.com" }), tenant), status: http.StatusUnauthorized},
+		{name: "wrong audience", token: signJWT(t, key, "test-key", with(func(c *jwt.Claims) { c.Audience = jwt.Audience{"other"} }), tenant), status: http.StatusUnauthorized},
+		{name: "expired", token: signJWT(t, key, "test-key", with(func(c *jwt.Claims) { c.Expiry = jwt.NewNumericDate(now.Add(-time.Hour)) }), tenant), status: http.StatusUnauthorized},
+		{name: "no subject", token: signJWT(t, key, "test-key", with(func(c *jwt.Claims) { c.Subject = "" }), tenant), status: http.StatusUnauthorized},
+		{name: "signed with another key", token: signJWT(t, other, "test-key", valid, tenant), status: http.StatusUnauthorized},
+		{name: "unknown key ID", token: signJWT(t, key, "rotated-key", valid, tenant), status: http.StatusUnauthorized},
+		{name: "malformed", token: "not-a-jwt", status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+
+			w := serveResolver(resolver, req)
+			if w.Code != tt.status {
+				t.Fatalf("got %d, want %d: %s", w.Code, tt.status, w.Body.String())
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Fatalf("got caller %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+func TestOIDCResolver(t *testing.T) {
+	o := NewOIDC(config.OIDCAuthConfig{SessionSecret: "session-secret"}, testLogger())
+	forged := NewOIDC(config.OIDCAuthConfig{SessionSecret: "guessed-secret"}, testLogger())
+
+	seal := func(o *OIDC, s session) string {
+		value, err := o.seal(s)
+		if err != nil {
+			t.Fatalf("failed to seal session: %v", err)
+		}
+		return value
+	}
+	live := session{Subject: "bob", Tenant: "team-b", Expiry: time.Now().Add(time.Hour).Unix()}
+	expired := session{Subject: "bob", Tenant: "team-b", Expiry: time.Now().Add(-time.Minute).Unix()}
+
+	tests := []struct {
+		name   string
+		cookie string
+		status int
+		body   string
+	}{
+		{name: "valid session", cookie: seal(o, live), status: http.StatusOK, body: "bob team-b"},
+		{name: "expired session", cookie: seal(o, expired), status: http.StatusUnauthorized},
+		{name: "forged signature", cookie: seal(forged, live), status: http.StatusUnauthorized},
+		{name: "malformed", cookie: "garbage", status: http.StatusUnauthorized},
+		{name: "no session", status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/test", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: SessionCookie, Value: tt.cookie})
+			}
+
+			w := serveResolver(o, req)
+			if w.Code != tt.status {
+				t.Fatalf("got %d, want %d", w.Code, tt.status)
+			}
+			if tt.body != "" && w.Body.String() != tt.body {
+				t.Fatalf("got caller %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
+
+// blockingJWKS serves the key set once, then holds every further fetch until
+// release is closed.
+type blockingJWKS struct {
+	testJWKS
+	release chan struct{}
+
+	mu      sync.Mutex
+	fetches int
+}
+
+func (j *blockingJWKS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	j.mu.Lock()
+	j.fetches++
+	first := j.fetches == 1
+	j.mu.Unlock()
+
+	if !first {
+		<-j.release
+	}
+	j.testJWKS.ServeHTTP(w, r)
+}
+
+func TestJWKSFetchDoesNotBlockKnownKeys(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	provider := &blockingJWKS{testJWKS: testJWKS{key: key}, release: make(chan struct{})}
+	server := httptest.NewServer(provider)
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(provider.release) })
+
+	keys := &jwks{url: server.URL, refresh: time.Hour, client: server.Client()}
+	if _, err := keys.key(context.Background(), "test-key"); err != nil {
+		t.Fatalf("initial fetch failed: %v", err)
+	}
+
+	// An unknown key ID triggers a fetch the provider holds
+	keys.mu.Lock()
+	keys.fetchedAt = time.Now().Add(-2 * jwksMinRefresh)
+	keys.mu.Unlock()
+	go keys.key(context.Background(), "rotated-key")
+	for {
+		provider.mu.Lock()
+		fetches := provider.fetches
+		provider.mu.Unlock()
+		if fetches == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if _, err := keys.key(ctx, "test-key"); err != nil {
+		t.Fatalf("known key waited for the fetch in progress: %v", err)
+	}
+}
@@ -0,0 +1,231 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v3"
+	"github.com/go-jose/go-jose/v3/jwt"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// jwksMinRefresh limits how often an unknown key ID triggers a JWKS fetch,
+// so tokens with made-up key IDs cannot hammer the identity provider.
+const jwksMinRefresh = time.Minute
+
+// JWTResolver identifies callers by a bearer JWT. The subject becomes the
+// caller ID, and the tenant and groups are read from the configured claims.
+type JWTResolver struct {
+	cfg  config.JWTAuthConfig
+	jwks *jwks
+}
+
+func NewJWTResolver(cfg config.JWTAuthConfig) *JWTResolver {
+	return &JWTResolver{
+		cfg: cfg,
+		jwks: &jwks{
+			url:     cfg.JWKSURL,
+			refresh: cfg.JWKSRefresh,
+			client:  &http.Client{Timeout: 10 * time.Second},
+		},
+	}
+}
+
+func (r *JWTResolver) Name() string { return "jwt" }
+
+func (r *JWTResolver) Resolve(c *gin.Context) (*Caller, error) {
+	raw, ok := bearerToken(c)
+	if !ok {
+		return nil, nil
+	}
+
+	claims, custom, err := verifyJWT(c.Request.Context(), raw, r.jwks, jwt.Expected{
+		Issuer:   r.cfg.Issuer,
+		Audience: jwt.Audience{r.cfg.Audience},
+	}, r.cfg.Leeway)
+	if err != nil {
+		return nil, err
+	}
+
+	caller := &Caller{
+		ID:     claims.Subject,
+		Groups: stringClaims(custom[r.cfg.GroupsClaim]),
+		Method: "jwt",
+		Claims: custom,
+	}
+	caller.Tenant, _ = custom[r.cfg.TenantClaim].(string)
+	return caller, nil
+}
+
+// verifyJWT checks the signature of a JWT against the key set and its issuer,
+// audience and validity window, returning its registered and custom claims.
+// Tokens without a subject are rejected.
+func verifyJWT(ctx context.Context, raw string, keys *jwks, expected jwt.Expected, leeway time.Duration) (*jwt.Claims, map[string]interface{}, error) {
+	token, err := jwt.ParseSigned(raw)
+	if err != nil {
+		return nil, nil, fmt.Errorf("malformed JWT: %w", err)
+	}
+	if len(token.Headers) != 1 {
+		return nil, nil, errors.New("JWT must carry exactly one signature")
+	}
+
+	key, err := keys.key(ctx, token.Headers[0].KeyID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		claims jwt.Claims
+		custom map[string]interface{}
+	)
+	if err := token.Claims(key, &claims, &custom); err != nil {
+		return nil, nil, fmt.Errorf("invalid JWT signature: %w", err)
+	}
+
+	if err := claims.ValidateWithLeeway(expected.WithTime(time.Now()), leeway); err != nil {
+		return nil, nil, fmt.Errorf("invalid JWT claims: %w", err)
+	}
+	if claims.Subject == "" {
+		return nil, nil, errors.New("JWT has no subject")
+	}
+	return &claims, custom, nil
+}
+
+// bearerToken returns the token of an Authorization: Bearer header.
+func bearerToken(c *gin.Context) (string, bool) {
+	scheme, token, ok := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// stringClaims reads a claim holding a string or a list of strings.
+func stringClaims(value interface{}) []string {
+	switch value := value.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		result := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// jwks caches the JSON Web Key Set of the identity provider, fetching it
+// again every refresh interval or when a token names an unknown key. Fetches
+// happen outside the lock, so verifying tokens with known keys never waits
+// for the provider.
+type jwks struct {
+	url     string
+	refresh time.Duration
+	client  *http.Client
+
+	mu   sync.Mutex
+	keys jose.JSONWebKeySet
+	// fetchedAt is the last fetch attempt, successful or not
+	fetchedAt time.Time
+	// fetching is closed when the fetch in progress ends, and nil while
+	// there is none
+	fetching chan struct{}
+}
+
+func (k *jwks) key(ctx context.Context, kid string) (*jose.JSONWebKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	var err error
+	age := time.Since(k.fetchedAt)
+	known := len(k.keys.Key(kid)) > 0
+	if age > k.refresh || (!known && age > jwksMinRefresh) {
+		err = k.refetch(ctx)
+	} else if !known && k.fetching != nil {
+		// The key may be in the key set being fetched
+		err = k.wait(ctx)
+	}
+
+	keys := k.keys.Key(kid)
+	if len(keys) == 0 {
+		// Keys already fetched stay usable while the provider is unreachable
+		if err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unknown JWT key ID %q", kid)
+	}
+	return &keys[0], nil
+}
+
+// refetch fetches the key set, or waits for the fetch already in progress.
+// k.mu must be held; it is released during the fetch.
+func (k *jwks) refetch(ctx context.Context) error {
+	if k.fetching != nil {
+		return k.wait(ctx)
+	}
+
+	done := make(chan struct{})
+	k.fetching = done
+	k.fetchedAt = time.Now()
+	k.mu.Unlock()
+
+	keys, err := k.fetch(ctx)
+
+	k.mu.Lock()
+	if err == nil {
+		k.keys = keys
+	}
+	k.fetching = nil
+	close(done)
+	return err
+}
+
+// wait waits for the fetch in progress to end. k.mu must be held; it is
+// released while waiting.
+func (k *jwks) wait(ctx context.Context) error {
+	done := k.fetching
+	k.mu.Unlock()
+	defer k.mu.Lock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (k *jwks) fetch(ctx context.Context) (jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, k.url, nil)
+	if err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to build JWKS request: %w", err)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to fetch JWKS: unexpected status %d", resp.StatusCode)
+	}
+
+	var keys jose.JSONWebKeySet
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return jose.JSONWebKeySet{}, fmt.Errorf("failed to decode JWKS: %w", err)
+	}
+	return keys, nil
+}
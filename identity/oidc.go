@@ -0,0 +1,372 @@
+package identity
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-jose/go-jose/v3/jwt"
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+const (
+	// SessionCookie carries the signed session of a caller who logged in
+	// through OIDC.
+	SessionCookie = "hcvapi_session"
+	// stateCookie carries the state and nonce of a login in progress.
+	stateCookie = "hcvapi_oidc_state"
+	// stateTTL bounds how long a login may take at the identity provider.
+	stateTTL = 10 * time.Minute
+)
+
+// OIDC logs human operators in with the authorization code flow of an
+// OpenID Connect provider and identifies them afterwards by a signed session
+// cookie. The session holds the caller itself, so no server-side state is
+// kept and any replica can verify it.
+type OIDC struct {
+	cfg    config.OIDCAuthConfig
+	secure bool
+	client *http.Client
+	logger *logrus.Logger
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	jwks      *jwks
+}
+
+// oidcDiscovery is the part of the provider's discovery document hcvapi uses.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// session is the payload of the session cookie.
+type session struct {
+	Subject string   `json:"sub"`
+	Tenant  string   `json:"tenant,omitempty"`
+	Groups  []string `json:"groups,omitempty"`
+	Expiry  int64    `json:"exp"`
+}
+
+func NewOIDC(cfg config.OIDCAuthConfig, logger *logrus.Logger) *OIDC {
+	return &OIDC{
+		cfg:    cfg,
+		secure: strings.HasPrefix(cfg.RedirectURL, "https://"),
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logger,
+	}
+}
+
+func (o *OIDC) Name() string { return "oidc" }
+
+// Resolve identifies the caller by the session cookie.
+func (o *OIDC) Resolve(c *gin.Context) (*Caller, error) {
+	value, err := c.Cookie(SessionCookie)
+	if err != nil || value == "" {
+		return nil, nil
+	}
+
+	var s session
+	if err := o.open(value, &s); err != nil {
+		return nil, fmt.Errorf("invalid session: %w", err)
+	}
+	if time.Now().Unix() >= s.Expiry {
+		return nil, errors.New("session expired, log in again")
+	}
+
+	return &Caller{
+		ID:     s.Subject,
+		Tenant: s.Tenant,
+		Groups: s.Groups,
+		Method: "oidc",
+	}, nil
+}
+
+// Login redirects the browser to the identity provider, remembering the
+// state and nonce of the login in a short-lived cookie.
+func (o *OIDC) Login(c *gin.Context) {
+	discovery, err := o.discover(c.Request.Context())
+	if err != nil {
+		o.logger.WithError(err).Error("Failed to discover OIDC provider")
+		c.JSON(http.StatusBadGateway, gin.H{
+			"error":   "Identity provider unavailable",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	state, err := randomToken()
+	nonce, nonceErr := randomToken()
+	if err = errors.Join(err, nonceErr); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to start login",
+			"details": err.Error(),
+		})
+		return
+	}
+	o.setCookie(c, stateCookie, state+"."+nonce, stateTTL)
+
+	scopes := append([]string{"openid"}, o.cfg.Scopes...)
+	query := url.Values{
+		"response_type": {"code"},
+		"client_id":     {o.cfg.ClientID},
+		"redirect_uri":  {o.cfg.RedirectURL},
+		"scope":         {strings.Join(scopes, " ")},
+		"state":         {state},
+		"nonce":         {nonce},
+	}
+	c.Redirect(http.StatusFound, discovery.AuthorizationEndpoint+"?"+query.Encode())
+}
+
+// Callback completes a login: it exchanges the authorization code for an ID
+// token, verifies it and sets the session cookie.
+func (o *OIDC) Callback(c *gin.Context) {
+	if errorCode := c.Query("error"); errorCode != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Login failed",
+			"details": strings.TrimSpace(errorCode + ": " + c.Query("error_description")),
+		})
+		return
+	}
+
+	stored, _ := c.Cookie(stateCookie)
+	o.setCookie(c, stateCookie, "", -1)
+	state, nonce, _ := strings.Cut(stored, ".")
+	if state == "" || !hmac.Equal([]byte(state), []byte(c.Query("state"))) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Login state mismatch, start the login again",
+		})
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Authorization code is required",
+		})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 15*time.Second)
+	defer cancel()
+
+	caller, err := o.exchange(ctx, code, nonce)
+	if err != nil {
+		o.logger.WithError(err).Warn("OIDC login failed")
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error":   "Login failed",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	value, err := o.seal(&session{
+		Subject: caller.ID,
+		Tenant:  caller.Tenant,
+		Groups:  caller.Groups,
+		Expiry:  time.Now().Add(o.cfg.SessionTTL).Unix(),
+	})
+	if err != nil {
+		o.logger.WithError(err).Error("Failed to create session")
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to create session",
+			"details": err.Error(),
+		})
+		return
+	}
+	o.setCookie(c, SessionCookie, value, o.cfg.SessionTTL)
+
+	o.logger.WithFields(logrus.Fields{
+		"caller": caller.ID,
+		"tenant": caller.Tenant,
+	}).Info("OIDC login succeeded")
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged in successfully",
+		"data":    caller,
+	})
+}
+
+// Logout clears the session cookie.
+func (o *OIDC) Logout(c *gin.Context) {
+	o.setCookie(c, SessionCookie, "", -1)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Logged out successfully",
+	})
+}
+
+// exchange redeems an authorization code at the token endpoint and verifies
+// the ID token returned.
+func (o *OIDC) exchange(ctx context.Context, code, nonce string) (*Caller, error) {
+	discovery, err := o.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {o.cfg.RedirectURL},
+		"client_id":     {o.cfg.ClientID},
+		"client_secret": {o.cfg.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeem authorization code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to redeem authorization code: unexpected status %d", resp.StatusCode)
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("token response has no ID token")
+	}
+
+	claims, custom, err := verifyJWT(ctx, tokens.IDToken, o.jwks, jwt.Expected{
+		Issuer:   discovery.Issuer,
+		Audience: jwt.Audience{o.cfg.ClientID},
+	}, o.cfg.Leeway)
+	if err != nil {
+		return nil, err
+	}
+	if got, _ := custom["nonce"].(string); nonce == "" || !hmac.Equal([]byte(got), []byte(nonce)) {
+		return nil, errors.New("ID token nonce mismatch")
+	}
+
+	caller := &Caller{
+		ID:     claims.Subject,
+		Groups: stringClaims(custom[o.cfg.GroupsClaim]),
+		Method: "oidc",
+	}
+	caller.Tenant, _ = custom[o.cfg.TenantClaim].(string)
+	return caller, nil
+}
+
+// discover fetches the provider's discovery document once and keeps it for
+// the life of the process.
+func (o *OIDC) discover(ctx context.Context) (*oidcDiscovery, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.discovery != nil {
+		return o.discovery, nil
+	}
+
+	issuer := strings.TrimSuffix(o.cfg.IssuerURL, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build discovery request: %w", err)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: unexpected status %d", resp.StatusCode)
+	}
+
+	var discovery oidcDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("OIDC discovery document is for issuer %q", discovery.Issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is incomplete")
+	}
+
+	o.discovery = &discovery
+	o.jwks = &jwks{
+		url:     discovery.JWKSURI,
+		refresh: o.cfg.JWKSRefresh,
+		client:  o.client,
+	}
+	return o.discovery, nil
+}
+
+// seal encodes a payload as base64url(JSON) "." base64url(HMAC-SHA256).
+func (o *OIDC) seal(payload interface{}) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(o.sign(encoded)), nil
+}
+
+// open verifies a sealed value and decodes its payload.
+func (o *OIDC) open(value string, payload interface{}) error {
+	encoded, signature, ok := strings.Cut(value, ".")
+	if !ok {
+		return errors.New("malformed session cookie")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, o.sign(encoded)) {
+		return errors.New("session signature mismatch")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return errors.New("malformed session cookie")
+	}
+	return json.Unmarshal(data, payload)
+}
+
+func (o *OIDC) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, []byte(o.cfg.SessionSecret))
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
+
+// setCookie sets an HTTP-only cookie; a negative maxAge deletes it. SameSite
+// Lax keeps the session off cross-site POSTs while still letting the
+// provider redirect back to the callback.
+func (o *OIDC) setCookie(c *gin.Context, name, value string, maxAge time.Duration) {
+	seconds := int(maxAge / time.Second)
+	if maxAge < 0 {
+		seconds = -1
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(name, value, seconds, "/", "", o.secure, true)
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
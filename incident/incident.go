@@ -0,0 +1,289 @@
+package incident
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Revocation actions, run in this order for every roleset.
+const (
+	ActionRevokeLeases = "revoke_leases"
+	ActionRotate       = "rotate"
+)
+
+// Task states.
+const (
+	StateQueued  = "queued"
+	StateRunning = "running"
+	StateDone    = "done"
+	StateFailed  = "failed"
+)
+
+// Spec selects what an incident contains. Issuance is paused for the listed
+// rolesets and tenants; with Revoke, the rolesets' credentials are revoked.
+type Spec struct {
+	Mount    string   `json:"mount,omitempty"`
+	Rolesets []string `json:"rolesets"`
+	Tenants  []string `json:"tenants"`
+	Revoke   bool     `json:"revoke"`
+	Reason   string   `json:"reason,omitempty"`
+}
+
+type Task struct {
+	Mount   string `json:"mount"`
+	Roleset string `json:"roleset"`
+	Action  string `json:"action"`
+	State   string `json:"state"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Status reports the incident. Paused rolesets are listed as
+// {mount}/{roleset}.
+type Status struct {
+	Active     bool      `json:"active"`
+	DeclaredAt time.Time `json:"declared_at,omitempty"`
+	Reasons    []string  `json:"reasons,omitempty"`
+	Rolesets   []string  `json:"paused_rolesets"`
+	Tenants    []string  `json:"paused_tenants"`
+	Tasks      []Task    `json:"tasks"`
+	Total      int       `json:"total"`
+	Completed  int       `json:"completed"`
+	Failed     int       `json:"failed"`
+}
+
+// Controller holds the incident state and works off its revocation queue.
+// Revocations run one after another as soon as they are queued, ahead of any
+// other background work.
+type Controller struct {
+	mu          sync.Mutex
+	vaultClient *vault.Client
+	logger      *logrus.Logger
+	active      bool
+	declaredAt  time.Time
+	reasons     []string
+	rolesets    map[string]bool
+	tenants     map[string]bool
+	tasks       []Task
+	next        int
+	wake        chan struct{}
+	// cleared counts the finished tasks dropped by Resolve, so task indexes
+	// handed out by dequeue stay valid
+	cleared int
+}
+
+func NewController(vaultClient *vault.Client, logger *logrus.Logger) *Controller {
+	return &Controller{
+		vaultClient: vaultClient,
+		logger:      logger,
+		rolesets:    map[string]bool{},
+		tenants:     map[string]bool{},
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Declare starts an incident, or widens the active one, and queues the
+// revocations it asks for.
+func (c *Controller) Declare(spec Spec) (*Status, error) {
+	mountClient, ok := c.vaultClient.Mount(spec.Mount)
+	if !ok {
+		return nil, fmt.Errorf("unknown GCP mount %q", spec.Mount)
+	}
+	if len(spec.Rolesets) == 0 && len(spec.Tenants) == 0 {
+		return nil, fmt.Errorf("at least one roleset or tenant is required")
+	}
+
+	c.mu.Lock()
+	if !c.active {
+		c.active = true
+		c.declaredAt = time.Now().UTC()
+	}
+	if spec.Reason != "" {
+		c.reasons = append(c.reasons, spec.Reason)
+	}
+	for _, roleset := range spec.Rolesets {
+		c.rolesets[rolesetKey(mountClient.MountPath(), roleset)] = true
+		if spec.Revoke {
+			for _, action := range []string{ActionRevokeLeases, ActionRotate} {
+				c.tasks = append(c.tasks, Task{
+					Mount:   mountClient.MountPath(),
+					Roleset: roleset,
+					Action:  action,
+					State:   StateQueued,
+				})
+			}
+		}
+	}
+	for _, tenant := range spec.Tenants {
+		c.tenants[tenant] = true
+	}
+	c.mu.Unlock()
+
+	c.logger.WithFields(logrus.Fields{
+		"mount":    mountClient.MountPath(),
+		"rolesets": spec.Rolesets,
+		"tenants":  spec.Tenants,
+		"revoke":   spec.Revoke,
+		"reason":   spec.Reason,
+	}).Warn("Incident mode declared, issuance paused")
+
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+
+	return c.Status(), nil
+}
+
+// Resolve ends the incident and resumes issuance. Finished revocations are
+// cleared; queued ones still run to completion.
+func (c *Controller) Resolve() *Status {
+	c.mu.Lock()
+	c.active = false
+	c.reasons = nil
+	c.rolesets = map[string]bool{}
+	c.tenants = map[string]bool{}
+	finished := c.next
+	if finished > 0 && c.tasks[finished-1].State == StateRunning {
+		finished--
+	}
+	c.tasks = append([]Task(nil), c.tasks[finished:]...)
+	c.next -= finished
+	c.cleared += finished
+	c.mu.Unlock()
+
+	c.logger.Warn("Incident mode resolved, issuance resumed")
+	return c.Status()
+}
+
+// Paused reports whether issuance is paused for the tenant or the roleset on
+// mount. Tenant and roleset may be empty.
+func (c *Controller) Paused(tenant, mount, roleset string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.active {
+		return false
+	}
+	return (tenant != "" && c.tenants[tenant]) || (roleset != "" && c.rolesets[rolesetKey(mount, roleset)])
+}
+
+func (c *Controller) Status() *Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := &Status{
+		Active:   c.active,
+		Reasons:  append([]string(nil), c.reasons...),
+		Rolesets: keys(c.rolesets),
+		Tenants:  keys(c.tenants),
+		Tasks:    append([]Task{}, c.tasks...),
+		Total:    len(c.tasks),
+	}
+	if c.active {
+		status.DeclaredAt = c.declaredAt
+	}
+	for _, task := range c.tasks {
+		switch task.State {
+		case StateDone:
+			status.Completed++
+		case StateFailed:
+			status.Failed++
+		}
+	}
+	return status
+}
+
+// Run works off queued revocations until ctx is done.
+func (c *Controller) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.wake:
+		}
+
+		for {
+			task, index, ok := c.dequeue()
+			if !ok {
+				break
+			}
+			c.finish(index, c.process(ctx, task))
+		}
+	}
+}
+
+func (c *Controller) dequeue() (Task, int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.next >= len(c.tasks) {
+		return Task{}, 0, false
+	}
+
+	index := c.next
+	c.next++
+	c.tasks[index].State = StateRunning
+	return c.tasks[index], c.cleared + index, true
+}
+
+func (c *Controller) process(ctx context.Context, task Task) error {
+	mountClient, ok := c.vaultClient.Mount(task.Mount)
+	if !ok {
+		return fmt.Errorf("unknown GCP mount %q", task.Mount)
+	}
+
+	taskCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	switch task.Action {
+	case ActionRevokeLeases:
+		return mountClient.RevokeRolesetLeases(taskCtx, task.Roleset)
+	case ActionRotate:
+		return mountClient.RotateRoleset(taskCtx, task.Roleset)
+	default:
+		return fmt.Errorf("unknown action %q", task.Action)
+	}
+}
+
+func (c *Controller) finish(index int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	task := &c.tasks[index-c.cleared]
+	entry := c.logger.WithFields(logrus.Fields{
+		"roleset": task.Roleset,
+		"action":  task.Action,
+	})
+
+	if err != nil {
+		task.State = StateFailed
+		task.Error = err.Error()
+		entry.WithError(err).Error("Incident revocation failed")
+		return
+	}
+
+	task.State = StateDone
+	entry.Info("Incident revocation completed")
+}
+
+// rolesetKey identifies a roleset in the paused set. Rolesets on different
+// mounts may share a name, so the key includes the mount.
+func rolesetKey(mount, roleset string) string {
+	return mount + "/" + roleset
+}
+
+func keys(set map[string]bool) []string {
+	result := make([]string, 0, len(set))
+	for key := range set {
+		result = append(result, key)
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,68 @@
+package incident
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/cache"
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+func newTestController(t *testing.T) *Controller {
+	t.Helper()
+
+	cfg := &config.Config{}
+	cfg.GCP.MountPath = "gcp"
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	client, err := vault.NewClient(cfg, cache.NewMemory(), logger)
+	if err != nil {
+		t.Fatalf("failed to create vault client: %v", err)
+	}
+	return NewController(client, logger)
+}
+
+func TestResolveClearsFinishedTasks(t *testing.T) {
+	c := newTestController(t)
+
+	if _, err := c.Declare(Spec{Rolesets: []string{"ci-deployer"}, Revoke: true}); err != nil {
+		t.Fatalf("Declare failed: %v", err)
+	}
+
+	// Leases revoked, rotation still running when the incident is resolved
+	_, index, _ := c.dequeue()
+	c.finish(index, nil)
+	_, running, _ := c.dequeue()
+
+	status := c.Resolve()
+	if status.Active || len(status.Rolesets) != 0 {
+		t.Fatalf("incident still active after Resolve: %+v", status)
+	}
+	if status.Total != 1 || status.Tasks[0].Action != ActionRotate || status.Tasks[0].State != StateRunning {
+		t.Fatalf("expected only the running rotation to remain, got %+v", status.Tasks)
+	}
+
+	c.finish(running, errors.New("rotation failed"))
+	if status := c.Status(); status.Failed != 1 || status.Tasks[0].Error != "rotation failed" {
+		t.Fatalf("running task not finished after Resolve: %+v", status.Tasks)
+	}
+
+	// The next incident starts with only its own tasks
+	c.Resolve()
+	if _, err := c.Declare(Spec{Rolesets: []string{"ci-reader"}, Revoke: true}); err != nil {
+		t.Fatalf("Declare failed: %v", err)
+	}
+	status = c.Status()
+	if status.Total != 2 || status.Tasks[0].Roleset != "ci-reader" {
+		t.Fatalf("expected the new incident's tasks only, got %+v", status.Tasks)
+	}
+	if task, _, ok := c.dequeue(); !ok || task.Roleset != "ci-reader" || task.Action != ActionRevokeLeases {
+		t.Fatalf("expected the new incident's first task, got %+v", task)
+	}
+}
@@ -0,0 +1,169 @@
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Component is a subsystem with a managed lifetime. Components are started in
+// the order they are added and stopped in reverse, so a component may depend
+// on everything added before it.
+type Component interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	// HealthReport returns nil while the component is healthy.
+	HealthReport(ctx context.Context) error
+}
+
+// Hooks adapts plain functions to a Component. Unset hooks are no-ops.
+type Hooks struct {
+	ComponentName string
+	OnStart       func(ctx context.Context) error
+	OnStop        func(ctx context.Context) error
+	OnHealth      func(ctx context.Context) error
+}
+
+func (h *Hooks) Name() string { return h.ComponentName }
+
+func (h *Hooks) Start(ctx context.Context) error {
+	if h.OnStart == nil {
+		return nil
+	}
+	return h.OnStart(ctx)
+}
+
+func (h *Hooks) Stop(ctx context.Context) error {
+	if h.OnStop == nil {
+		return nil
+	}
+	return h.OnStop(ctx)
+}
+
+func (h *Hooks) HealthReport(ctx context.Context) error {
+	if h.OnHealth == nil {
+		return nil
+	}
+	return h.OnHealth(ctx)
+}
+
+type Status struct {
+	Name    string `json:"name"`
+	Started bool   `json:"started"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Manager starts, stops and reports on components.
+type Manager struct {
+	mu         sync.Mutex
+	components []Component
+	started    map[string]bool
+	logger     *logrus.Logger
+}
+
+func NewManager(logger *logrus.Logger) *Manager {
+	return &Manager{
+		started: map[string]bool{},
+		logger:  logger,
+	}
+}
+
+func (m *Manager) Add(components ...Component) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.components = append(m.components, components...)
+}
+
+// Start starts all components in order. If one fails, the components already
+// started are stopped again and the error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	for _, component := range m.snapshot() {
+		m.logger.WithField("component", component.Name()).Info("Starting component...")
+
+		if err := component.Start(ctx); err != nil {
+			m.Stop(ctx)
+			return fmt.Errorf("failed to start %s: %w", component.Name(), err)
+		}
+
+		m.mu.Lock()
+		m.started[component.Name()] = true
+		m.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Stop stops the started components in reverse order. Errors are logged so
+// every component gets the chance to stop.
+func (m *Manager) Stop(ctx context.Context) {
+	components := m.snapshot()
+
+	for i := len(components) - 1; i >= 0; i-- {
+		component := components[i]
+
+		m.mu.Lock()
+		started := m.started[component.Name()]
+		delete(m.started, component.Name())
+		m.mu.Unlock()
+
+		if !started {
+			continue
+		}
+
+		logger := m.logger.WithField("component", component.Name())
+		if err := component.Stop(ctx); err != nil {
+			logger.WithError(err).Error("Failed to stop component")
+			continue
+		}
+		logger.Info("Component stopped")
+	}
+}
+
+// Started reports whether every component is started.
+func (m *Manager) Started() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, component := range m.components {
+		if !m.started[component.Name()] {
+			return false
+		}
+	}
+	return true
+}
+
+// Health reports the status of every component and whether all of them are
+// started and healthy.
+func (m *Manager) Health(ctx context.Context) ([]Status, bool) {
+	statuses := []Status{}
+	healthy := true
+
+	for _, component := range m.snapshot() {
+		m.mu.Lock()
+		status := Status{Name: component.Name(), Started: m.started[component.Name()]}
+		m.mu.Unlock()
+
+		if status.Started {
+			if err := component.HealthReport(ctx); err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Healthy = true
+			}
+		}
+
+		healthy = healthy && status.Healthy
+		statuses = append(statuses, status)
+	}
+
+	return statuses, healthy
+}
+
+func (m *Manager) snapshot() []Component {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Component(nil), m.components...)
+}
@@ -0,0 +1,115 @@
+package logging
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Redacted replaces secrets removed from log entries.
+const Redacted = "[REDACTED]"
+
+// sensitiveFields are the field names whose values are always redacted,
+// whatever they hold.
+var sensitiveFields = map[string]bool{
+	"token":            true,
+	"access_token":     true,
+	"client_token":     true,
+	"vault_token":      true,
+	"wrapping_token":   true,
+	"private_key_data": true,
+	"private_key":      true,
+	"secret_id":        true,
+	"secret_key":       true,
+	"client_secret":    true,
+	"session_secret":   true,
+	"password":         true,
+	"bindpass":         true,
+	"api_key":          true,
+	"authorization":    true,
+	"cookie":           true,
+}
+
+// sensitivePatterns find secrets embedded in messages and field values, such
+// as Vault error messages quoting a response body.
+var sensitivePatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	// PEM private keys
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`), Redacted},
+	// Secret values in JSON or key=value text
+	{regexp.MustCompile(`(?i)((?:"|\b)(?:token|access_token|client_token|wrapping_token|private_key_data|private_key|secret_id|secret_key|client_secret|password|bindpass)"?\s*[:=]\s*)(?:"(?:[^"\\]|\\.)*"|[^\s,}&]+)`), `${1}"` + Redacted + `"`},
+	// Authorization and Vault token headers
+	{regexp.MustCompile(`(?i)(\bBearer\s+)[A-Za-z0-9._~+/=-]+`), `${1}` + Redacted},
+	{regexp.MustCompile(`(?i)(X-Vault-Token"?\s*[:=]\s*"?)[A-Za-z0-9._-]+`), `${1}` + Redacted},
+	// Vault service, batch and recovery tokens, in the current and legacy formats
+	{regexp.MustCompile(`\bhv[sbr]\.[A-Za-z0-9_-]{20,}`), Redacted},
+	{regexp.MustCompile(`\b[sbr]\.[A-Za-z0-9]{24}\b`), Redacted},
+	// Google OAuth2 access tokens
+	{regexp.MustCompile(`\bya29\.[A-Za-z0-9._-]+`), Redacted},
+}
+
+// RedactingFormatter removes tokens, private keys and other secrets from
+// entries before the wrapped formatter renders them, so no log level or
+// error message can leak them.
+type RedactingFormatter struct {
+	logrus.Formatter
+}
+
+// NewRedactingFormatter wraps formatter.
+func NewRedactingFormatter(formatter logrus.Formatter) *RedactingFormatter {
+	return &RedactingFormatter{Formatter: formatter}
+}
+
+func (f *RedactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	// The entry is shared with hooks, so the redacted one is a copy
+	redacted := *entry
+	redacted.Message = RedactString(entry.Message)
+	redacted.Data = make(logrus.Fields, len(entry.Data))
+	for key, value := range entry.Data {
+		redacted.Data[key] = redactValue(key, value)
+	}
+	return f.Formatter.Format(&redacted)
+}
+
+// RedactString removes the secrets sensitivePatterns find in s.
+func RedactString(s string) string {
+	for _, pattern := range sensitivePatterns {
+		s = pattern.re.ReplaceAllString(s, pattern.replacement)
+	}
+	return s
+}
+
+func redactValue(key string, value interface{}) interface{} {
+	if sensitiveFields[strings.ToLower(key)] {
+		return Redacted
+	}
+
+	switch v := value.(type) {
+	case string:
+		return RedactString(v)
+	case error:
+		return RedactString(v.Error())
+	case []string:
+		redacted := make([]string, len(v))
+		for i, s := range v {
+			redacted[i] = RedactString(s)
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for k, s := range v {
+			redacted[k] = redactValue(k, s).(string)
+		}
+		return redacted
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for k, s := range v {
+			redacted[k] = redactValue(k, s)
+		}
+		return redacted
+	}
+	return value
+}
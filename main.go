@@ -12,15 +12,24 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 
+	"github.com/kalpesh172000/hcvapi/audit"
+	"github.com/kalpesh172000/hcvapi/cache"
 	"github.com/kalpesh172000/hcvapi/config"
 	"github.com/kalpesh172000/hcvapi/handlers"
+	"github.com/kalpesh172000/hcvapi/identity"
+	"github.com/kalpesh172000/hcvapi/incident"
+	"github.com/kalpesh172000/hcvapi/lifecycle"
+	"github.com/kalpesh172000/hcvapi/logging"
+	"github.com/kalpesh172000/hcvapi/reports"
+	"github.com/kalpesh172000/hcvapi/usage"
 	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/kalpesh172000/hcvapi/webhook"
 )
 
 func main() {
-	// Initialize logger
+	// Initialize logger; secrets are redacted from every entry
 	logger := logrus.New()
-	logger.SetFormatter(&logrus.JSONFormatter{})
+	logger.SetFormatter(logging.NewRedactingFormatter(&logrus.JSONFormatter{}))
 	logger.SetLevel(logrus.InfoLevel)
 
 	// Load configuration
@@ -35,40 +44,86 @@ func main() {
 		"gcp_project":   cfg.GCP.ProjectID,
 	}).Info("Configuration loaded successfully")
 
+	// Initialize cache backend
+	store, err := cache.New(cfg.Cache)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to create cache backend")
+	}
+
 	// Initialize Vault client
-	vaultClient, err := vault.NewClient(cfg, logger)
+	vaultClient, err := vault.NewClient(cfg, store, logger)
 	if err != nil {
 		logger.WithError(err).Fatal("Failed to create Vault client")
 	}
 
-	// Initialize Vault GCP secrets engine
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
+	// Usage recording and scheduled reports
+	recorder := usage.NewRecorder()
+	reportScheduler := reports.NewScheduler(cfg.Reports, recorder, vaultClient, logger)
 
-	if err := vaultClient.Initialize(ctx); err != nil {
-		logger.WithError(err).Fatal("Failed to initialize Vault GCP secrets engine")
-	}
+	// Incident mode: issuance pauses and priority revocations
+	incidents := incident.NewController(vaultClient, logger)
 
-	// Perform initial health check
-	if err := vaultClient.HealthCheck(ctx); err != nil {
-		logger.WithError(err).Fatal("Initial Vault health check failed")
+	// Audit log of secret issuance and roleset changes
+	auditLogger, err := audit.New(cfg.AuditLog, logger)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to create audit logger")
 	}
 
+	// Signed webhook notifications of roleset changes and key issuance
+	webhooks := webhook.New(cfg.Webhooks, cfg.Tenants, logger)
+
+	// Components are started in order and stopped in reverse
+	components := lifecycle.NewManager(logger)
+
 	// Initialize handlers
-	handler := handlers.NewHandler(vaultClient, logger)
+	handler := handlers.NewHandler(cfg, handlers.Services{
+		Vault:      vaultClient,
+		Cache:      store,
+		Usage:      recorder,
+		Reports:    reportScheduler,
+		Components: components,
+		Incidents:  incidents,
+		Audit:      auditLogger,
+		Webhooks:   webhooks,
+	}, logger)
 
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 
+	// Forwarding headers are only believed from trusted proxies
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		logger.WithError(err).Fatal("Invalid trusted proxies")
+	}
+
 	// Add middlewares
 	router.Use(handler.ErrorHandlingMiddleware())
 	router.Use(handler.LoggingMiddleware())
+	router.Use(handler.CORSMiddleware())
+
+	// Identify API consumers; resolvers for each authentication method are
+	// appended to the chain
+	var resolvers []identity.Resolver
+	if len(cfg.Auth.APIKeys.Keys) > 0 {
+		resolvers = append(resolvers, identity.NewAPIKeyResolver(cfg.Auth.APIKeys))
+	}
+	if cfg.Auth.JWT.JWKSURL != "" {
+		resolvers = append(resolvers, identity.NewJWTResolver(cfg.Auth.JWT))
+	}
+	var oidc *identity.OIDC
+	if cfg.Auth.OIDC.IssuerURL != "" {
+		oidc = identity.NewOIDC(cfg.Auth.OIDC, logger)
+		resolvers = append(resolvers, oidc)
+	}
+	// Client certificates identify callers that present no other credentials
+	if cfg.Server.TLS.CertFile != "" && cfg.Server.TLS.ClientAuth != config.ClientAuthNone {
+		resolvers = append(resolvers, identity.NewCertificateResolver(cfg.Auth.ClientCerts))
+	}
+	identityChain := identity.NewChain(cfg.Auth.Required, logger, resolvers...)
 
 	// Setup routes
-	setupRoutes(router, handler)
+	setupRoutes(router, handler, identityChain, oidc)
 
-	// Start server
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port),
 		Handler:      router,
@@ -76,21 +131,69 @@ func main() {
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
+	server.TLSConfig, err = serverTLSConfig(cfg.Server.TLS)
+	if err != nil {
+		logger.WithError(err).Fatal("Failed to configure TLS")
+	}
 
-	// Start server in a goroutine
-	go func() {
-		logger.WithField("address", server.Addr).Info("Starting server...")
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.WithError(err).Fatal("Failed to start server")
+	// In degraded mode the server listens right away and answers 503 until
+	// everything else has started
+	degraded := cfg.Vault.Startup.Mode == config.StartupDegraded
+
+	components.Add(cacheComponent(store))
+	components.Add(auditComponent(auditLogger))
+	components.Add(webhookComponent(webhooks))
+	if degraded {
+		components.Add(serverComponent(server, logger))
+	}
+	components.Add(vaultComponent(cfg, vaultClient, logger))
+	for _, engine := range handler.Engines() {
+		if engine.Enabled() {
+			components.Add(engineComponent(engine))
 		}
-	}()
+	}
+	components.Add(jobsComponent(cfg, vaultClient, reportScheduler, incidents))
+	if !degraded {
+		components.Add(serverComponent(server, logger))
+	}
+
+	// Waiting for Vault is bounded by vault.startup.timeout instead
+	var (
+		ctx    context.Context
+		cancel context.CancelFunc
+	)
+	if cfg.Vault.Startup.Mode == config.StartupFail {
+		ctx, cancel = context.WithTimeout(context.Background(), 60*time.Second)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
 
-	logger.Info("Server started successfully. Press Ctrl+C to shutdown...")
+	started := make(chan error, 1)
+	go func() {
+		started <- components.Start(ctx)
+	}()
 
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+
+	select {
+	case err := <-started:
+		cancel()
+		if err != nil {
+			logger.WithError(err).Fatal("Startup failed")
+		}
+
+		logger.Info("Server started successfully. Press Ctrl+C to shutdown...")
+		<-quit
+	case <-quit:
+		// Start stops the components it started when it is interrupted
+		cancel()
+		if err := <-started; err != nil {
+			logger.WithError(err).Info("Startup interrupted")
+			return
+		}
+	}
 
 	logger.Info("Shutting down server...")
 
@@ -98,39 +201,240 @@ func main() {
 	ctx, cancel = context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Shutdown the server gracefully
-	if err := server.Shutdown(ctx); err != nil {
-		logger.WithError(err).Fatal("Server forced to shutdown")
-	}
+	components.Stop(ctx)
 
 	logger.Info("Server shutdown completed")
 }
 
-func setupRoutes(router *gin.Engine, handler *handlers.Handler) {
+// setupRoutes registers all routes. Deprecated routes are wrapped with
+// handler.Deprecated(handlers.Deprecation{...}) so they emit Deprecation and
+// Sunset headers and show up in /api/v1/system/deprecations. High-impact
+// admin operations are wrapped with handler.ReplayProtectionMiddleware().
+func setupRoutes(router *gin.Engine, handler *handlers.Handler, identityChain *identity.Chain, oidc *identity.OIDC) {
 	// Health check
 	router.GET("/health", handler.HealthCheck)
+	router.GET("/readyz", handler.Readiness)
+	router.GET("/health/details", handler.HealthDetails)
+	router.GET("/health/vault", handler.VaultHealth)
 
-	// API v1 group
-	v1 := router.Group("/api/v1")
+	// OIDC login for human operators, when configured
+	if oidc != nil {
+		router.GET("/auth/oidc/login", oidc.Login)       // GET /auth/oidc/login
+		router.GET("/auth/oidc/callback", oidc.Callback) // GET /auth/oidc/callback
+		router.POST("/auth/oidc/logout", oidc.Logout)    // POST /auth/oidc/logout
+	}
+
+	// Strict headers for responses carrying tokens or keys
+	secretHeaders := handler.SecretHeadersMiddleware()
+
+	// Issuance is recorded in the audit log
+	issuanceAudit := handler.AuditMiddleware(audit.ActionIssue)
+
+	// Issuance is refused for rolesets and tenants paused by an incident
+	incidentGuard := handler.IncidentGuard()
+
+	// API v1 group; requests denied before reaching their route are audited
+	// too
+	v1 := router.Group("/api/v1", handler.AuditDeniedMiddleware(), handler.APIAccessMiddleware(), handler.RequestValidationMiddleware(), handler.StartupMiddleware(), identityChain.Middleware(), handler.TenantMiddleware(), handler.RequestContextMiddleware(), handler.AuthorizationMiddleware())
 	{
-		// Roleset management
-		rolesets := v1.Group("/rolesets")
+		// GCP secrets engine routes operate on the mount named in the mount
+		// header, or the default one, and on an explicit /mounts/{mount} prefix
+		setupGCPRoutes(v1.Group("", handler.MountMiddleware()), handler, secretHeaders, issuanceAudit, incidentGuard)
+		setupGCPRoutes(v1.Group("/mounts/:mount", handler.MountMiddleware()), handler, secretHeaders, issuanceAudit, incidentGuard)
+
+		// Wrapped tokens and keys are unwrapped in the namespace they were
+		// issued in
+		v1.POST("/unwrap", handler.MountMiddleware(), secretHeaders, handler.AuditMiddleware(audit.ActionUnwrap), incidentGuard, handler.Unwrap) // POST /api/v1/unwrap
+
+		// Optional secrets engines, answering 404 while disabled
+		for _, engine := range handler.Engines() {
+			engine.Routes(v1.Group("", handler.EngineMiddleware(engine)), secretHeaders, issuanceAudit, incidentGuard)
+		}
+
+		// KV v2 secrets; reads return secrets and are treated like issuance
+		kv := v1.Group("/kv")
+		{
+			kv.GET("/*path", secretHeaders, issuanceAudit, incidentGuard, handler.GetKVSecret) // GET /api/v1/kv/{path}
+			kv.PUT("/*path", handler.PutKVSecret)                                              // PUT /api/v1/kv/{path}
+			kv.DELETE("/*path", handler.DeleteKVSecret)                                        // DELETE /api/v1/kv/{path}
+		}
+		v1.POST("/kv-undelete/*path", handler.UndeleteKVSecret) // POST /api/v1/kv-undelete/{path}
+
+		// Leases of issued secrets, looked up in the namespace they were
+		// issued in
+		leases := v1.Group("/leases", handler.MountMiddleware())
+		{
+			leases.GET("/*lease_id", handler.LookupLease) // GET /api/v1/leases/{lease_id}
+			leases.POST("/revoke", handler.RevokeLease)   // POST /api/v1/leases/revoke
+		}
+
+		// Cubbyhole scratch space, owned by the token in the cubbyhole token header
+		cubbyhole := v1.Group("/cubbyhole")
+		{
+			cubbyhole.POST("", secretHeaders, handler.CreateCubbyhole)         // POST /api/v1/cubbyhole
+			cubbyhole.GET("/*path", secretHeaders, handler.GetCubbyholeSecret) // GET /api/v1/cubbyhole/{path}
+			cubbyhole.PUT("/*path", handler.PutCubbyholeSecret)                // PUT /api/v1/cubbyhole/{path}
+			cubbyhole.DELETE("/*path", handler.DeleteCubbyholeSecret)          // DELETE /api/v1/cubbyhole/{path}
+		}
+
+		// Vault identities: entities, their aliases and groups
+		identityGroup := v1.Group("/identity")
+		{
+			identityGroup.GET("/entities", handler.ListIdentityEntities)          // GET /api/v1/identity/entities
+			identityGroup.GET("/entities/:name", handler.GetIdentityEntity)       // GET /api/v1/identity/entities/{name}
+			identityGroup.POST("/entities/:name", handler.CreateIdentityEntity)   // POST /api/v1/identity/entities/{name}
+			identityGroup.PUT("/entities/:name", handler.UpdateIdentityEntity)    // PUT /api/v1/identity/entities/{name}
+			identityGroup.DELETE("/entities/:name", handler.DeleteIdentityEntity) // DELETE /api/v1/identity/entities/{name}
+
+			identityGroup.GET("/aliases", handler.ListIdentityAliases)        // GET /api/v1/identity/aliases
+			identityGroup.POST("/aliases", handler.CreateIdentityAlias)       // POST /api/v1/identity/aliases
+			identityGroup.GET("/aliases/:id", handler.GetIdentityAlias)       // GET /api/v1/identity/aliases/{id}
+			identityGroup.PUT("/aliases/:id", handler.UpdateIdentityAlias)    // PUT /api/v1/identity/aliases/{id}
+			identityGroup.DELETE("/aliases/:id", handler.DeleteIdentityAlias) // DELETE /api/v1/identity/aliases/{id}
+
+			identityGroup.GET("/groups", handler.ListIdentityGroups)           // GET /api/v1/identity/groups
+			identityGroup.GET("/groups/:name", handler.GetIdentityGroup)       // GET /api/v1/identity/groups/{name}
+			identityGroup.POST("/groups/:name", handler.CreateIdentityGroup)   // POST /api/v1/identity/groups/{name}
+			identityGroup.PUT("/groups/:name", handler.UpdateIdentityGroup)    // PUT /api/v1/identity/groups/{name}
+			identityGroup.DELETE("/groups/:name", handler.DeleteIdentityGroup) // DELETE /api/v1/identity/groups/{name}
+		}
+
+		// Administration
+		admin := v1.Group("/admin", handler.AdminAccessMiddleware())
 		{
-			rolesets.GET("", handler.ListRolesets)                    // GET /api/v1/rolesets
-			rolesets.POST("/:name", handler.CreateRoleset)            // POST /api/v1/rolesets/{name}
-			rolesets.DELETE("/:name", handler.DeleteRoleset)          // DELETE /api/v1/rolesets/{name}
+			admin.POST("/reports", handler.RunReport)                                                        // POST /api/v1/admin/reports
+			admin.GET("/namespaces", handler.DiscoverNamespaces)                                             // GET /api/v1/admin/namespaces
+			admin.POST("/namespaces/:name", handler.ReplayProtectionMiddleware(), handler.CreateNamespace)   // POST /api/v1/admin/namespaces/{name}
+			admin.DELETE("/namespaces/:name", handler.ReplayProtectionMiddleware(), handler.DeleteNamespace) // DELETE /api/v1/admin/namespaces/{name}
+			admin.GET("/incident", handler.GetIncident)                                                      // GET /api/v1/admin/incident
+			admin.POST("/incident", handler.ReplayProtectionMiddleware(), handler.DeclareIncident)           // POST /api/v1/admin/incident
+			admin.DELETE("/incident", handler.ReplayProtectionMiddleware(), handler.ResolveIncident)         // DELETE /api/v1/admin/incident
+			admin.POST("/unseal", handler.ReplayProtectionMiddleware(), handler.Unseal)                      // POST /api/v1/admin/unseal
+			admin.GET("/mounts", handler.ListMounts)                                                         // GET /api/v1/admin/mounts
+			admin.POST("/mounts/*path", handler.ReplayProtectionMiddleware(), handler.EnableMount)           // POST /api/v1/admin/mounts/{path}
+			admin.PATCH("/mounts/*path", handler.ReplayProtectionMiddleware(), handler.TuneMountPath)        // PATCH /api/v1/admin/mounts/{path}
+			admin.DELETE("/mounts/*path", handler.ReplayProtectionMiddleware(), handler.DisableMount)        // DELETE /api/v1/admin/mounts/{path}
+			admin.GET("/policies", handler.ListPolicies)                                                     // GET /api/v1/admin/policies
+			admin.GET("/policies/:name", handler.GetPolicy)                                                  // GET /api/v1/admin/policies/{name}
+			admin.POST("/policies/:name", handler.ReplayProtectionMiddleware(), handler.CreatePolicy)        // POST /api/v1/admin/policies/{name}
+			admin.PUT("/policies/:name", handler.ReplayProtectionMiddleware(), handler.UpdatePolicy)         // PUT /api/v1/admin/policies/{name}
+			admin.DELETE("/policies/:name", handler.ReplayProtectionMiddleware(), handler.DeletePolicy)      // DELETE /api/v1/admin/policies/{name}
+			admin.GET("/audit", handler.ListAuditDevices)                                                    // GET /api/v1/admin/audit
+			admin.POST("/audit/*path", handler.ReplayProtectionMiddleware(), handler.EnableAuditDevice)      // POST /api/v1/admin/audit/{path}
+			admin.DELETE("/audit/*path", handler.ReplayProtectionMiddleware(), handler.DisableAuditDevice)   // DELETE /api/v1/admin/audit/{path}
+			admin.POST("/rotate", handler.ReplayProtectionMiddleware(), handler.RotateBarrierKey)            // POST /api/v1/admin/rotate
 		}
 
-		// Token generation
-		tokens := v1.Group("/tokens")
+		// Reports
+		reportsGroup := v1.Group("/reports")
 		{
-			tokens.POST("/:name", handler.GetAccessToken)             // POST /api/v1/tokens/{name}
+			reportsGroup.GET("/access-review", handler.GetAccessReview)   // GET /api/v1/reports/access-review
+			reportsGroup.GET("/stale-rolesets", handler.GetStaleRolesets) // GET /api/v1/reports/stale-rolesets
 		}
 
-		// Service account key generation
-		keys := v1.Group("/keys")
+		// Vault system information
+		system := v1.Group("/system")
 		{
-			keys.POST("/:name", handler.GetServiceAccountKey)         // POST /api/v1/keys/{name}
+			system.GET("/token-accessors", handler.GetTokenAccessors) // GET /api/v1/system/token-accessors
+			system.GET("/deprecations", handler.ListDeprecations)     // GET /api/v1/system/deprecations
+			system.GET("/seal-status", handler.GetSealStatus)         // GET /api/v1/system/seal-status
+			system.POST("/capabilities", handler.CheckCapabilities)   // POST /api/v1/system/capabilities
+			system.GET("/leader", handler.GetLeader)                  // GET /api/v1/system/leader
+			system.GET("/key-status", handler.GetKeyStatus)           // GET /api/v1/system/key-status
+
+			// Deprecated path of POST /api/v1/admin/rotate
+			system.POST("/rotate", handler.AdminAccessMiddleware(), handler.Deprecated(handlers.Deprecation{Since: adminRoutesMoved, Successor: "/api/v1/admin/rotate"}), handler.ReplayProtectionMiddleware(), handler.RotateBarrierKey) // POST /api/v1/system/rotate
 		}
 	}
 }
+
+// adminRoutesMoved is when the rotation and tuning routes moved under
+// /api/v1/admin; their old paths are deprecated since.
+var adminRoutesMoved = time.Date(2026, time.October, 17, 0, 0, 0, 0, time.UTC)
+
+// setupGCPRoutes registers the GCP secrets engine routes on group. The paths
+// in the comments are relative to the default, unprefixed group.
+func setupGCPRoutes(group *gin.RouterGroup, handler *handlers.Handler, secretHeaders, issuanceAudit, incidentGuard gin.HandlerFunc) {
+	// Roleset, token and key routes are limited to the caller's rolesets
+	rolesetScope := handler.RolesetScopeMiddleware()
+
+	// Roleset changes are recorded in the audit log
+	rolesetAudit := handler.AuditMiddleware(audit.ActionRolesetChange)
+
+	// GCP secrets engine configuration
+	gcp := group.Group("/gcp")
+	{
+		gcp.GET("/config", handler.GetEngineConfig) // GET /api/v1/gcp/config
+	}
+
+	// GCP secrets engine administration, needing the admin permission and
+	// passing the admin address lists
+	gcpAdmin := group.Group("/admin/gcp", handler.AdminAccessMiddleware())
+	{
+		gcpAdmin.POST("/config/rotate-root", handler.ReplayProtectionMiddleware(), handler.RotateRootCredentials) // POST /api/v1/admin/gcp/config/rotate-root
+		gcpAdmin.PUT("/config/rotation", handler.ReplayProtectionMiddleware(), handler.UpdateRotationSettings)    // PUT /api/v1/admin/gcp/config/rotation
+		gcpAdmin.POST("/tune", handler.ReplayProtectionMiddleware(), handler.TuneMount)                           // POST /api/v1/admin/gcp/tune
+	}
+
+	// Deprecated paths of the administration routes, kept for existing
+	// clients; they are admin routes all the same
+	movedToAdmin := func(successor string) gin.HandlerFunc {
+		return handler.Deprecated(handlers.Deprecation{Since: adminRoutesMoved, Successor: group.BasePath() + successor})
+	}
+	{
+		gcp.POST("/config/rotate-root", handler.AdminAccessMiddleware(), movedToAdmin("/admin/gcp/config/rotate-root"), handler.ReplayProtectionMiddleware(), handler.RotateRootCredentials) // POST /api/v1/gcp/config/rotate-root
+		gcp.PUT("/config/rotation", handler.AdminAccessMiddleware(), movedToAdmin("/admin/gcp/config/rotation"), handler.ReplayProtectionMiddleware(), handler.UpdateRotationSettings)       // PUT /api/v1/gcp/config/rotation
+		gcp.POST("/tune", handler.AdminAccessMiddleware(), movedToAdmin("/admin/gcp/tune"), handler.ReplayProtectionMiddleware(), handler.TuneMount)                                         // POST /api/v1/gcp/tune
+	}
+
+	// Roleset management
+	rolesets := group.Group("/rolesets", rolesetScope)
+	{
+		rolesets.GET("", handler.ListRolesets)                                        // GET /api/v1/rolesets
+		rolesets.GET("/:name", handler.GetRoleset)                                    // GET /api/v1/rolesets/{name}
+		rolesets.POST("/:name", rolesetAudit, handler.CreateRoleset)                  // POST /api/v1/rolesets/{name}
+		rolesets.PUT("/:name", rolesetAudit, handler.UpdateRoleset)                   // PUT /api/v1/rolesets/{name}
+		rolesets.PATCH("/:name", rolesetAudit, handler.PatchRoleset)                  // PATCH /api/v1/rolesets/{name}
+		rolesets.POST("/:name/rotate", rolesetAudit, handler.RotateRoleset)           // POST /api/v1/rolesets/{name}/rotate
+		rolesets.POST("/:name/rotate-key", rolesetAudit, handler.RotateRolesetKey)    // POST /api/v1/rolesets/{name}/rotate-key
+		rolesets.GET("/:name/leases", handler.ListRolesetLeases)                      // GET /api/v1/rolesets/{name}/leases
+		rolesets.POST("/:name/revoke-all", rolesetAudit, handler.RevokeRolesetLeases) // POST /api/v1/rolesets/{name}/revoke-all
+		rolesets.POST("/:name/archive", rolesetAudit, handler.ArchiveRoleset)         // POST /api/v1/rolesets/{name}/archive
+		rolesets.DELETE("/:name", rolesetAudit, handler.DeleteRoleset)                // DELETE /api/v1/rolesets/{name}
+	}
+
+	// Static account management
+	staticAccounts := group.Group("/static-accounts")
+	{
+		staticAccounts.GET("", handler.ListStaticAccounts)           // GET /api/v1/static-accounts
+		staticAccounts.GET("/:name", handler.GetStaticAccount)       // GET /api/v1/static-accounts/{name}
+		staticAccounts.POST("/:name", handler.CreateStaticAccount)   // POST /api/v1/static-accounts/{name}
+		staticAccounts.PUT("/:name", handler.UpdateStaticAccount)    // PUT /api/v1/static-accounts/{name}
+		staticAccounts.DELETE("/:name", handler.DeleteStaticAccount) // DELETE /api/v1/static-accounts/{name}
+		staticAccounts.POST("/:name/token", secretHeaders, issuanceAudit, incidentGuard, handler.GetStaticAccountToken) // POST /api/v1/static-accounts/{name}/token
+		staticAccounts.POST("/:name/key", secretHeaders, issuanceAudit, incidentGuard, handler.GetStaticAccountKey)     // POST /api/v1/static-accounts/{name}/key
+	}
+
+	// Impersonated account management
+	impersonatedAccounts := group.Group("/impersonated-accounts")
+	{
+		impersonatedAccounts.GET("", handler.ListImpersonatedAccounts)           // GET /api/v1/impersonated-accounts
+		impersonatedAccounts.GET("/:name", handler.GetImpersonatedAccount)       // GET /api/v1/impersonated-accounts/{name}
+		impersonatedAccounts.POST("/:name", handler.CreateImpersonatedAccount)   // POST /api/v1/impersonated-accounts/{name}
+		impersonatedAccounts.PUT("/:name", handler.UpdateImpersonatedAccount)    // PUT /api/v1/impersonated-accounts/{name}
+		impersonatedAccounts.DELETE("/:name", handler.DeleteImpersonatedAccount) // DELETE /api/v1/impersonated-accounts/{name}
+		impersonatedAccounts.POST("/:name/token", secretHeaders, issuanceAudit, incidentGuard, handler.GetImpersonatedAccountToken) // POST /api/v1/impersonated-accounts/{name}/token
+	}
+
+	// Token generation
+	tokens := group.Group("/tokens", issuanceAudit, rolesetScope, secretHeaders, incidentGuard)
+	{
+		tokens.POST("/:name", handler.GetAccessToken)             // POST /api/v1/tokens/{name}
+	}
+
+	// Service account key generation
+	keys := group.Group("/keys", issuanceAudit, rolesetScope, secretHeaders, incidentGuard)
+	{
+		keys.POST("/:name", handler.GetServiceAccountKey)         // POST /api/v1/keys/{name}
+	}
+}
@@ -0,0 +1,214 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// AccessReview lists every roleset of every mount with what it grants and how
+// it is used, for periodic access reviews.
+type AccessReview struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Rolesets    []RolesetAccess `json:"rolesets"`
+}
+
+// RolesetAccess is the access review entry of one roleset. Vault rolesets
+// carry no owner labels, so the tenants that issued secrets from the roleset
+// during the current usage period stand in for its owners. Only service
+// account keys are leased, so access token rolesets have no leases.
+type RolesetAccess struct {
+	Mount               string              `json:"mount"`
+	Name                string              `json:"name"`
+	Project             string              `json:"project,omitempty"`
+	SecretType          string              `json:"secret_type,omitempty"`
+	ServiceAccountEmail string              `json:"service_account_email,omitempty"`
+	Bindings            map[string][]string `json:"bindings"`
+	Tenants             []string            `json:"tenants"`
+	LastIssuedAt        *time.Time          `json:"last_issued_at,omitempty"`
+	OutstandingLeases   int                 `json:"outstanding_leases"`
+	// Error is set when the roleset or its leases could not be read
+	Error string `json:"error,omitempty"`
+}
+
+// AccessReview builds the access review of all rolesets. Lease lookups need
+// sudo on sys/leases/lookup; failed lookups are reported per roleset.
+func (s *Scheduler) AccessReview(ctx context.Context) (*AccessReview, error) {
+	_, entries := s.recorder.Snapshot()
+	tenants := rolesetTenants(entries)
+
+	review := &AccessReview{
+		GeneratedAt: time.Now().UTC(),
+		Rolesets:    []RolesetAccess{},
+	}
+
+	for _, mount := range s.vaultClient.MountPaths() {
+		mountClient, _ := s.vaultClient.Mount(mount)
+
+		names, err := mountClient.ListRolesets(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("mount %s: %w", mount, err)
+		}
+
+		for _, name := range names {
+			access := RolesetAccess{
+				Mount:    mount,
+				Name:     name,
+				Bindings: map[string][]string{},
+				Tenants:  tenants[mount+"/"+name],
+			}
+			if access.Tenants == nil {
+				access.Tenants = []string{}
+			}
+			if at, ok := s.lastIssued(mount, name); ok {
+				access.LastIssuedAt = &at
+			}
+
+			roleset, err := mountClient.GetRoleset(ctx, name)
+			if err != nil {
+				access.Error = err.Error()
+				review.Rolesets = append(review.Rolesets, access)
+				continue
+			}
+			access.Project = roleset.Project
+			access.SecretType = roleset.SecretType
+			access.ServiceAccountEmail = roleset.ServiceAccountEmail
+			access.Bindings = vault.ReadBindingRoles(roleset.Bindings)
+
+			if roleset.SecretType == "service_account_key" {
+				leases, err := mountClient.ListRolesetLeases(ctx, name)
+				if err != nil {
+					access.Error = err.Error()
+				}
+				access.OutstandingLeases = len(leases)
+			}
+
+			review.Rolesets = append(review.Rolesets, access)
+		}
+	}
+
+	return review, nil
+}
+
+// lastIssued returns when a token or key was last issued for the roleset on
+// mount.
+func (s *Scheduler) lastIssued(mount, name string) (time.Time, bool) {
+	tokenAt, tokenOK := s.recorder.LastIssued(usage.KindRolesetToken, mount, name)
+	keyAt, keyOK := s.recorder.LastIssued(usage.KindRolesetKey, mount, name)
+
+	if keyOK && (!tokenOK || keyAt.After(tokenAt)) {
+		return keyAt, true
+	}
+	return tokenAt, tokenOK
+}
+
+// rolesetTenants maps each roleset, as mount/name, to the tenants that issued
+// secrets from it. Entries are sorted by tenant, so the tenants come out in
+// order.
+func rolesetTenants(entries []usage.Entry) map[string][]string {
+	tenants := make(map[string][]string)
+	for _, entry := range entries {
+		if entry.Kind != usage.KindRolesetToken && entry.Kind != usage.KindRolesetKey {
+			continue
+		}
+		if entry.Tenant == "" {
+			continue
+		}
+
+		key := entry.Mount + "/" + entry.Name
+		names := tenants[key]
+		if len(names) == 0 || names[len(names)-1] != entry.Tenant {
+			tenants[key] = append(names, entry.Tenant)
+		}
+	}
+	return tenants
+}
+
+// Encode renders the access review as json or csv, returning the body and
+// its content type.
+func (r *AccessReview) Encode(format string) ([]byte, string, error) {
+	switch format {
+	case "", "json":
+		body, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode access review: %w", err)
+		}
+		return body, "application/json", nil
+	case "csv":
+		body, err := r.csv()
+		if err != nil {
+			return nil, "", err
+		}
+		return body, "text/csv", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// csv renders one row per roleset. Bindings are written as
+// "resource=role|role" pairs separated by ";".
+func (r *AccessReview) csv() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{
+		"mount", "name", "project", "secret_type", "service_account_email",
+		"bindings", "tenants", "last_issued_at", "outstanding_leases", "error",
+	}}
+	for _, access := range r.Rolesets {
+		lastIssued := ""
+		if access.LastIssuedAt != nil {
+			lastIssued = access.LastIssuedAt.Format(time.RFC3339)
+		}
+
+		rows = append(rows, []string{
+			access.Mount,
+			access.Name,
+			access.Project,
+			access.SecretType,
+			access.ServiceAccountEmail,
+			formatBindings(access.Bindings),
+			strings.Join(access.Tenants, ";"),
+			lastIssued,
+			strconv.Itoa(access.OutstandingLeases),
+			access.Error,
+		})
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to encode access review: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Filename names the access review after its generation time, e.g.
+// access-review-20240131T000000Z.csv.
+func (r *AccessReview) Filename(format string) string {
+	if format == "" {
+		format = "json"
+	}
+	return fmt.Sprintf("access-review-%s.%s", r.GeneratedAt.Format("20060102T150405Z"), format)
+}
+
+func formatBindings(bindings map[string][]string) string {
+	resources := make([]string, 0, len(bindings))
+	for resource := range bindings {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+
+	pairs := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		pairs = append(pairs, resource+"="+strings.Join(bindings[resource], "|"))
+	}
+	return strings.Join(pairs, ";")
+}
@@ -0,0 +1,122 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/vault"
+)
+
+// Exporter delivers an encoded report somewhere outside hcvapi.
+type Exporter interface {
+	Name() string
+	Export(ctx context.Context, filename, contentType string, body []byte) error
+}
+
+// gcsExporter uploads reports to a GCS bucket using an access token minted
+// from one of our own rolesets.
+type gcsExporter struct {
+	cfg         config.GCSExportConfig
+	vaultClient *vault.Client
+	httpClient  *http.Client
+}
+
+func (e *gcsExporter) Name() string {
+	return "gcs"
+}
+
+func (e *gcsExporter) Export(ctx context.Context, filename, contentType string, body []byte) error {
+	token, err := e.vaultClient.GetToken(ctx, e.cfg.Roleset, "")
+	if err != nil {
+		return fmt.Errorf("failed to get upload token: %w", err)
+	}
+
+	uploadURL := fmt.Sprintf("https://storage.googleapis.com/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		url.PathEscape(e.cfg.Bucket), url.QueryEscape(e.cfg.Prefix+filename))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uploadURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build upload request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Token)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := e.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("report upload failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
+
+// emailExporter mails reports as an attachment over SMTP.
+type emailExporter struct {
+	cfg config.EmailExportConfig
+}
+
+func (e *emailExporter) Name() string {
+	return "email"
+}
+
+func (e *emailExporter) Export(_ context.Context, filename, contentType string, body []byte) error {
+	var msg bytes.Buffer
+	writer := multipart.NewWriter(&msg)
+
+	fmt.Fprintf(&msg, "From: %s\r\n", e.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(e.cfg.To, ", "))
+	fmt.Fprintf(&msg, "Subject: hcvapi usage report %s\r\n", filename)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+
+	text, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {"text/plain; charset=utf-8"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build report email: %w", err)
+	}
+	fmt.Fprintf(text, "The attached hcvapi usage report %s was generated automatically.\r\n", filename)
+
+	attachment, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType},
+		"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", filename)},
+		"Content-Transfer-Encoding": {"base64"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build report email: %w", err)
+	}
+	attachment.Write([]byte(base64.StdEncoding.EncodeToString(body)))
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to build report email: %w", err)
+	}
+
+	var auth smtp.Auth
+	if e.cfg.Username != "" {
+		auth = smtp.PlainAuth("", e.cfg.Username, e.cfg.Password, e.cfg.SMTPHost)
+	}
+
+	addr := fmt.Sprintf("%s:%d", e.cfg.SMTPHost, e.cfg.SMTPPort)
+	if err := smtp.SendMail(addr, auth, e.cfg.From, e.cfg.To, msg.Bytes()); err != nil {
+		return fmt.Errorf("failed to send report email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kalpesh172000/hcvapi/usage"
+)
+
+// Report summarizes secret issuance over a period, per tenant and per name.
+type Report struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	PeriodStart time.Time       `json:"period_start"`
+	PeriodEnd   time.Time       `json:"period_end"`
+	Tenants     []TenantSummary `json:"tenants"`
+	Entries     []usage.Entry   `json:"entries"`
+}
+
+type TenantSummary struct {
+	Tenant string `json:"tenant"`
+	Issued int64  `json:"issued"`
+}
+
+func newReport(since time.Time, entries []usage.Entry) *Report {
+	now := time.Now().UTC()
+
+	totals := make(map[string]int64)
+	for _, entry := range entries {
+		totals[entry.Tenant] += entry.Count
+	}
+
+	tenants := make([]TenantSummary, 0, len(totals))
+	for tenant, issued := range totals {
+		tenants = append(tenants, TenantSummary{Tenant: tenant, Issued: issued})
+	}
+	sort.Slice(tenants, func(i, j int) bool { return tenants[i].Tenant < tenants[j].Tenant })
+
+	return &Report{
+		GeneratedAt: now,
+		PeriodStart: since,
+		PeriodEnd:   now,
+		Tenants:     tenants,
+		Entries:     entries,
+	}
+}
+
+// Encode renders the report as json or csv, returning the body and its
+// content type.
+func (r *Report) Encode(format string) ([]byte, string, error) {
+	switch format {
+	case "", "json":
+		body, err := json.MarshalIndent(r, "", "  ")
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encode report: %w", err)
+		}
+		return body, "application/json", nil
+	case "csv":
+		body, err := r.csv()
+		if err != nil {
+			return nil, "", err
+		}
+		return body, "text/csv", nil
+	default:
+		return nil, "", fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+func (r *Report) csv() ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	rows := [][]string{{"tenant", "kind", "mount", "name", "count", "last_issued_at"}}
+	for _, entry := range r.Entries {
+		rows = append(rows, []string{
+			entry.Tenant,
+			entry.Kind,
+			entry.Mount,
+			entry.Name,
+			strconv.FormatInt(entry.Count, 10),
+			entry.LastIssuedAt.Format(time.RFC3339),
+		})
+	}
+
+	if err := w.WriteAll(rows); err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// filename names the report after its period end, e.g. usage-20240131T000000Z.json.
+func (r *Report) filename(format string) string {
+	if format == "" {
+		format = "json"
+	}
+	return fmt.Sprintf("usage-%s.%s", r.PeriodEnd.Format("20060102T150405Z"), format)
+}
@@ -0,0 +1,108 @@
+package reports
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/usage"
+	"github.com/kalpesh172000/hcvapi/vault"
+	"github.com/sirupsen/logrus"
+)
+
+type ExportResult struct {
+	Exporter string `json:"exporter"`
+	Filename string `json:"filename"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Scheduler periodically turns recorded usage into reports and exports them
+// to the configured destinations.
+type Scheduler struct {
+	cfg         config.ReportsConfig
+	recorder    *usage.Recorder
+	vaultClient *vault.Client
+	exporters   []Exporter
+	httpClient  *http.Client
+	logger      *logrus.Logger
+}
+
+func NewScheduler(cfg config.ReportsConfig, recorder *usage.Recorder, vaultClient *vault.Client, logger *logrus.Logger) *Scheduler {
+	var exporters []Exporter
+	if cfg.GCS.Bucket != "" {
+		exporters = append(exporters, &gcsExporter{
+			cfg:         cfg.GCS,
+			vaultClient: vaultClient,
+			httpClient:  &http.Client{Timeout: time.Minute},
+		})
+	}
+	if cfg.Email.SMTPHost != "" && len(cfg.Email.To) > 0 {
+		exporters = append(exporters, &emailExporter{cfg: cfg.Email})
+	}
+
+	return &Scheduler{
+		cfg:         cfg,
+		recorder:    recorder,
+		vaultClient: vaultClient,
+		exporters:   exporters,
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		logger:      logger,
+	}
+}
+
+// Generate builds a report of the current period. Scheduled reports close
+// the period so the next report starts counting from zero.
+func (s *Scheduler) Generate(closePeriod bool) *Report {
+	if closePeriod {
+		return newReport(s.recorder.Reset())
+	}
+	return newReport(s.recorder.Snapshot())
+}
+
+// Export sends the report to every configured exporter.
+func (s *Scheduler) Export(ctx context.Context, report *Report) ([]ExportResult, error) {
+	body, contentType, err := report.Encode(s.cfg.Format)
+	if err != nil {
+		return nil, err
+	}
+	filename := report.filename(s.cfg.Format)
+
+	results := make([]ExportResult, 0, len(s.exporters))
+	for _, exporter := range s.exporters {
+		result := ExportResult{Exporter: exporter.Name(), Filename: filename}
+		if err := exporter.Export(ctx, filename, contentType, body); err != nil {
+			s.logger.WithError(err).WithField("exporter", exporter.Name()).Error("Failed to export report")
+			result.Error = err.Error()
+		} else {
+			s.logger.WithFields(logrus.Fields{
+				"exporter": exporter.Name(),
+				"filename": filename,
+			}).Info("Report exported successfully")
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// Run generates and exports a report every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.logger.WithField("interval", s.cfg.Interval).Info("Starting report scheduler")
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			exportCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			if _, err := s.Export(exportCtx, s.Generate(true)); err != nil {
+				s.logger.WithError(err).Error("Failed to generate report")
+			}
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StaleReport lists the rolesets nothing has been issued from for at least
+// the configured period. Issuance is only known since ObservedSince: until
+// the period has passed since then, rolesets nothing was issued from are
+// listed as Unknown rather than stale, since they may have been used before.
+type StaleReport struct {
+	GeneratedAt   time.Time      `json:"generated_at"`
+	ObservedSince time.Time      `json:"observed_since"`
+	After         string         `json:"after"`
+	Rolesets      []StaleRoleset `json:"rolesets"`
+	Unknown       []StaleRoleset `json:"unknown"`
+}
+
+type StaleRoleset struct {
+	Mount        string     `json:"mount"`
+	Name         string     `json:"name"`
+	LastIssuedAt *time.Time `json:"last_issued_at,omitempty"`
+	// Actions are the API calls cleaning up the roleset
+	Actions StaleActions `json:"actions"`
+}
+
+type StaleActions struct {
+	Archive string `json:"archive"`
+	Delete  string `json:"delete"`
+}
+
+// StaleRolesets finds the rolesets of every mount with no issuance during the
+// configured period.
+func (s *Scheduler) StaleRolesets(ctx context.Context) (*StaleReport, error) {
+	now := time.Now().UTC()
+	cutoff := now.Add(-s.cfg.Stale.After)
+
+	report := &StaleReport{
+		GeneratedAt:   now,
+		ObservedSince: s.recorder.Started(),
+		After:         s.cfg.Stale.After.String(),
+		Rolesets:      []StaleRoleset{},
+		Unknown:       []StaleRoleset{},
+	}
+
+	// Without a full period of observation, unused rolesets cannot be told
+	// apart from ones used before the restart
+	observed := !report.ObservedSince.After(cutoff)
+
+	for _, mount := range s.vaultClient.MountPaths() {
+		mountClient, _ := s.vaultClient.Mount(mount)
+
+		names, err := mountClient.ListRolesets(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("mount %s: %w", mount, err)
+		}
+
+		for _, name := range names {
+			stale := StaleRoleset{
+				Mount: mount,
+				Name:  name,
+				Actions: StaleActions{
+					Archive: fmt.Sprintf("POST /api/v1/mounts/%s/rolesets/%s/archive", mount, name),
+					Delete:  fmt.Sprintf("DELETE /api/v1/mounts/%s/rolesets/%s", mount, name),
+				},
+			}
+
+			at, ok := s.lastIssued(mount, name)
+			switch {
+			case ok && at.After(cutoff):
+				continue
+			case ok:
+				stale.LastIssuedAt = &at
+			case !observed:
+				report.Unknown = append(report.Unknown, stale)
+				continue
+			}
+
+			report.Rolesets = append(report.Rolesets, stale)
+		}
+	}
+
+	return report, nil
+}
+
+// RunStaleAnalyzer looks for stale rolesets every interval until ctx is done,
+// notifying the webhook about rolesets that became stale since the last run.
+func (s *Scheduler) RunStaleAnalyzer(ctx context.Context) {
+	s.logger.WithField("interval", s.cfg.Stale.Interval).Info("Starting stale roleset analyzer")
+
+	ticker := time.NewTicker(s.cfg.Stale.Interval)
+	defer ticker.Stop()
+
+	known := map[string]bool{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			analyzeCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+			known = s.analyzeStale(analyzeCtx, known)
+			cancel()
+		}
+	}
+}
+
+// analyzeStale reports rolesets not in known and returns the current set of
+// stale rolesets.
+func (s *Scheduler) analyzeStale(ctx context.Context, known map[string]bool) map[string]bool {
+	report, err := s.StaleRolesets(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to analyze stale rolesets")
+		return known
+	}
+
+	current := make(map[string]bool, len(report.Rolesets))
+	var fresh []StaleRoleset
+	for _, stale := range report.Rolesets {
+		key := stale.Mount + "/" + stale.Name
+		current[key] = true
+		if !known[key] {
+			fresh = append(fresh, stale)
+		}
+	}
+
+	if len(fresh) == 0 {
+		return current
+	}
+
+	s.logger.WithField("count", len(fresh)).Warn("Found newly stale rolesets")
+
+	if s.cfg.Stale.WebhookURL != "" {
+		if err := s.notifyStale(ctx, fresh); err != nil {
+			s.logger.WithError(err).Error("Failed to notify about stale rolesets")
+			// Retry the notification on the next run
+			return known
+		}
+	}
+
+	return current
+}
+
+func (s *Scheduler) notifyStale(ctx context.Context, rolesets []StaleRoleset) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"event":    "stale_rolesets",
+		"after":    s.cfg.Stale.After.String(),
+		"rolesets": rolesets,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.cfg.Stale.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("notification failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package usage
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kinds of issued secrets.
+const (
+	KindRolesetToken          = "roleset_token"
+	KindRolesetKey            = "roleset_key"
+	KindStaticToken           = "static_account_token"
+	KindStaticKey             = "static_account_key"
+	KindImpersonatedToken     = "impersonated_account_token"
+	KindAWSCredentials        = "aws_credentials"
+	KindAWSSTSCredentials     = "aws_sts_credentials"
+	KindDatabaseCredentials   = "database_credentials"
+	KindPKICertificate        = "pki_certificate"
+	KindSSHCertificate        = "ssh_certificate"
+	KindTOTPCode              = "totp_code"
+	KindConsulToken           = "consul_token"
+	KindRabbitMQCredentials   = "rabbitmq_credentials"
+	KindLDAPCredentials       = "ldap_credentials"
+	KindLDAPStaticCredentials = "ldap_static_credentials"
+	KindLDAPCheckOut          = "ldap_check_out"
+	KindNomadToken            = "nomad_token"
+	KindKubernetesToken       = "kubernetes_token"
+	KindTerraformToken        = "terraform_token"
+	KindKVSecret              = "kv_secret"
+)
+
+// Entry counts the secrets of one kind issued for a tenant from name. Mount
+// is the GCP mount of roleset and account secrets, and empty for other kinds.
+type Entry struct {
+	Tenant       string    `json:"tenant"`
+	Kind         string    `json:"kind"`
+	Mount        string    `json:"mount,omitempty"`
+	Name         string    `json:"name"`
+	Count        int64     `json:"count"`
+	LastIssuedAt time.Time `json:"last_issued_at"`
+}
+
+type entryKey struct {
+	tenant string
+	kind   string
+	mount  string
+	name   string
+}
+
+type subjectKey struct {
+	kind  string
+	mount string
+	name  string
+}
+
+// Recorder counts issued secrets per tenant, kind, mount and name since the
+// start of the current period. Last issuance times survive period resets, but
+// not restarts: everything is kept in memory.
+type Recorder struct {
+	mu         sync.Mutex
+	started    time.Time
+	since      time.Time
+	entries    map[entryKey]*Entry
+	lastIssued map[subjectKey]time.Time
+}
+
+func NewRecorder() *Recorder {
+	now := time.Now().UTC()
+	return &Recorder{
+		started:    now,
+		since:      now,
+		entries:    make(map[entryKey]*Entry),
+		lastIssued: make(map[subjectKey]time.Time),
+	}
+}
+
+// Record counts one issued secret.
+func (r *Recorder) Record(tenant, kind, mount, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	key := entryKey{tenant: tenant, kind: kind, mount: mount, name: name}
+
+	entry, ok := r.entries[key]
+	if !ok {
+		entry = &Entry{Tenant: tenant, Kind: kind, Mount: mount, Name: name}
+		r.entries[key] = entry
+	}
+	entry.Count++
+	entry.LastIssuedAt = now
+
+	r.lastIssued[subjectKey{kind: kind, mount: mount, name: name}] = now
+}
+
+// Snapshot returns the counts of the current period and when it started.
+func (r *Recorder) Snapshot() (time.Time, []Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.since, r.snapshot()
+}
+
+// Reset returns the counts of the current period and starts a new one.
+func (r *Recorder) Reset() (time.Time, []Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	since, entries := r.since, r.snapshot()
+	r.since = time.Now().UTC()
+	r.entries = make(map[entryKey]*Entry)
+	return since, entries
+}
+
+// LastIssued returns when a secret of the given kind was last issued for name
+// on mount, if it was since Started.
+func (r *Recorder) LastIssued(kind, mount, name string) (time.Time, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	at, ok := r.lastIssued[subjectKey{kind: kind, mount: mount, name: name}]
+	return at, ok
+}
+
+// Started returns when recording began. Nothing is known about issuance
+// before then.
+func (r *Recorder) Started() time.Time {
+	return r.started
+}
+
+// snapshot copies the entries; the caller must hold r.mu.
+func (r *Recorder) snapshot() []Entry {
+	entries := make([]Entry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		entries = append(entries, *entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Tenant != b.Tenant {
+			return a.Tenant < b.Tenant
+		}
+		if a.Mount != b.Mount {
+			return a.Mount < b.Mount
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Kind < b.Kind
+	})
+	return entries
+}
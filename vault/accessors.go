@@ -0,0 +1,170 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type TokenAccessor struct {
+	Accessor     string `json:"accessor"`
+	DisplayName  string `json:"display_name"`
+	Role         string `json:"role,omitempty"`
+	CreationTime int64  `json:"creation_time"`
+	ExpireTime   string `json:"expire_time,omitempty"`
+}
+
+type TokenAccessorReport struct {
+	CheckedAt time.Time       `json:"checked_at"`
+	Role      string          `json:"role,omitempty"`
+	Total     int             `json:"total"`
+	Matching  int             `json:"matching"`
+	Threshold int             `json:"threshold"`
+	Exceeded  bool            `json:"exceeded"`
+	Accessors []TokenAccessor `json:"accessors"`
+	Error     string          `json:"error,omitempty"`
+}
+
+// accessorAudit keeps the most recent token accessor report.
+type accessorAudit struct {
+	mu     sync.RWMutex
+	report *TokenAccessorReport
+}
+
+// AuditTokenAccessors lists all token accessors and looks up the ones created
+// through the configured auth role. Listing accessors requires sudo on
+// auth/token/accessors.
+func (c *Client) AuditTokenAccessors(ctx context.Context) (*TokenAccessorReport, error) {
+	auditCfg := c.config.Vault.Accessors
+
+	secret, err := c.client.Logical().ListWithContext(ctx, "auth/token/accessors")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list token accessors: %w", err)
+	}
+
+	report := &TokenAccessorReport{
+		CheckedAt: time.Now().UTC(),
+		Role:      auditCfg.Role,
+		Threshold: auditCfg.Threshold,
+		Accessors: []TokenAccessor{},
+	}
+
+	if secret == nil || secret.Data == nil {
+		return report, nil
+	}
+
+	keys, _ := secret.Data["keys"].([]interface{})
+	report.Total = len(keys)
+
+	for _, key := range keys {
+		accessor, ok := key.(string)
+		if !ok {
+			continue
+		}
+
+		lookup, err := c.client.Auth().Token().LookupAccessorWithContext(ctx, accessor)
+		if err != nil || lookup == nil || lookup.Data == nil {
+			// Tokens can expire between list and lookup
+			continue
+		}
+
+		info := TokenAccessor{Accessor: accessor}
+		info.DisplayName, _ = lookup.Data["display_name"].(string)
+		info.Role = tokenRole(lookup.Data)
+		info.ExpireTime, _ = lookup.Data["expire_time"].(string)
+		info.CreationTime = int64Value(lookup.Data["creation_time"])
+
+		if auditCfg.Role != "" && info.Role != auditCfg.Role {
+			continue
+		}
+		report.Accessors = append(report.Accessors, info)
+	}
+
+	report.Matching = len(report.Accessors)
+	report.Exceeded = auditCfg.Threshold > 0 && report.Matching > auditCfg.Threshold
+
+	return report, nil
+}
+
+// tokenRole extracts the role a token was issued for, whether it came from a
+// token role or from an auth method that records the role in its metadata.
+func tokenRole(data map[string]interface{}) string {
+	if role, ok := data["role"].(string); ok && role != "" {
+		return role
+	}
+
+	meta, _ := data["meta"].(map[string]interface{})
+	for _, key := range []string{"role", "role_name"} {
+		if role, ok := meta[key].(string); ok && role != "" {
+			return role
+		}
+	}
+
+	return ""
+}
+
+// RunAccessorAudit audits token accessors every interval until ctx is done,
+// warning when the number of matching accessors exceeds the threshold.
+func (c *Client) RunAccessorAudit(ctx context.Context) {
+	interval := c.config.Vault.Accessors.Interval
+	c.log(ctx).WithField("interval", interval).Info("Starting token accessor audit")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.runAccessorAudit(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) runAccessorAudit(ctx context.Context) *TokenAccessorReport {
+	auditCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	report, err := c.AuditTokenAccessors(auditCtx)
+	if err != nil {
+		c.log(ctx).WithError(err).Error("Token accessor audit failed")
+		report = &TokenAccessorReport{
+			CheckedAt: time.Now().UTC(),
+			Role:      c.config.Vault.Accessors.Role,
+			Threshold: c.config.Vault.Accessors.Threshold,
+			Error:     err.Error(),
+		}
+	} else if report.Exceeded {
+		c.log(ctx).WithFields(logrus.Fields{
+			"role":      report.Role,
+			"matching":  report.Matching,
+			"threshold": report.Threshold,
+		}).Warn("Unexpected accumulation of Vault token accessors")
+	} else {
+		c.log(ctx).WithField("matching", report.Matching).Info("Token accessor audit completed")
+	}
+
+	c.accessors.mu.Lock()
+	c.accessors.report = report
+	c.accessors.mu.Unlock()
+
+	return report
+}
+
+// AccessorReport returns the latest accessor audit report, running an audit
+// on demand if none has been recorded yet.
+func (c *Client) AccessorReport(ctx context.Context) *TokenAccessorReport {
+	c.accessors.mu.RLock()
+	report := c.accessors.report
+	c.accessors.mu.RUnlock()
+
+	if report != nil {
+		return report
+	}
+	return c.runAccessorAudit(ctx)
+}
@@ -0,0 +1,140 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrAuditDeviceExists is returned when enabling an audit device at a path
+// that is already in use.
+var ErrAuditDeviceExists = errors.New("an audit device is already enabled at this path")
+
+// AuditDeviceRequest enables an audit device of Type. Options depend on the
+// type, such as {"file_path": "/var/log/vault/audit.log"} for file devices.
+type AuditDeviceRequest struct {
+	Type        string            `json:"type" binding:"required,oneof=file syslog socket"`
+	Description string            `json:"description,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+	Local       bool              `json:"local,omitempty"`
+}
+
+type AuditDeviceResponse struct {
+	Path        string            `json:"path"`
+	Type        string            `json:"type"`
+	Description string            `json:"description"`
+	Options     map[string]string `json:"options,omitempty"`
+	Local       bool              `json:"local"`
+}
+
+// ListAuditDevices lists the enabled audit devices, sorted by path. Listing
+// requires sudo on sys/audit.
+func (c *Client) ListAuditDevices(ctx context.Context) ([]AuditDeviceResponse, error) {
+	devices, err := c.client.Sys().ListAuditWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit devices: %w", err)
+	}
+
+	response := make([]AuditDeviceResponse, 0, len(devices))
+	for path, device := range devices {
+		response = append(response, AuditDeviceResponse{
+			Path:        strings.TrimSuffix(path, "/"),
+			Type:        device.Type,
+			Description: device.Description,
+			Options:     device.Options,
+			Local:       device.Local,
+		})
+	}
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Path < response[j].Path
+	})
+
+	return response, nil
+}
+
+// EnableAuditDevice enables an audit device at path. Vault refuses devices it
+// cannot write to, such as a file path it cannot open.
+func (c *Client) EnableAuditDevice(ctx context.Context, path string, req *AuditDeviceRequest) error {
+	path = strings.Trim(path, "/")
+	c.log(ctx).WithFields(logrus.Fields{
+		"path": path,
+		"type": req.Type,
+	}).Info("Enabling audit device...")
+
+	exists, err := c.hasAuditDevice(ctx, path)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrAuditDeviceExists
+	}
+
+	err = c.client.Sys().EnableAuditWithOptionsWithContext(ctx, path, &api.EnableAuditOptions{
+		Type:        req.Type,
+		Description: req.Description,
+		Options:     req.Options,
+		Local:       req.Local,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable audit device: %w", err)
+	}
+
+	c.log(ctx).WithField("path", path).Info("Audit device enabled successfully")
+	return nil
+}
+
+// DisableAuditDevice disables the audit device at path.
+func (c *Client) DisableAuditDevice(ctx context.Context, path string) error {
+	path = strings.Trim(path, "/")
+	c.log(ctx).WithField("path", path).Info("Disabling audit device...")
+
+	exists, err := c.hasAuditDevice(ctx, path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+
+	if err := c.client.Sys().DisableAuditWithContext(ctx, path); err != nil {
+		return fmt.Errorf("failed to disable audit device: %w", err)
+	}
+
+	c.log(ctx).WithField("path", path).Info("Audit device disabled successfully")
+	return nil
+}
+
+// InitializeAuditDevices enables the configured audit devices that are not
+// enabled yet. Devices already enabled are left as they are.
+func (c *Client) InitializeAuditDevices(ctx context.Context) error {
+	for path, device := range c.config.Vault.AuditDevices {
+		err := c.EnableAuditDevice(ctx, path, &AuditDeviceRequest{
+			Type:        device.Type,
+			Description: device.Description,
+			Options:     device.Options,
+			Local:       device.Local,
+		})
+		if errors.Is(err, ErrAuditDeviceExists) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("audit device %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (c *Client) hasAuditDevice(ctx context.Context, path string) (bool, error) {
+	devices, err := c.client.Sys().ListAuditWithContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to list audit devices: %w", err)
+	}
+
+	_, exists := devices[path+"/"]
+	return exists, nil
+}
@@ -0,0 +1,180 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Login walks the configured auth methods in order and keeps the token of the
+// first one that succeeds.
+func (c *Client) Login(ctx context.Context) error {
+	methods := c.config.Vault.AuthMethods
+	if len(methods) == 0 {
+		methods = []string{"token"}
+	}
+
+	var failures []string
+	for _, method := range methods {
+		var err error
+		switch method {
+		case "token":
+			err = c.loginToken(ctx)
+		case "kubernetes":
+			err = c.loginKubernetes(ctx)
+		case "approle":
+			err = c.loginAppRole(ctx)
+		default:
+			err = fmt.Errorf("unsupported auth method")
+		}
+
+		if err != nil {
+			c.log(ctx).WithError(err).WithField("auth_method", method).Warn("Vault authentication failed, trying next method")
+			failures = append(failures, fmt.Sprintf("%s: %v", method, err))
+			continue
+		}
+
+		c.log(ctx).WithField("auth_method", method).Info("Authenticated to Vault")
+		return nil
+	}
+
+	return fmt.Errorf("all auth methods failed: %s", strings.Join(failures, "; "))
+}
+
+// RevokeSelf revokes the client's own token. Vault revokes its child tokens and
+// the leases issued to it along with it.
+func (c *Client) RevokeSelf(ctx context.Context) error {
+	if c.client.Token() == "" {
+		return nil
+	}
+
+	if err := c.client.Auth().Token().RevokeSelfWithContext(ctx, ""); err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	c.client.ClearToken()
+	c.log(ctx).Info("Vault token revoked")
+	return nil
+}
+
+type TokenStatus struct {
+	TTL        time.Duration `json:"-"`
+	TTLSeconds int64         `json:"ttl_seconds"`
+	Renewable  bool          `json:"renewable"`
+	Expires    bool          `json:"expires"`
+	Renewed    bool          `json:"renewed"`
+}
+
+// TokenStatus reports the remaining TTL of the client's own token. When the
+// TTL has dropped below the configured minimum and the token is renewable, a
+// renewal is attempted first.
+func (c *Client) TokenStatus(ctx context.Context) (*TokenStatus, error) {
+	status, err := c.lookupSelf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Expires && status.Renewable && status.TTL < c.config.Vault.MinTokenTTL {
+		if _, err := c.client.Auth().Token().RenewSelfWithContext(ctx, 0); err != nil {
+			c.log(ctx).WithError(err).Warn("Failed to renew Vault token")
+			return status, nil
+		}
+
+		renewed, err := c.lookupSelf(ctx)
+		if err != nil {
+			return nil, err
+		}
+		renewed.Renewed = true
+		c.log(ctx).WithField("ttl", renewed.TTL).Info("Vault token renewed")
+		return renewed, nil
+	}
+
+	return status, nil
+}
+
+func (c *Client) lookupSelf(ctx context.Context) (*TokenStatus, error) {
+	secret, err := c.client.Auth().Token().LookupSelfWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up token: %w", err)
+	}
+
+	ttl, err := secret.TokenTTL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token ttl: %w", err)
+	}
+
+	renewable, _ := secret.TokenIsRenewable()
+
+	// Tokens without a TTL (e.g. root tokens) never expire
+	expires := ttl > 0
+	if raw, ok := secret.Data["expire_time"]; ok && raw == nil {
+		expires = false
+	}
+
+	return &TokenStatus{
+		TTL:        ttl,
+		TTLSeconds: int64(ttl / time.Second),
+		Renewable:  renewable,
+		Expires:    expires,
+	}, nil
+}
+
+func (c *Client) loginToken(ctx context.Context) error {
+	if c.config.Vault.Token == "" {
+		return fmt.Errorf("no token configured")
+	}
+
+	c.client.SetToken(c.config.Vault.Token)
+
+	if _, err := c.client.Auth().Token().LookupSelfWithContext(ctx); err != nil {
+		c.client.ClearToken()
+		return fmt.Errorf("failed to look up token: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) loginKubernetes(ctx context.Context) error {
+	k8s := c.config.Vault.Kubernetes
+	if k8s.Role == "" {
+		return fmt.Errorf("no kubernetes role configured")
+	}
+
+	jwt, err := os.ReadFile(k8s.TokenPath)
+	if err != nil {
+		return fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	return c.loginWith(ctx, k8s.MountPath, map[string]interface{}{
+		"role": k8s.Role,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+}
+
+func (c *Client) loginAppRole(ctx context.Context) error {
+	appRole := c.config.Vault.AppRole
+	if appRole.RoleID == "" {
+		return fmt.Errorf("no approle role_id configured")
+	}
+
+	return c.loginWith(ctx, appRole.MountPath, map[string]interface{}{
+		"role_id":   appRole.RoleID,
+		"secret_id": appRole.SecretID,
+	})
+}
+
+func (c *Client) loginWith(ctx context.Context, mountPath string, data map[string]interface{}) error {
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("auth/%s/login", strings.Trim(mountPath, "/")), data)
+	if err != nil {
+		return fmt.Errorf("login request failed: %w", err)
+	}
+
+	if secret == nil || secret.Auth == nil || secret.Auth.ClientToken == "" {
+		return fmt.Errorf("no client token returned")
+	}
+
+	c.client.SetToken(secret.Auth.ClientToken)
+	return nil
+}
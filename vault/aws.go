@@ -0,0 +1,217 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type AWSRoleRequest struct {
+	CredentialType string   `json:"credential_type" binding:"required,oneof=iam_user assumed_role federation_token session_token"`
+	PolicyARNs     []string `json:"policy_arns,omitempty"`
+	PolicyDocument string   `json:"policy_document,omitempty"`
+	RoleARNs       []string `json:"role_arns,omitempty"`
+	IAMGroups      []string `json:"iam_groups,omitempty"`
+	DefaultSTSTTL  string   `json:"default_sts_ttl,omitempty"`
+	MaxSTSTTL      string   `json:"max_sts_ttl,omitempty"`
+}
+
+type AWSRoleResponse struct {
+	Name            string   `json:"name"`
+	CredentialTypes []string `json:"credential_types"`
+	PolicyARNs      []string `json:"policy_arns,omitempty"`
+	PolicyDocument  string   `json:"policy_document,omitempty"`
+	RoleARNs        []string `json:"role_arns,omitempty"`
+	IAMGroups       []string `json:"iam_groups,omitempty"`
+	DefaultSTSTTL   int64    `json:"default_sts_ttl,omitempty"`
+	MaxSTSTTL       int64    `json:"max_sts_ttl,omitempty"`
+}
+
+// AWSCredentialsRequest holds the optional parameters of a credential
+// request. RoleARN picks one of the role's role_arns for assumed_role
+// credentials.
+type AWSCredentialsRequest struct {
+	TTL             string `json:"ttl,omitempty"`
+	RoleARN         string `json:"role_arn,omitempty"`
+	RoleSessionName string `json:"role_session_name,omitempty"`
+}
+
+type AWSCredentialsResponse struct {
+	AccessKey    string `json:"access_key"`
+	SecretKey    string `json:"secret_key"`
+	SessionToken string `json:"session_token,omitempty"`
+	ARN          string `json:"arn,omitempty"`
+	Lease
+}
+
+// InitializeAWS enables and configures the AWS secrets engine.
+func (c *Client) InitializeAWS(ctx context.Context) error {
+	mount := c.config.AWS.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault AWS secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "aws", "AWS secrets engine for managing IAM and STS credentials"); err != nil {
+		return err
+	}
+
+	// Without static keys Vault falls back to its own AWS credential chain
+	rootData := map[string]interface{}{
+		"region": c.config.AWS.Region,
+	}
+	if c.config.AWS.AccessKey != "" {
+		rootData["access_key"] = c.config.AWS.AccessKey
+		rootData["secret_key"] = c.config.AWS.SecretKey
+	}
+	if _, err := c.client.Logical().WriteWithContext(ctx, mount+"/config/root", rootData); err != nil {
+		return fmt.Errorf("failed to configure AWS engine: %w", err)
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, mount+"/config/lease", map[string]interface{}{
+		"lease":     c.config.AWS.DefaultTTL,
+		"lease_max": c.config.AWS.MaxTTL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure AWS engine leases: %w", err)
+	}
+
+	c.log(ctx).Info("Vault AWS secrets engine initialized successfully")
+	return nil
+}
+
+// WriteAWSRole creates an AWS role or updates an existing one.
+func (c *Client) WriteAWSRole(ctx context.Context, name string, req *AWSRoleRequest) error {
+	c.log(ctx).WithField("aws_role", name).Info("Writing AWS role...")
+
+	data := map[string]interface{}{
+		"credential_type": req.CredentialType,
+	}
+	if len(req.PolicyARNs) > 0 {
+		data["policy_arns"] = req.PolicyARNs
+	}
+	if req.PolicyDocument != "" {
+		data["policy_document"] = req.PolicyDocument
+	}
+	if len(req.RoleARNs) > 0 {
+		data["role_arns"] = req.RoleARNs
+	}
+	if len(req.IAMGroups) > 0 {
+		data["iam_groups"] = req.IAMGroups
+	}
+	if req.DefaultSTSTTL != "" {
+		data["default_sts_ttl"] = req.DefaultSTSTTL
+	}
+	if req.MaxSTSTTL != "" {
+		data["max_sts_ttl"] = req.MaxSTSTTL
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.AWS.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write AWS role: %w", err)
+	}
+
+	c.log(ctx).WithField("aws_role", name).Info("AWS role written successfully")
+	return nil
+}
+
+func (c *Client) GetAWSRole(ctx context.Context, name string) (*AWSRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.AWS.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read AWS role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &AWSRoleResponse{
+		Name:            name,
+		CredentialTypes: stringSlice(secret.Data["credential_type"]),
+		PolicyARNs:      stringSlice(secret.Data["policy_arns"]),
+		RoleARNs:        stringSlice(secret.Data["role_arns"]),
+		IAMGroups:       stringSlice(secret.Data["iam_groups"]),
+		DefaultSTSTTL:   int64Value(secret.Data["default_sts_ttl"]),
+		MaxSTSTTL:       int64Value(secret.Data["max_sts_ttl"]),
+	}
+	// Older Vault versions return credential_type as a single string
+	if credentialType, ok := secret.Data["credential_type"].(string); ok {
+		response.CredentialTypes = strings.Split(credentialType, ",")
+	}
+	response.PolicyDocument, _ = secret.Data["policy_document"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListAWSRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing AWS roles...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.AWS.MountPath+"/roles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AWS roles: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+func (c *Client) DeleteAWSRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("aws_role", name).Info("Deleting AWS role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.AWS.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete AWS role: %w", err)
+	}
+
+	c.log(ctx).WithField("aws_role", name).Info("AWS role deleted successfully")
+	return nil
+}
+
+// GetAWSCredentials generates credentials of the role's credential type
+// through aws/creds.
+func (c *Client) GetAWSCredentials(ctx context.Context, name string, req *AWSCredentialsRequest) (*AWSCredentialsResponse, error) {
+	return c.awsCredentials(ctx, "creds", name, req)
+}
+
+// GetAWSSTSCredentials generates STS credentials through aws/sts. The role
+// must use an STS credential type.
+func (c *Client) GetAWSSTSCredentials(ctx context.Context, name string, req *AWSCredentialsRequest) (*AWSCredentialsResponse, error) {
+	return c.awsCredentials(ctx, "sts", name, req)
+}
+
+func (c *Client) awsCredentials(ctx context.Context, endpoint, name string, req *AWSCredentialsRequest) (*AWSCredentialsResponse, error) {
+	c.log(ctx).WithField("aws_role", name).Info("Generating AWS credentials...")
+
+	data := map[string]interface{}{}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+	if req.RoleARN != "" {
+		data["role_arn"] = req.RoleARN
+	}
+	if req.RoleSessionName != "" {
+		data["role_session_name"] = req.RoleSessionName
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/%s/%s", c.config.AWS.MountPath, endpoint, name), data)
+	if err != nil {
+		if _, lookupErr := c.GetAWSRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("AWS role %q: %w", name, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to get AWS credentials: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no credential data returned")
+	}
+
+	response := &AWSCredentialsResponse{Lease: leaseFromSecret(secret)}
+	response.AccessKey, _ = secret.Data["access_key"].(string)
+	response.SecretKey, _ = secret.Data["secret_key"].(string)
+	response.SessionToken, _ = secret.Data["session_token"].(string)
+	response.ARN, _ = secret.Data["arn"].(string)
+
+	c.log(ctx).WithField("aws_role", name).Info("AWS credentials generated successfully")
+	return response, nil
+}
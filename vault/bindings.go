@@ -0,0 +1,217 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// rolePattern matches predefined roles and project or organization custom roles.
+var rolePattern = regexp.MustCompile(`^(roles/[\w.]+|(projects|organizations)/[\w.-]+/roles/[\w.]+)$`)
+
+// Bindings are the IAM bindings of a roleset or static account in the JSON
+// form of Vault's HCL bindings. Requests may instead give a list of
+// {"resource": ..., "roles": [...]} objects, which is converted on decode.
+type Bindings map[string]interface{}
+
+// ResourceBinding grants roles on a single resource.
+type ResourceBinding struct {
+	Resource string   `json:"resource"`
+	Roles    []string `json:"roles"`
+}
+
+func (b *Bindings) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		var list []ResourceBinding
+		if err := json.Unmarshal(data, &list); err != nil {
+			return fmt.Errorf("invalid bindings list: %w", err)
+		}
+		*b = BindingsFromList(list)
+		return nil
+	}
+
+	var blocks map[string]interface{}
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return err
+	}
+	*b = blocks
+	return nil
+}
+
+// BindingsFromList converts a list of resource bindings into resource blocks.
+// Roles of a resource listed more than once are combined.
+func BindingsFromList(list []ResourceBinding) Bindings {
+	blocks := make(map[string]interface{}, len(list))
+	for _, binding := range list {
+		var roles []interface{}
+		if existing, ok := blocks[binding.Resource].(map[string]interface{}); ok {
+			roles, _ = existing["roles"].([]interface{})
+		}
+		for _, role := range binding.Roles {
+			roles = append(roles, role)
+		}
+		blocks[binding.Resource] = map[string]interface{}{"roles": roles}
+	}
+	return Bindings{"resource": blocks}
+}
+
+// ReadBindingRoles converts bindings as returned by a roleset or static
+// account read into the roles granted per resource.
+func ReadBindingRoles(bindings interface{}) map[string][]string {
+	resources, _ := bindings.(map[string]interface{})
+
+	roles := make(map[string][]string, len(resources))
+	for resource, resourceRoles := range resources {
+		roles[resource] = stringSlice(resourceRoles)
+	}
+	return roles
+}
+
+// renderBindingsHCL renders validated bindings as Vault's HCL bindings, one
+// resource block per resource.
+func renderBindingsHCL(bindings map[string]interface{}) (string, error) {
+	var groups []map[string]interface{}
+	switch blocks := bindings["resource"].(type) {
+	case map[string]interface{}:
+		groups = append(groups, blocks)
+	case []interface{}:
+		for _, block := range blocks {
+			resources, ok := block.(map[string]interface{})
+			if !ok {
+				return "", fmt.Errorf("invalid bindings: resource blocks must be objects")
+			}
+			groups = append(groups, resources)
+		}
+	default:
+		return "", fmt.Errorf("invalid bindings: no resource blocks")
+	}
+
+	var hcl strings.Builder
+	for _, resources := range groups {
+		for _, resource := range sortedKeys(resources) {
+			binding, _ := resources[resource].(map[string]interface{})
+			roles := stringSlice(binding["roles"])
+			if len(roles) == 0 {
+				return "", fmt.Errorf("invalid bindings: no roles for resource %q", resource)
+			}
+
+			quoted := make([]string, len(roles))
+			for i, role := range roles {
+				quoted[i] = strconv.Quote(role)
+			}
+
+			fmt.Fprintf(&hcl, "resource %s {\n  roles = [%s]\n}\n", strconv.Quote(resource), strings.Join(quoted, ", "))
+		}
+	}
+
+	return hcl.String(), nil
+}
+
+// BindingError points at the field of a bindings document that is invalid.
+type BindingError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e BindingError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidateBindings checks bindings against the JSON form of Vault's HCL
+// bindings, in which each resource block maps a resource name to its roles:
+//
+//	{"resource": {"//cloudresourcemanager.googleapis.com/projects/p": {"roles": ["roles/viewer"]}}}
+//
+// Repeated resource blocks may also be given as a list of such objects.
+func ValidateBindings(bindings map[string]interface{}) []BindingError {
+	var errs []BindingError
+
+	for _, key := range sortedKeys(bindings) {
+		if key != "resource" {
+			errs = append(errs, BindingError{Field: "bindings." + key, Message: "unknown block, expected \"resource\""})
+		}
+	}
+
+	switch blocks := bindings["resource"].(type) {
+	case map[string]interface{}:
+		errs = append(errs, validateResourceBlock("bindings.resource", blocks)...)
+	case []interface{}:
+		for i, block := range blocks {
+			field := fmt.Sprintf("bindings.resource[%d]", i)
+			resources, ok := block.(map[string]interface{})
+			if !ok {
+				errs = append(errs, BindingError{Field: field, Message: "must be an object of resource names"})
+				continue
+			}
+			errs = append(errs, validateResourceBlock(field, resources)...)
+		}
+	case nil:
+		errs = append(errs, BindingError{Field: "bindings.resource", Message: "at least one resource is required"})
+	default:
+		errs = append(errs, BindingError{Field: "bindings.resource", Message: "must be an object of resource names"})
+	}
+
+	return errs
+}
+
+func validateResourceBlock(field string, resources map[string]interface{}) []BindingError {
+	var errs []BindingError
+
+	if len(resources) == 0 {
+		errs = append(errs, BindingError{Field: field, Message: "at least one resource is required"})
+	}
+
+	for _, resource := range sortedKeys(resources) {
+		value := resources[resource]
+		resourceField := fmt.Sprintf("%s[%q]", field, resource)
+		if resource == "" {
+			errs = append(errs, BindingError{Field: resourceField, Message: "resource name must not be empty"})
+		}
+
+		binding, ok := value.(map[string]interface{})
+		if !ok {
+			errs = append(errs, BindingError{Field: resourceField, Message: "must be an object with roles"})
+			continue
+		}
+
+		for _, key := range sortedKeys(binding) {
+			if key != "roles" {
+				errs = append(errs, BindingError{Field: resourceField + "." + key, Message: "unknown field, expected \"roles\""})
+			}
+		}
+
+		roles, ok := binding["roles"].([]interface{})
+		if !ok || len(roles) == 0 {
+			errs = append(errs, BindingError{Field: resourceField + ".roles", Message: "at least one role is required"})
+			continue
+		}
+
+		for i, role := range roles {
+			roleField := fmt.Sprintf("%s.roles[%d]", resourceField, i)
+			name, ok := role.(string)
+			if !ok {
+				errs = append(errs, BindingError{Field: roleField, Message: "must be a string"})
+				continue
+			}
+			if !rolePattern.MatchString(name) {
+				errs = append(errs, BindingError{Field: roleField, Message: fmt.Sprintf("%q is not a valid role name", name)})
+			}
+		}
+	}
+
+	return errs
+}
+
+// sortedKeys returns the keys of m in order, so errors are reported stably.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
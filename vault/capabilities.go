@@ -0,0 +1,33 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+)
+
+// CapabilitiesRequest lists the Vault paths to check hcvapi's token against.
+type CapabilitiesRequest struct {
+	Paths []string `json:"paths" binding:"required,min=1,dive,required"`
+}
+
+// CapabilitiesSelf returns the capabilities hcvapi's own token has on each
+// path, such as ["create", "read"] or ["deny"].
+func (c *Client) CapabilitiesSelf(ctx context.Context, paths []string) (map[string][]string, error) {
+	secret, err := c.client.Logical().WriteWithContext(ctx, "sys/capabilities-self", map[string]interface{}{
+		"paths": paths,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token capabilities: %w", err)
+	}
+
+	capabilities := make(map[string][]string, len(paths))
+	for _, path := range paths {
+		capabilities[path] = []string{"deny"}
+		if secret != nil && secret.Data != nil {
+			if granted := stringSlice(secret.Data[path]); len(granted) > 0 {
+				capabilities[path] = granted
+			}
+		}
+	}
+	return capabilities, nil
+}
@@ -0,0 +1,177 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ConsulRoleRequest defines the ACL token a Consul role issues. At least one
+// policy, role or identity must be set.
+type ConsulRoleRequest struct {
+	ConsulPolicies    []string `json:"consul_policies,omitempty"`
+	ConsulRoles       []string `json:"consul_roles,omitempty"`
+	ServiceIdentities []string `json:"service_identities,omitempty"`
+	NodeIdentities    []string `json:"node_identities,omitempty"`
+	Local             bool     `json:"local"`
+	TTL               string   `json:"ttl,omitempty"`
+	MaxTTL            string   `json:"max_ttl,omitempty"`
+}
+
+type ConsulRoleResponse struct {
+	Name              string   `json:"name"`
+	ConsulPolicies    []string `json:"consul_policies,omitempty"`
+	ConsulRoles       []string `json:"consul_roles,omitempty"`
+	ServiceIdentities []string `json:"service_identities,omitempty"`
+	NodeIdentities    []string `json:"node_identities,omitempty"`
+	Local             bool     `json:"local"`
+	TTL               int64    `json:"ttl"`
+	MaxTTL            int64    `json:"max_ttl"`
+}
+
+type ConsulTokenResponse struct {
+	Token    string `json:"token"`
+	Accessor string `json:"accessor"`
+	Local    bool   `json:"local"`
+	Lease
+}
+
+// InitializeConsul enables the Consul secrets engine and configures its
+// access to Consul. Without a token, Vault bootstraps the Consul ACL system
+// and keeps the management token.
+func (c *Client) InitializeConsul(ctx context.Context) error {
+	mount := c.config.Consul.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault Consul secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "consul", "Consul secrets engine for issuing ACL tokens"); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"address": c.config.Consul.Address,
+		"scheme":  c.config.Consul.Scheme,
+	}
+	if c.config.Consul.Token != "" {
+		data["token"] = c.config.Consul.Token
+	}
+	if _, err := c.client.Logical().WriteWithContext(ctx, mount+"/config/access", data); err != nil {
+		return fmt.Errorf("failed to configure Consul engine: %w", err)
+	}
+
+	c.log(ctx).Info("Vault Consul secrets engine initialized successfully")
+	return nil
+}
+
+// WriteConsulRole creates a Consul role or updates an existing one.
+func (c *Client) WriteConsulRole(ctx context.Context, name string, req *ConsulRoleRequest) error {
+	c.log(ctx).WithField("consul_role", name).Info("Writing Consul role...")
+
+	data := map[string]interface{}{
+		"local": req.Local,
+	}
+	if len(req.ConsulPolicies) > 0 {
+		data["consul_policies"] = req.ConsulPolicies
+	}
+	if len(req.ConsulRoles) > 0 {
+		data["consul_roles"] = req.ConsulRoles
+	}
+	if len(req.ServiceIdentities) > 0 {
+		data["service_identities"] = req.ServiceIdentities
+	}
+	if len(req.NodeIdentities) > 0 {
+		data["node_identities"] = req.NodeIdentities
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+	if req.MaxTTL != "" {
+		data["max_ttl"] = req.MaxTTL
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.Consul.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write Consul role: %w", err)
+	}
+
+	c.log(ctx).WithField("consul_role", name).Info("Consul role written successfully")
+	return nil
+}
+
+func (c *Client) GetConsulRole(ctx context.Context, name string) (*ConsulRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.Consul.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Consul role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &ConsulRoleResponse{
+		Name:              name,
+		ConsulPolicies:    stringSlice(secret.Data["consul_policies"]),
+		ConsulRoles:       stringSlice(secret.Data["consul_roles"]),
+		ServiceIdentities: stringSlice(secret.Data["service_identities"]),
+		NodeIdentities:    stringSlice(secret.Data["node_identities"]),
+		TTL:               int64Value(secret.Data["ttl"]),
+		MaxTTL:            int64Value(secret.Data["max_ttl"]),
+	}
+	response.Local, _ = secret.Data["local"].(bool)
+
+	return response, nil
+}
+
+func (c *Client) ListConsulRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing Consul roles...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.Consul.MountPath+"/roles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Consul roles: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+func (c *Client) DeleteConsulRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("consul_role", name).Info("Deleting Consul role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.Consul.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete Consul role: %w", err)
+	}
+
+	c.log(ctx).WithField("consul_role", name).Info("Consul role deleted successfully")
+	return nil
+}
+
+// GetConsulToken creates a Consul ACL token for the role. The token is
+// deleted from Consul when its lease expires or is revoked.
+func (c *Client) GetConsulToken(ctx context.Context, name string) (*ConsulTokenResponse, error) {
+	c.log(ctx).WithField("consul_role", name).Info("Generating Consul token...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/creds/%s", c.config.Consul.MountPath, name))
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetConsulRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("Consul role %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Consul token: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no token data returned")
+	}
+
+	response := &ConsulTokenResponse{Lease: leaseFromSecret(secret)}
+	response.Token, _ = secret.Data["token"].(string)
+	response.Accessor, _ = secret.Data["accessor"].(string)
+	response.Local, _ = secret.Data["local"].(bool)
+
+	c.log(ctx).WithField("consul_role", name).Info("Consul token generated successfully")
+	return response, nil
+}
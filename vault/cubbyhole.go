@@ -0,0 +1,224 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCubbyholeToken is returned when a cubbyhole token is invalid, expired
+// or out of uses.
+var ErrCubbyholeToken = errors.New("cubbyhole token is invalid or expired")
+
+// defaultCubbyholeTTL is the TTL of cubbyhole tokens created without one.
+const defaultCubbyholeTTL = "1h"
+
+type CubbyholeCreateRequest struct {
+	Data map[string]interface{} `json:"data" binding:"required"`
+	// Path is where the data is stored in the new cubbyhole, "data" if unset
+	Path string `json:"path,omitempty"`
+	TTL  string `json:"ttl,omitempty"`
+	// NumUses limits the reads and writes made with the token after the
+	// initial write; 0 is unlimited
+	NumUses int `json:"num_uses,omitempty" binding:"omitempty,min=1"`
+	// WrapTTL returns the token response-wrapped for that long
+	WrapTTL string `json:"wrap_ttl,omitempty"`
+}
+
+type CubbyholeWriteRequest struct {
+	Data map[string]interface{} `json:"data" binding:"required"`
+}
+
+// CubbyholeTokenResponse is the token owning a new cubbyhole. When Wrapped
+// is set, Token is a wrapping token to unwrap for the cubbyhole token with
+// Vault's sys/wrapping/unwrap; Unwrap only redeems wrapped GCP secrets.
+type CubbyholeTokenResponse struct {
+	Token    string `json:"token"`
+	Accessor string `json:"accessor"`
+	Path     string `json:"path"`
+	TTL      int    `json:"ttl"`
+	Wrapped  bool   `json:"wrapped"`
+}
+
+type CubbyholeSecretResponse struct {
+	Path string                 `json:"path"`
+	Data map[string]interface{} `json:"data"`
+}
+
+// CreateCubbyhole creates a child token with its own cubbyhole and stores
+// the data in it. The token is tagged with the caller it was created for.
+func (c *Client) CreateCubbyhole(ctx context.Context, caller string, req *CubbyholeCreateRequest) (*CubbyholeTokenResponse, error) {
+	c.log(ctx).WithField("caller", caller).Info("Creating cubbyhole...")
+
+	ttl := req.TTL
+	if ttl == "" {
+		ttl = defaultCubbyholeTTL
+	}
+	path := strings.Trim(req.Path, "/")
+	if path == "" {
+		path = "data"
+	}
+
+	// The initial write uses the token once
+	numUses := 0
+	if req.NumUses > 0 {
+		numUses = req.NumUses + 1
+	}
+	renewable := false
+
+	secret, err := c.client.Auth().Token().CreateWithContext(ctx, &api.TokenCreateRequest{
+		Policies:    []string{"default"},
+		Metadata:    map[string]string{"caller": caller},
+		TTL:         ttl,
+		DisplayName: "cubbyhole",
+		NumUses:     numUses,
+		Renewable:   &renewable,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cubbyhole token: %w", err)
+	}
+	if secret == nil || secret.Auth == nil {
+		return nil, fmt.Errorf("no token data returned")
+	}
+
+	// The token is never handed out when a later step fails
+	revoke := func() {
+		if err := c.client.Auth().Token().RevokeAccessorWithContext(ctx, secret.Auth.Accessor); err != nil {
+			c.log(ctx).WithError(err).Warn("Failed to revoke unused cubbyhole token")
+		}
+	}
+
+	if err := c.WriteCubbyhole(ctx, secret.Auth.ClientToken, path, req.Data); err != nil {
+		revoke()
+		return nil, err
+	}
+
+	response := &CubbyholeTokenResponse{
+		Token:    secret.Auth.ClientToken,
+		Accessor: secret.Auth.Accessor,
+		Path:     path,
+		TTL:      secret.Auth.LeaseDuration,
+	}
+
+	if req.WrapTTL != "" {
+		wrapped, err := c.wrapToken(ctx, response.Token, req.WrapTTL)
+		if err != nil {
+			revoke()
+			return nil, err
+		}
+		response.Token = wrapped
+		response.Wrapped = true
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"caller":   caller,
+		"accessor": response.Accessor,
+	}).Info("Cubbyhole created successfully")
+	return response, nil
+}
+
+// ReadCubbyhole reads a secret from the token's cubbyhole.
+func (c *Client) ReadCubbyhole(ctx context.Context, token, path string) (*CubbyholeSecretResponse, error) {
+	client, err := c.cubbyholeClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := client.Logical().ReadWithContext(ctx, "cubbyhole/"+path)
+	if err != nil {
+		return nil, cubbyholeError("read", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+	return &CubbyholeSecretResponse{Path: path, Data: secret.Data}, nil
+}
+
+// ListCubbyhole lists the keys under a folder of the token's cubbyhole.
+func (c *Client) ListCubbyhole(ctx context.Context, token, path string) ([]string, error) {
+	client, err := c.cubbyholeClient(token)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := client.Logical().ListWithContext(ctx, "cubbyhole/"+path)
+	if err != nil {
+		return nil, cubbyholeError("list", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+// WriteCubbyhole writes a secret to the token's cubbyhole, replacing any
+// data already at path.
+func (c *Client) WriteCubbyhole(ctx context.Context, token, path string, data map[string]interface{}) error {
+	client, err := c.cubbyholeClient(token)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Logical().WriteWithContext(ctx, "cubbyhole/"+path, data); err != nil {
+		return cubbyholeError("write", err)
+	}
+	return nil
+}
+
+// DeleteCubbyhole deletes a secret from the token's cubbyhole.
+func (c *Client) DeleteCubbyhole(ctx context.Context, token, path string) error {
+	client, err := c.cubbyholeClient(token)
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Logical().DeleteWithContext(ctx, "cubbyhole/"+path); err != nil {
+		return cubbyholeError("delete", err)
+	}
+	return nil
+}
+
+// cubbyholeClient returns a client acting as the cubbyhole's token, since a
+// cubbyhole is only reachable by the token that owns it.
+func (c *Client) cubbyholeClient(token string) (*api.Client, error) {
+	client, err := c.client.CloneWithHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone vault client: %w", err)
+	}
+	client.SetToken(token)
+	return client, nil
+}
+
+// wrapToken response-wraps token for ttl and returns the wrapping token.
+func (c *Client) wrapToken(ctx context.Context, token, ttl string) (string, error) {
+	client, err := c.wrappingClient(ttl)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := client.Logical().WriteWithContext(ctx, "sys/wrapping/wrap", map[string]interface{}{
+		"token": token,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap cubbyhole token: %w", err)
+	}
+	if secret == nil || secret.WrapInfo == nil {
+		return "", fmt.Errorf("no wrapping data returned")
+	}
+	return secret.WrapInfo.Token, nil
+}
+
+func cubbyholeError(operation string, err error) error {
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusForbidden {
+		return ErrCubbyholeToken
+	}
+	return fmt.Errorf("failed to %s cubbyhole secret: %w", operation, err)
+}
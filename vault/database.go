@@ -0,0 +1,191 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+type DatabaseRoleRequest struct {
+	DBName               string   `json:"db_name" binding:"required"`
+	CreationStatements   []string `json:"creation_statements" binding:"required,min=1"`
+	RevocationStatements []string `json:"revocation_statements,omitempty"`
+	RollbackStatements   []string `json:"rollback_statements,omitempty"`
+	RenewStatements      []string `json:"renew_statements,omitempty"`
+	DefaultTTL           string   `json:"default_ttl,omitempty"`
+	MaxTTL               string   `json:"max_ttl,omitempty"`
+}
+
+type DatabaseRoleResponse struct {
+	Name                 string   `json:"name"`
+	DBName               string   `json:"db_name"`
+	CreationStatements   []string `json:"creation_statements"`
+	RevocationStatements []string `json:"revocation_statements,omitempty"`
+	RollbackStatements   []string `json:"rollback_statements,omitempty"`
+	RenewStatements      []string `json:"renew_statements,omitempty"`
+	DefaultTTL           int64    `json:"default_ttl"`
+	MaxTTL               int64    `json:"max_ttl"`
+}
+
+type DatabaseCredentialsResponse struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Lease
+}
+
+// InitializeDatabase enables the database secrets engine and writes the
+// connections configured in hcvapi.
+func (c *Client) InitializeDatabase(ctx context.Context) error {
+	mount := c.config.Database.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault database secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "database", "Database secrets engine for issuing dynamic database credentials"); err != nil {
+		return err
+	}
+
+	for _, name := range sortedConnectionNames(c.config.Database.Connections) {
+		connection := c.config.Database.Connections[name]
+
+		data := map[string]interface{}{
+			"plugin_name":    connection.PluginName,
+			"connection_url": connection.ConnectionURL,
+			"allowed_roles":  connection.AllowedRoles,
+		}
+		if connection.Username != "" {
+			data["username"] = connection.Username
+			data["password"] = connection.Password
+		}
+
+		_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/config/%s", mount, name), data)
+		if err != nil {
+			return fmt.Errorf("failed to configure database connection %s: %w", name, err)
+		}
+		c.log(ctx).WithField("connection", name).Info("Database connection configured successfully")
+	}
+
+	c.log(ctx).Info("Vault database secrets engine initialized successfully")
+	return nil
+}
+
+// WriteDatabaseRole creates a database role or updates an existing one.
+func (c *Client) WriteDatabaseRole(ctx context.Context, name string, req *DatabaseRoleRequest) error {
+	c.log(ctx).WithField("database_role", name).Info("Writing database role...")
+
+	data := map[string]interface{}{
+		"db_name":             req.DBName,
+		"creation_statements": req.CreationStatements,
+	}
+	if len(req.RevocationStatements) > 0 {
+		data["revocation_statements"] = req.RevocationStatements
+	}
+	if len(req.RollbackStatements) > 0 {
+		data["rollback_statements"] = req.RollbackStatements
+	}
+	if len(req.RenewStatements) > 0 {
+		data["renew_statements"] = req.RenewStatements
+	}
+	if req.DefaultTTL != "" {
+		data["default_ttl"] = req.DefaultTTL
+	}
+	if req.MaxTTL != "" {
+		data["max_ttl"] = req.MaxTTL
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.Database.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write database role: %w", err)
+	}
+
+	c.log(ctx).WithField("database_role", name).Info("Database role written successfully")
+	return nil
+}
+
+func (c *Client) GetDatabaseRole(ctx context.Context, name string) (*DatabaseRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.Database.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read database role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &DatabaseRoleResponse{
+		Name:                 name,
+		CreationStatements:   stringSlice(secret.Data["creation_statements"]),
+		RevocationStatements: stringSlice(secret.Data["revocation_statements"]),
+		RollbackStatements:   stringSlice(secret.Data["rollback_statements"]),
+		RenewStatements:      stringSlice(secret.Data["renew_statements"]),
+		DefaultTTL:           int64Value(secret.Data["default_ttl"]),
+		MaxTTL:               int64Value(secret.Data["max_ttl"]),
+	}
+	response.DBName, _ = secret.Data["db_name"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListDatabaseRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing database roles...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.Database.MountPath+"/roles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list database roles: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+func (c *Client) DeleteDatabaseRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("database_role", name).Info("Deleting database role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.Database.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete database role: %w", err)
+	}
+
+	c.log(ctx).WithField("database_role", name).Info("Database role deleted successfully")
+	return nil
+}
+
+// GetDatabaseCredentials creates a database user for the role. The user is
+// dropped when its lease expires or is revoked.
+func (c *Client) GetDatabaseCredentials(ctx context.Context, name string) (*DatabaseCredentialsResponse, error) {
+	c.log(ctx).WithField("database_role", name).Info("Generating database credentials...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/creds/%s", c.config.Database.MountPath, name))
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetDatabaseRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("database role %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database credentials: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no credential data returned")
+	}
+
+	response := &DatabaseCredentialsResponse{Lease: leaseFromSecret(secret)}
+	response.Username, _ = secret.Data["username"].(string)
+	response.Password, _ = secret.Data["password"].(string)
+
+	c.log(ctx).WithField("database_role", name).Info("Database credentials generated successfully")
+	return response, nil
+}
+
+func sortedConnectionNames(connections map[string]config.DatabaseConnectionConfig) []string {
+	names := make([]string, 0, len(connections))
+	for name := range connections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
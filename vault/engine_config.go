@@ -0,0 +1,123 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// EngineConfigResponse is the GCP secrets engine configuration. Credentials
+// are never included.
+type EngineConfigResponse struct {
+	TTL                      int64  `json:"ttl"`
+	MaxTTL                   int64  `json:"max_ttl"`
+	DisableAutomatedRotation bool   `json:"disable_automated_rotation"`
+	RotationSchedule         string `json:"rotation_schedule,omitempty"`
+	RotationWindow           int64  `json:"rotation_window,omitempty"`
+	RotationPeriod           int64  `json:"rotation_period,omitempty"`
+}
+
+func (c *Client) GetEngineConfig(ctx context.Context) (*EngineConfigResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, c.mount+"/config")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCP engine config: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &EngineConfigResponse{
+		TTL:            int64Value(secret.Data["ttl"]),
+		MaxTTL:         int64Value(secret.Data["max_ttl"]),
+		RotationWindow: int64Value(secret.Data["rotation_window"]),
+		RotationPeriod: int64Value(secret.Data["rotation_period"]),
+	}
+	response.DisableAutomatedRotation, _ = secret.Data["disable_automated_rotation"].(bool)
+	response.RotationSchedule, _ = secret.Data["rotation_schedule"].(string)
+
+	return response, nil
+}
+
+// RotationSettings holds the root rotation fields of an engine config update;
+// nil fields are left unchanged.
+type RotationSettings struct {
+	RotationSchedule         *string `json:"rotation_schedule,omitempty"`
+	RotationWindow           *string `json:"rotation_window,omitempty"`
+	DisableAutomatedRotation *bool   `json:"disable_automated_rotation,omitempty"`
+}
+
+// UpdateRotationSettings changes the engine's automated root rotation and
+// returns the resulting config. Other config fields keep their values.
+func (c *Client) UpdateRotationSettings(ctx context.Context, settings *RotationSettings) (*EngineConfigResponse, error) {
+	c.log(ctx).Info("Updating GCP root rotation settings...")
+
+	data := map[string]interface{}{}
+	if settings.RotationSchedule != nil {
+		data["rotation_schedule"] = *settings.RotationSchedule
+	}
+	if settings.RotationWindow != nil {
+		data["rotation_window"] = *settings.RotationWindow
+	}
+	if settings.DisableAutomatedRotation != nil {
+		data["disable_automated_rotation"] = *settings.DisableAutomatedRotation
+	}
+
+	if len(data) > 0 {
+		if _, err := c.client.Logical().WriteWithContext(ctx, c.mount+"/config", data); err != nil {
+			return nil, fmt.Errorf("failed to update GCP root rotation settings: %w", err)
+		}
+	}
+
+	c.log(ctx).Info("GCP root rotation settings updated successfully")
+	return c.GetEngineConfig(ctx)
+}
+
+// MountTuning holds the lease TTLs of the engine mount. TTLs are written as
+// Vault duration strings such as "1h" and read back in seconds.
+type MountTuning struct {
+	DefaultLeaseTTL string `json:"default_lease_ttl,omitempty"`
+	MaxLeaseTTL     string `json:"max_lease_ttl,omitempty"`
+}
+
+type MountTuningResponse struct {
+	DefaultLeaseTTL int `json:"default_lease_ttl"`
+	MaxLeaseTTL     int `json:"max_lease_ttl"`
+}
+
+// TuneMount changes the lease TTLs of the engine mount and returns the
+// resulting values. Empty fields keep their values.
+func (c *Client) TuneMount(ctx context.Context, tuning *MountTuning) (*MountTuningResponse, error) {
+	c.log(ctx).WithField("mount", c.mount).Info("Tuning GCP engine mount...")
+
+	response, err := c.tuneMount(ctx, c.mount, tuning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tune GCP engine mount: %w", err)
+	}
+
+	c.log(ctx).WithField("mount", c.mount).Info("GCP engine mount tuned successfully")
+	return response, nil
+}
+
+// tuneMount changes the lease TTLs of the mount at path and returns the
+// resulting values.
+func (c *Client) tuneMount(ctx context.Context, path string, tuning *MountTuning) (*MountTuningResponse, error) {
+	err := c.client.Sys().TuneMountWithContext(ctx, path, api.MountConfigInput{
+		DefaultLeaseTTL: tuning.DefaultLeaseTTL,
+		MaxLeaseTTL:     tuning.MaxLeaseTTL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mountConfig, err := c.client.Sys().MountConfigWithContext(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mount tuning: %w", err)
+	}
+
+	return &MountTuningResponse{
+		DefaultLeaseTTL: mountConfig.DefaultLeaseTTL,
+		MaxLeaseTTL:     mountConfig.MaxLeaseTTL,
+	}, nil
+}
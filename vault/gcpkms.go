@@ -0,0 +1,278 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// GCPKMSRegisterRequest registers an existing Cloud KMS crypto key under a
+// name. CryptoKey is the key's full resource name,
+// projects/{project}/locations/{location}/keyRings/{ring}/cryptoKeys/{key}.
+type GCPKMSRegisterRequest struct {
+	CryptoKey string `json:"crypto_key" binding:"required"`
+	// Verify checks that Vault can access the key before registering it
+	Verify *bool `json:"verify,omitempty"`
+}
+
+type GCPKMSKeyResponse struct {
+	Name           string `json:"name"`
+	CryptoKey      string `json:"crypto_key"`
+	Purpose        string `json:"purpose,omitempty"`
+	RotationPeriod string `json:"rotation_period,omitempty"`
+	MinVersion     int64  `json:"min_version,omitempty"`
+	MaxVersion     int64  `json:"max_version,omitempty"`
+}
+
+// GCPKMSEncryptRequest encrypts Plaintext with a symmetric key. KeyVersion 0
+// uses the primary version.
+type GCPKMSEncryptRequest struct {
+	Plaintext                   string `json:"plaintext" binding:"required"`
+	AdditionalAuthenticatedData string `json:"additional_authenticated_data,omitempty"`
+	KeyVersion                  int    `json:"key_version,omitempty"`
+}
+
+// GCPKMSDecryptRequest decrypts or re-encrypts Ciphertext. KeyVersion is
+// required for asymmetric keys.
+type GCPKMSDecryptRequest struct {
+	Ciphertext                  string `json:"ciphertext" binding:"required"`
+	AdditionalAuthenticatedData string `json:"additional_authenticated_data,omitempty"`
+	KeyVersion                  int    `json:"key_version,omitempty"`
+}
+
+type GCPKMSCiphertextResponse struct {
+	Ciphertext string `json:"ciphertext"`
+	KeyVersion int64  `json:"key_version,omitempty"`
+}
+
+// GCPKMSSignRequest signs a base64 encoded Digest with an asymmetric signing
+// key. The digest algorithm must match the key's.
+type GCPKMSSignRequest struct {
+	Digest     string `json:"digest" binding:"required"`
+	KeyVersion int    `json:"key_version" binding:"required,min=1"`
+}
+
+type GCPKMSVerifyRequest struct {
+	Digest     string `json:"digest" binding:"required"`
+	Signature  string `json:"signature" binding:"required"`
+	KeyVersion int    `json:"key_version" binding:"required,min=1"`
+}
+
+// InitializeGCPKMS enables the Google Cloud KMS secrets engine and configures
+// its credentials.
+func (c *Client) InitializeGCPKMS(ctx context.Context) error {
+	mount := c.config.GCPKMS.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault GCP KMS secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "gcpkms", "Google Cloud KMS secrets engine for encryption and signing"); err != nil {
+		return err
+	}
+
+	// Without credentials Vault uses Application Default Credentials
+	data := map[string]interface{}{}
+	if c.config.GCPKMS.ServiceAccountPath != "" {
+		credentials, err := os.ReadFile(c.config.GCPKMS.ServiceAccountPath)
+		if err != nil {
+			return fmt.Errorf("failed to read service account file: %w", err)
+		}
+		data["credentials"] = string(credentials)
+	}
+	if _, err := c.client.Logical().WriteWithContext(ctx, mount+"/config", data); err != nil {
+		return fmt.Errorf("failed to configure GCP KMS engine: %w", err)
+	}
+
+	c.log(ctx).Info("Vault GCP KMS secrets engine initialized successfully")
+	return nil
+}
+
+// RegisterGCPKMSKey registers an existing crypto key. Registered keys are
+// only referenced; deregistering them leaves them in Cloud KMS.
+func (c *Client) RegisterGCPKMSKey(ctx context.Context, name string, req *GCPKMSRegisterRequest) error {
+	c.log(ctx).WithFields(logrus.Fields{
+		"gcpkms_key": name,
+		"crypto_key": req.CryptoKey,
+	}).Info("Registering GCP KMS key...")
+
+	data := map[string]interface{}{
+		"crypto_key": req.CryptoKey,
+	}
+	if req.Verify != nil {
+		data["verify"] = *req.Verify
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/keys/register/%s", c.config.GCPKMS.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to register GCP KMS key: %w", err)
+	}
+
+	c.log(ctx).WithField("gcpkms_key", name).Info("GCP KMS key registered successfully")
+	return nil
+}
+
+func (c *Client) GetGCPKMSKey(ctx context.Context, name string) (*GCPKMSKeyResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/keys/%s", c.config.GCPKMS.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GCP KMS key: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &GCPKMSKeyResponse{
+		Name:       name,
+		MinVersion: int64Value(secret.Data["min_version"]),
+		MaxVersion: int64Value(secret.Data["max_version"]),
+	}
+	response.CryptoKey, _ = secret.Data["id"].(string)
+	response.Purpose, _ = secret.Data["purpose"].(string)
+	response.RotationPeriod, _ = secret.Data["rotation_period"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListGCPKMSKeys(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing GCP KMS keys...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.GCPKMS.MountPath+"/keys")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list GCP KMS keys: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+// DeregisterGCPKMSKey removes a key from Vault without touching it in
+// Cloud KMS.
+func (c *Client) DeregisterGCPKMSKey(ctx context.Context, name string) error {
+	c.log(ctx).WithField("gcpkms_key", name).Info("Deregistering GCP KMS key...")
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/keys/deregister/%s", c.config.GCPKMS.MountPath, name), nil)
+	if err != nil {
+		return fmt.Errorf("failed to deregister GCP KMS key: %w", err)
+	}
+
+	c.log(ctx).WithField("gcpkms_key", name).Info("GCP KMS key deregistered successfully")
+	return nil
+}
+
+func (c *Client) GCPKMSEncrypt(ctx context.Context, name string, req *GCPKMSEncryptRequest) (*GCPKMSCiphertextResponse, error) {
+	data := map[string]interface{}{
+		"plaintext": req.Plaintext,
+	}
+	if req.AdditionalAuthenticatedData != "" {
+		data["additional_authenticated_data"] = req.AdditionalAuthenticatedData
+	}
+	if req.KeyVersion != 0 {
+		data["key_version"] = req.KeyVersion
+	}
+
+	secret, err := c.gcpkmsOperation(ctx, "encrypt", name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GCPKMSCiphertextResponse{KeyVersion: int64Value(secret.Data["key_version"])}
+	response.Ciphertext, _ = secret.Data["ciphertext"].(string)
+	return response, nil
+}
+
+func (c *Client) GCPKMSDecrypt(ctx context.Context, name string, req *GCPKMSDecryptRequest) (string, error) {
+	secret, err := c.gcpkmsOperation(ctx, "decrypt", name, gcpkmsCiphertextData(req))
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, _ := secret.Data["plaintext"].(string)
+	return plaintext, nil
+}
+
+// GCPKMSReencrypt re-encrypts a ciphertext with the key's primary version
+// without returning the plaintext.
+func (c *Client) GCPKMSReencrypt(ctx context.Context, name string, req *GCPKMSDecryptRequest) (*GCPKMSCiphertextResponse, error) {
+	secret, err := c.gcpkmsOperation(ctx, "reencrypt", name, gcpkmsCiphertextData(req))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GCPKMSCiphertextResponse{KeyVersion: int64Value(secret.Data["key_version"])}
+	response.Ciphertext, _ = secret.Data["ciphertext"].(string)
+	return response, nil
+}
+
+func (c *Client) GCPKMSSign(ctx context.Context, name string, req *GCPKMSSignRequest) (string, error) {
+	secret, err := c.gcpkmsOperation(ctx, "sign", name, map[string]interface{}{
+		"digest":      req.Digest,
+		"key_version": req.KeyVersion,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signature, _ := secret.Data["signature"].(string)
+	return signature, nil
+}
+
+func (c *Client) GCPKMSVerify(ctx context.Context, name string, req *GCPKMSVerifyRequest) (bool, error) {
+	secret, err := c.gcpkmsOperation(ctx, "verify", name, map[string]interface{}{
+		"digest":      req.Digest,
+		"signature":   req.Signature,
+		"key_version": req.KeyVersion,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	valid, _ := secret.Data["valid"].(bool)
+	return valid, nil
+}
+
+// gcpkmsOperation runs a cryptographic operation with a registered key. A
+// missing key is reported as ErrNotFound.
+func (c *Client) gcpkmsOperation(ctx context.Context, operation, name string, data map[string]interface{}) (*api.Secret, error) {
+	c.log(ctx).WithFields(logrus.Fields{
+		"gcpkms_key": name,
+		"operation":  operation,
+	}).Info("Running GCP KMS operation...")
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/%s/%s", c.config.GCPKMS.MountPath, operation, name), data)
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetGCPKMSKey(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("GCP KMS key %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s with GCP KMS key: %w", operation, err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no %s result returned", operation)
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"gcpkms_key": name,
+		"operation":  operation,
+	}).Info("GCP KMS operation completed successfully")
+	return secret, nil
+}
+
+func gcpkmsCiphertextData(req *GCPKMSDecryptRequest) map[string]interface{} {
+	data := map[string]interface{}{
+		"ciphertext": req.Ciphertext,
+	}
+	if req.AdditionalAuthenticatedData != "" {
+		data["additional_authenticated_data"] = req.AdditionalAuthenticatedData
+	}
+	if req.KeyVersion != 0 {
+		data["key_version"] = req.KeyVersion
+	}
+	return data
+}
@@ -0,0 +1,86 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// VaultHealthResponse is the health of the Vault node hcvapi talks to.
+// Replication modes are "disabled" outside Vault Enterprise.
+type VaultHealthResponse struct {
+	Initialized                bool      `json:"initialized"`
+	Sealed                     bool      `json:"sealed"`
+	Standby                    bool      `json:"standby"`
+	PerformanceStandby         bool      `json:"performance_standby"`
+	ReplicationPerformanceMode string    `json:"replication_performance_mode"`
+	ReplicationDRMode          string    `json:"replication_dr_mode"`
+	ServerTime                 time.Time `json:"server_time"`
+	Version                    string    `json:"version"`
+	ClusterName                string    `json:"cluster_name,omitempty"`
+	ClusterID                  string    `json:"cluster_id,omitempty"`
+}
+
+// Ready reports whether the node can serve requests. Standby nodes forward
+// requests to the active node, so they count as ready.
+func (r *VaultHealthResponse) Ready() bool {
+	return r.Initialized && !r.Sealed
+}
+
+// VaultHealth reads the full health of the Vault node, unlike HealthCheck,
+// which only tells whether it is ready. It is never cached.
+func (c *Client) VaultHealth(ctx context.Context) (*VaultHealthResponse, error) {
+	health, err := c.client.Sys().HealthWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("vault health check failed: %w", err)
+	}
+
+	return &VaultHealthResponse{
+		Initialized:                health.Initialized,
+		Sealed:                     health.Sealed,
+		Standby:                    health.Standby,
+		PerformanceStandby:         health.PerformanceStandby,
+		ReplicationPerformanceMode: health.ReplicationPerformanceMode,
+		ReplicationDRMode:          health.ReplicationDRMode,
+		ServerTime:                 time.Unix(health.ServerTimeUTC, 0).UTC(),
+		Version:                    health.Version,
+		ClusterName:                health.ClusterName,
+		ClusterID:                  health.ClusterID,
+	}, nil
+}
+
+// LeaderResponse tells whether the node hcvapi talks to is the active node
+// of its cluster and which node is.
+type LeaderResponse struct {
+	HAEnabled            bool       `json:"ha_enabled"`
+	IsSelf               bool       `json:"is_self"`
+	ActiveTime           *time.Time `json:"active_time,omitempty"`
+	LeaderAddress        string     `json:"leader_address"`
+	LeaderClusterAddress string     `json:"leader_cluster_address"`
+	PerformanceStandby   bool       `json:"performance_standby"`
+	RaftCommittedIndex   uint64     `json:"raft_committed_index,omitempty"`
+	RaftAppliedIndex     uint64     `json:"raft_applied_index,omitempty"`
+}
+
+// Leader reads the HA status of the Vault node from sys/leader.
+func (c *Client) Leader(ctx context.Context) (*LeaderResponse, error) {
+	leader, err := c.client.Sys().LeaderWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read leader status: %w", err)
+	}
+
+	response := &LeaderResponse{
+		HAEnabled:            leader.HAEnabled,
+		IsSelf:               leader.IsSelf,
+		LeaderAddress:        leader.LeaderAddress,
+		LeaderClusterAddress: leader.LeaderClusterAddress,
+		PerformanceStandby:   leader.PerfStandby,
+		RaftCommittedIndex:   leader.RaftCommittedIndex,
+		RaftAppliedIndex:     leader.RaftAppliedIndex,
+	}
+	// Only the active node reports when it became active
+	if !leader.ActiveTime.IsZero() {
+		response.ActiveTime = &leader.ActiveTime
+	}
+	return response, nil
+}
@@ -0,0 +1,278 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type IdentityEntityRequest struct {
+	Policies []string          `json:"policies,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	Disabled bool              `json:"disabled,omitempty"`
+}
+
+type IdentityEntityResponse struct {
+	ID             string                  `json:"id"`
+	Name           string                  `json:"name"`
+	Policies       []string                `json:"policies"`
+	Metadata       map[string]string       `json:"metadata,omitempty"`
+	Disabled       bool                    `json:"disabled"`
+	Aliases        []IdentityAliasResponse `json:"aliases"`
+	DirectGroupIDs []string                `json:"direct_group_ids"`
+	CreationTime   time.Time               `json:"creation_time"`
+	LastUpdateTime time.Time               `json:"last_update_time"`
+}
+
+// IdentityAliasRequest links an entity to a login through an auth method.
+// Name is the login name the auth method reports, such as a username.
+type IdentityAliasRequest struct {
+	Name           string            `json:"name" binding:"required"`
+	CanonicalID    string            `json:"canonical_id" binding:"required"`
+	MountAccessor  string            `json:"mount_accessor" binding:"required"`
+	CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+}
+
+type IdentityAliasResponse struct {
+	ID             string            `json:"id"`
+	Name           string            `json:"name"`
+	CanonicalID    string            `json:"canonical_id"`
+	MountAccessor  string            `json:"mount_accessor"`
+	MountType      string            `json:"mount_type"`
+	MountPath      string            `json:"mount_path"`
+	CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+}
+
+type IdentityGroupRequest struct {
+	Type            string            `json:"type,omitempty" binding:"omitempty,oneof=internal external"`
+	Policies        []string          `json:"policies,omitempty"`
+	MemberEntityIDs []string          `json:"member_entity_ids,omitempty"`
+	MemberGroupIDs  []string          `json:"member_group_ids,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+type IdentityGroupResponse struct {
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	Type            string            `json:"type"`
+	Policies        []string          `json:"policies"`
+	MemberEntityIDs []string          `json:"member_entity_ids"`
+	MemberGroupIDs  []string          `json:"member_group_ids"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+	CreationTime    time.Time         `json:"creation_time"`
+	LastUpdateTime  time.Time         `json:"last_update_time"`
+}
+
+// WriteIdentityEntity creates an entity or updates an existing one by name.
+func (c *Client) WriteIdentityEntity(ctx context.Context, name string, req *IdentityEntityRequest) error {
+	c.log(ctx).WithField("entity", name).Info("Writing identity entity...")
+
+	data := map[string]interface{}{
+		"policies": req.Policies,
+		"disabled": req.Disabled,
+	}
+	if req.Metadata != nil {
+		data["metadata"] = req.Metadata
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, "identity/entity/name/"+name, data)
+	if err != nil {
+		return fmt.Errorf("failed to write identity entity: %w", err)
+	}
+
+	c.log(ctx).WithField("entity", name).Info("Identity entity written successfully")
+	return nil
+}
+
+func (c *Client) GetIdentityEntity(ctx context.Context, name string) (*IdentityEntityResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, "identity/entity/name/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity entity: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &IdentityEntityResponse{}
+	if err := remarshal(secret.Data, response); err != nil {
+		return nil, fmt.Errorf("failed to decode identity entity: %w", err)
+	}
+	return response, nil
+}
+
+func (c *Client) ListIdentityEntities(ctx context.Context) ([]string, error) {
+	return c.listIdentity(ctx, "identity/entity/name", "identity entities")
+}
+
+func (c *Client) DeleteIdentityEntity(ctx context.Context, name string) error {
+	c.log(ctx).WithField("entity", name).Info("Deleting identity entity...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, "identity/entity/name/"+name)
+	if err != nil {
+		return fmt.Errorf("failed to delete identity entity: %w", err)
+	}
+
+	c.log(ctx).WithField("entity", name).Info("Identity entity deleted successfully")
+	return nil
+}
+
+// CreateIdentityAlias creates an entity alias and returns its ID.
+func (c *Client) CreateIdentityAlias(ctx context.Context, req *IdentityAliasRequest) (string, error) {
+	c.log(ctx).WithField("entity_id", req.CanonicalID).Info("Creating identity entity alias...")
+
+	data := map[string]interface{}{
+		"name":           req.Name,
+		"canonical_id":   req.CanonicalID,
+		"mount_accessor": req.MountAccessor,
+	}
+	if req.CustomMetadata != nil {
+		data["custom_metadata"] = req.CustomMetadata
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, "identity/entity-alias", data)
+	if err != nil {
+		return "", fmt.Errorf("failed to create identity entity alias: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("no entity alias data returned")
+	}
+	id, _ := secret.Data["id"].(string)
+
+	c.log(ctx).WithField("alias_id", id).Info("Identity entity alias created successfully")
+	return id, nil
+}
+
+// UpdateIdentityAlias updates an entity alias, which may move it to another
+// entity.
+func (c *Client) UpdateIdentityAlias(ctx context.Context, id string, req *IdentityAliasRequest) error {
+	c.log(ctx).WithField("alias_id", id).Info("Updating identity entity alias...")
+
+	data := map[string]interface{}{
+		"name":           req.Name,
+		"canonical_id":   req.CanonicalID,
+		"mount_accessor": req.MountAccessor,
+	}
+	if req.CustomMetadata != nil {
+		data["custom_metadata"] = req.CustomMetadata
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, "identity/entity-alias/id/"+id, data)
+	if err != nil {
+		return fmt.Errorf("failed to update identity entity alias: %w", err)
+	}
+
+	c.log(ctx).WithField("alias_id", id).Info("Identity entity alias updated successfully")
+	return nil
+}
+
+func (c *Client) GetIdentityAlias(ctx context.Context, id string) (*IdentityAliasResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, "identity/entity-alias/id/"+id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity entity alias: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &IdentityAliasResponse{}
+	if err := remarshal(secret.Data, response); err != nil {
+		return nil, fmt.Errorf("failed to decode identity entity alias: %w", err)
+	}
+	return response, nil
+}
+
+func (c *Client) ListIdentityAliases(ctx context.Context) ([]string, error) {
+	return c.listIdentity(ctx, "identity/entity-alias/id", "identity entity aliases")
+}
+
+func (c *Client) DeleteIdentityAlias(ctx context.Context, id string) error {
+	c.log(ctx).WithField("alias_id", id).Info("Deleting identity entity alias...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, "identity/entity-alias/id/"+id)
+	if err != nil {
+		return fmt.Errorf("failed to delete identity entity alias: %w", err)
+	}
+
+	c.log(ctx).WithField("alias_id", id).Info("Identity entity alias deleted successfully")
+	return nil
+}
+
+// WriteIdentityGroup creates a group or updates an existing one by name.
+// Vault manages the members of external groups through group aliases, so
+// member IDs only apply to internal groups.
+func (c *Client) WriteIdentityGroup(ctx context.Context, name string, req *IdentityGroupRequest) error {
+	c.log(ctx).WithField("group", name).Info("Writing identity group...")
+
+	data := map[string]interface{}{
+		"policies": req.Policies,
+	}
+	if req.Type != "" {
+		data["type"] = req.Type
+	}
+	if req.Type != "external" {
+		data["member_entity_ids"] = req.MemberEntityIDs
+		data["member_group_ids"] = req.MemberGroupIDs
+	}
+	if req.Metadata != nil {
+		data["metadata"] = req.Metadata
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, "identity/group/name/"+name, data)
+	if err != nil {
+		return fmt.Errorf("failed to write identity group: %w", err)
+	}
+
+	c.log(ctx).WithField("group", name).Info("Identity group written successfully")
+	return nil
+}
+
+func (c *Client) GetIdentityGroup(ctx context.Context, name string) (*IdentityGroupResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, "identity/group/name/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read identity group: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &IdentityGroupResponse{}
+	if err := remarshal(secret.Data, response); err != nil {
+		return nil, fmt.Errorf("failed to decode identity group: %w", err)
+	}
+	return response, nil
+}
+
+func (c *Client) ListIdentityGroups(ctx context.Context) ([]string, error) {
+	return c.listIdentity(ctx, "identity/group/name", "identity groups")
+}
+
+func (c *Client) DeleteIdentityGroup(ctx context.Context, name string) error {
+	c.log(ctx).WithField("group", name).Info("Deleting identity group...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, "identity/group/name/"+name)
+	if err != nil {
+		return fmt.Errorf("failed to delete identity group: %w", err)
+	}
+
+	c.log(ctx).WithField("group", name).Info("Identity group deleted successfully")
+	return nil
+}
+
+func (c *Client) listIdentity(ctx context.Context, path, what string) ([]string, error) {
+	c.log(ctx).Info("Listing " + what + "...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", what, err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
@@ -2,26 +2,43 @@ package vault
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/vault/api"
 	"github.com/sirupsen/logrus"
+	"github.com/kalpesh172000/hcvapi/cache"
 	"github.com/kalpesh172000/hcvapi/config"
+	"github.com/kalpesh172000/hcvapi/logging"
 )
 
+// ErrNotFound is returned when the requested Vault object does not exist.
+var ErrNotFound = errors.New("not found")
+
 type Client struct {
-	client *api.Client
-	config *config.Config
-	logger *logrus.Logger
+	client    *api.Client
+	config    *config.Config
+	logger    *logrus.Logger
+	cache     cache.Cache
+	accessors *accessorAudit
+	// mount is the path the GCP secrets engine is mounted at and engine its
+	// settings
+	mount     string
+	engine    config.GCPMountConfig
+	// mounts holds a client per configured mount, shared by all of them
+	mounts    map[string]*Client
 }
 
 type TokenResponse struct {
 	Token             string `json:"token"`
 	TokenTTL          string `json:"token_ttl"`
 	ExpiresAtSeconds  int64  `json:"expires_at_seconds"`
+	Lease
 }
 
 type ServiceAccountKeyResponse struct {
@@ -29,18 +46,70 @@ type ServiceAccountKeyResponse struct {
 	KeyAlgorithm   string `json:"key_algorithm"`
 	KeyType        string `json:"key_type"`
 	KeyID          string `json:"key_id"`
+	Lease
+}
+
+// Lease is the Vault lease of a generated secret, needed to renew or revoke
+// it later. Access tokens are not leased, so their lease_id is empty.
+type Lease struct {
+	LeaseID       string `json:"lease_id,omitempty"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// LeaseInfo returns the lease of the secret embedding it.
+func (l Lease) LeaseInfo() Lease { return l }
+
+func leaseFromSecret(secret *api.Secret) Lease {
+	return Lease{
+		LeaseID:       secret.LeaseID,
+		LeaseDuration: secret.LeaseDuration,
+		Renewable:     secret.Renewable,
+	}
+}
+
+type KeyRequest struct {
+	KeyAlgorithm string `json:"key_algorithm,omitempty" binding:"omitempty,oneof=KEY_ALG_RSA_1024 KEY_ALG_RSA_2048"`
+	KeyType      string `json:"key_type,omitempty" binding:"omitempty,oneof=TYPE_UNSPECIFIED TYPE_PKCS12_FILE TYPE_GOOGLE_CREDENTIALS_FILE"`
+	TTL          string `json:"ttl,omitempty"`
+	// WrapTTL returns the key response-wrapped for that long
+	WrapTTL      string `json:"wrap_ttl,omitempty"`
+}
+
+// data returns the request as Vault write parameters, omitting unset fields.
+func (r *KeyRequest) data() map[string]interface{} {
+	data := map[string]interface{}{}
+	if r.KeyAlgorithm != "" {
+		data["key_algorithm"] = r.KeyAlgorithm
+	}
+	if r.KeyType != "" {
+		data["key_type"] = r.KeyType
+	}
+	if r.TTL != "" {
+		data["ttl"] = r.TTL
+	}
+	return data
 }
 
 type RolesetRequest struct {
 	Project       string            `json:"project" binding:"required"`
 	SecretType    string            `json:"secret_type" binding:"required,oneof=access_token service_account_key"`
 	TokenScopes   string            `json:"token_scopes,omitempty"`
-	Bindings      map[string]interface{} `json:"bindings"`
+	Bindings      Bindings          `json:"bindings"`
 	TTL           string            `json:"ttl,omitempty"`
 	MaxTTL        string            `json:"max_ttl,omitempty"`
 }
 
-func NewClient(cfg *config.Config, logger *logrus.Logger) (*Client, error) {
+type RolesetResponse struct {
+	Name                string      `json:"name"`
+	Project             string      `json:"project"`
+	SecretType          string      `json:"secret_type"`
+	Bindings            interface{} `json:"bindings,omitempty"`
+	TokenScopes         []string    `json:"token_scopes,omitempty"`
+	ServiceAccountEmail string      `json:"service_account_email"`
+}
+
+func NewClient(cfg *config.Config, store cache.Cache, logger *logrus.Logger) (*Client, error) {
 	vaultCfg := api.DefaultConfig()
 	vaultCfg.Address = cfg.Vault.Address
 
@@ -58,49 +127,164 @@ func NewClient(cfg *config.Config, logger *logrus.Logger) (*Client, error) {
 		return nil, fmt.Errorf("failed to create vault client: %w", err)
 	}
 
-	// Set token
-	client.SetToken(cfg.Vault.Token)
+	// Token is set by Login using the configured auth methods
+	client.ClearToken()
 
 	// Set namespace if provided
 	if cfg.Vault.Namespace != "" {
 		client.SetNamespace(cfg.Vault.Namespace)
 	}
 
-	return &Client{
-		client: client,
-		config: cfg,
-		logger: logger,
-	}, nil
+	root := &Client{
+		client:    client,
+		config:    cfg,
+		logger:    logger,
+		cache:     store,
+		accessors: &accessorAudit{},
+		mount:     strings.Trim(cfg.GCP.MountPath, "/"),
+		engine: config.GCPMountConfig{
+			ServiceAccountPath: cfg.GCP.ServiceAccountPath,
+			DefaultTokenScopes: cfg.GCP.DefaultTokenScopes,
+			DefaultTTL:         cfg.GCP.DefaultTTL,
+			MaxTTL:             cfg.GCP.MaxTTL,
+		},
+		mounts: map[string]*Client{},
+	}
+	root.mounts[root.mount] = root
+
+	for path, mountCfg := range cfg.GCP.Mounts {
+		path = strings.Trim(path, "/")
+		if _, exists := root.mounts[path]; exists {
+			return nil, fmt.Errorf("GCP mount %q is configured twice", path)
+		}
+
+		mountClient := *root
+		mountClient.mount = path
+		mountClient.engine = mountEngine(root.engine, mountCfg)
+		root.mounts[path] = &mountClient
+	}
+
+	return root, nil
 }
 
+// mountEngine fills the unset settings of an additional mount from the
+// top-level ones.
+func mountEngine(defaults, mountCfg config.GCPMountConfig) config.GCPMountConfig {
+	if mountCfg.ServiceAccountPath == "" {
+		mountCfg.ServiceAccountPath = defaults.ServiceAccountPath
+	}
+	if mountCfg.DefaultTokenScopes == "" {
+		mountCfg.DefaultTokenScopes = defaults.DefaultTokenScopes
+	}
+	if mountCfg.DefaultTTL == "" {
+		mountCfg.DefaultTTL = defaults.DefaultTTL
+	}
+	if mountCfg.MaxTTL == "" {
+		mountCfg.MaxTTL = defaults.MaxTTL
+	}
+	return mountCfg
+}
+
+// Mount returns the client for a configured GCP mount. An empty path selects
+// the default mount.
+func (c *Client) Mount(path string) (*Client, bool) {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		path = strings.Trim(c.config.GCP.MountPath, "/")
+	}
+
+	mountClient, ok := c.mounts[path]
+	return mountClient, ok
+}
+
+// log returns the request-scoped log entry carried by ctx, so Vault calls
+// are logged with the correlation fields of the request that caused them.
+func (c *Client) log(ctx context.Context) *logrus.Entry {
+	return logging.FromContext(ctx, c.logger).WithField("mount", c.mount)
+}
+
+// MountPath returns the path of the mount the client operates on.
+func (c *Client) MountPath() string {
+	return c.mount
+}
+
+// MountPaths returns the paths of all configured GCP mounts.
+func (c *Client) MountPaths() []string {
+	paths := make([]string, 0, len(c.mounts))
+	for path := range c.mounts {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Initialize enables and configures every configured GCP mount.
 func (c *Client) Initialize(ctx context.Context) error {
-	c.logger.Info("Initializing Vault GCP secrets engine...")
+	for _, path := range c.MountPaths() {
+		if err := c.mounts[path].initializeMount(ctx); err != nil {
+			return fmt.Errorf("mount %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// enableEngine mounts a secrets engine of engineType at path unless
+// something is already mounted there.
+func (c *Client) enableEngine(ctx context.Context, path, engineType, description string) error {
+	exists, err := c.hasMount(ctx, path)
+	if err != nil || exists {
+		return err
+	}
 
-	// Check if GCP secrets engine is enabled
-	mounts, err := c.client.Sys().ListMounts()
+	return c.mountEngine(ctx, path, &api.MountInput{
+		Type:        engineType,
+		Description: description,
+	})
+}
+
+// mountEngine mounts the secrets engine described by input at path.
+func (c *Client) mountEngine(ctx context.Context, path string, input *api.MountInput) error {
+	c.log(ctx).WithField("engine", input.Type).Info("Enabling secrets engine...")
+	if err := c.client.Sys().MountWithContext(ctx, path, input); err != nil {
+		return fmt.Errorf("failed to enable %s secrets engine: %w", input.Type, err)
+	}
+	c.log(ctx).WithField("engine", input.Type).Info("Secrets engine enabled successfully")
+	return nil
+}
+
+// hasMount reports whether a secrets engine is mounted at path.
+func (c *Client) hasMount(ctx context.Context, path string) (bool, error) {
+	mounts, err := c.client.Sys().ListMountsWithContext(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to list mounts: %w", err)
+		return false, fmt.Errorf("failed to list mounts: %w", err)
 	}
 
-	gcpMountExists := false
-	for path := range mounts {
-		if strings.TrimSuffix(path, "/") == "gcp" {
-			gcpMountExists = true
-			break
+	for mountPath := range mounts {
+		if strings.TrimSuffix(mountPath, "/") == path {
+			return true, nil
 		}
 	}
+	return false, nil
+}
+
+// CheckMount returns an error unless a secrets engine is mounted at path.
+func (c *Client) CheckMount(ctx context.Context, path string) error {
+	exists, err := c.hasMount(ctx, path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("no secrets engine is mounted at %s", path)
+	}
+	return nil
+}
+
+func (c *Client) initializeMount(ctx context.Context) error {
+	c.log(ctx).WithField("mount", c.mount).Info("Initializing Vault GCP secrets engine...")
 
 	// Enable GCP secrets engine if not exists
-	if !gcpMountExists {
-		c.logger.Info("Enabling GCP secrets engine...")
-		err := c.client.Sys().Mount("gcp", &api.MountInput{
-			Type:        "gcp",
-			Description: "GCP secrets engine for managing access tokens and service account keys",
-		})
-		if err != nil {
-			return fmt.Errorf("failed to enable GCP secrets engine: %w", err)
-		}
-		c.logger.Info("GCP secrets engine enabled successfully")
+	if err := c.enableEngine(ctx, c.mount, "gcp", "GCP secrets engine for managing access tokens and service account keys"); err != nil {
+		return err
 	}
 
 	// Configure GCP secrets engine
@@ -108,40 +292,139 @@ func (c *Client) Initialize(ctx context.Context) error {
 		return fmt.Errorf("failed to configure GCP engine: %w", err)
 	}
 
-	c.logger.Info("Vault GCP secrets engine initialized successfully")
+	c.log(ctx).Info("Vault GCP secrets engine initialized successfully")
 	return nil
 }
 
 func (c *Client) configureGCPEngine(ctx context.Context) error {
-	c.logger.Info("Configuring GCP secrets engine...")
+	c.log(ctx).Info("Configuring GCP secrets engine...")
 
 	configData := map[string]interface{}{
-		"ttl":                         c.config.GCP.DefaultTTL,
-		"max_ttl":                     c.config.GCP.MaxTTL,
+		"ttl":                         c.engine.DefaultTTL,
+		"max_ttl":                     c.engine.MaxTTL,
 		"disable_automated_rotation":  c.config.GCP.DisableAutomatedRotation,
 	}
 
+	// Rotation schedules need a recent Vault, so only send them when set
+	if c.config.GCP.RotationSchedule != "" {
+		configData["rotation_schedule"] = c.config.GCP.RotationSchedule
+	}
+	if c.config.GCP.RotationWindow != "" {
+		configData["rotation_window"] = c.config.GCP.RotationWindow
+	}
+
 	// If service account path is provided, read and set credentials
-	if c.config.GCP.ServiceAccountPath != "" {
-		credentials, err := ioutil.ReadFile(c.config.GCP.ServiceAccountPath)
+	if c.engine.ServiceAccountPath != "" {
+		credentials, err := ioutil.ReadFile(c.engine.ServiceAccountPath)
 		if err != nil {
 			return fmt.Errorf("failed to read service account file: %w", err)
 		}
 		configData["credentials"] = string(credentials)
 	}
 
-	_, err := c.client.Logical().WriteWithContext(ctx, "gcp/config", configData)
+	_, err := c.client.Logical().WriteWithContext(ctx, c.mount+"/config", configData)
 	if err != nil {
 		return fmt.Errorf("failed to configure GCP engine: %w", err)
 	}
 
-	c.logger.Info("GCP secrets engine configured successfully")
+	c.log(ctx).Info("GCP secrets engine configured successfully")
 	return nil
 }
 
 func (c *Client) CreateRoleset(ctx context.Context, name string, req *RolesetRequest) error {
-	c.logger.WithField("roleset", name).Info("Creating GCP roleset...")
+	c.log(ctx).WithField("roleset", name).Info("Creating GCP roleset...")
+
+	data, err := c.rolesetData(req)
+	if err != nil {
+		return err
+	}
+
+	// Remember whether the roleset already existed so a failed write never
+	// rolls back someone else's roleset. If that cannot be told, nothing is
+	// written.
+	_, err = c.GetRoleset(ctx, name)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to check for existing roleset: %w", err)
+	}
+	existed := err == nil
+
+	_, err = c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roleset/%s", c.mount, name), data)
+	if err != nil {
+		createErr := &RolesetCreateError{Name: name, Err: err}
+		if !existed {
+			c.rollbackRoleset(ctx, createErr)
+		}
+		return createErr
+	}
+
+	c.log(ctx).WithField("roleset", name).Info("GCP roleset created successfully")
+	return nil
+}
+
+// encodeBindings renders bindings as the HCL Vault expects.
+func encodeBindings(bindings Bindings) (string, error) {
+	encoded, err := renderBindingsHCL(bindings)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode bindings: %w", err)
+	}
+	return encoded, nil
+}
+
+// stringSlice converts a list decoded from a Vault response into strings.
+func stringSlice(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
 
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// int64Value converts a number decoded from a Vault response. The Vault API
+// decodes numbers as json.Number.
+func int64Value(value interface{}) int64 {
+	switch v := value.(type) {
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			f, _ := v.Float64()
+			return int64(f)
+		}
+		return n
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	default:
+		return 0
+	}
+}
+
+// timeValue converts an RFC 3339 timestamp from a Vault response. Missing or
+// zero timestamps return nil.
+func timeValue(value interface{}) *time.Time {
+	s, ok := value.(string)
+	if !ok {
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil || t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// rolesetData converts a roleset request into Vault write parameters.
+func (c *Client) rolesetData(req *RolesetRequest) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"project":     req.Project,
 		"secret_type": req.SecretType,
@@ -150,11 +433,15 @@ func (c *Client) CreateRoleset(ctx context.Context, name string, req *RolesetReq
 	if req.TokenScopes != "" {
 		data["token_scopes"] = req.TokenScopes
 	} else if req.SecretType == "access_token" {
-		data["token_scopes"] = c.config.GCP.DefaultTokenScopes
+		data["token_scopes"] = c.engine.DefaultTokenScopes
 	}
 
-	if req.Bindings != nil && len(req.Bindings) > 0 {
-		data["bindings"] = req.Bindings
+	if len(req.Bindings) > 0 {
+		bindings, err := encodeBindings(req.Bindings)
+		if err != nil {
+			return nil, err
+		}
+		data["bindings"] = bindings
 	}
 
 	if req.TTL != "" {
@@ -165,17 +452,129 @@ func (c *Client) CreateRoleset(ctx context.Context, name string, req *RolesetReq
 		data["max_ttl"] = req.MaxTTL
 	}
 
-	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("gcp/roleset/%s", name), data)
+	return data, nil
+}
+
+// RolesetCreateError describes a failed roleset creation and what, if
+// anything, was left behind in Vault and GCP after cleanup.
+type RolesetCreateError struct {
+	Name       string
+	Err        error
+	RolledBack bool
+	Remaining  []string
+}
+
+func (e *RolesetCreateError) Error() string {
+	msg := fmt.Sprintf("failed to create roleset: %v", e.Err)
+	if e.RolledBack {
+		msg += "; partially created roleset was deleted"
+	}
+	if len(e.Remaining) > 0 {
+		msg += fmt.Sprintf("; remaining resources: %s", strings.Join(e.Remaining, ", "))
+	}
+	return msg
+}
+
+func (e *RolesetCreateError) Unwrap() error {
+	return e.Err
+}
+
+// rollbackRoleset deletes a roleset that Vault persisted even though the
+// creation request failed, and records whatever could not be cleaned up.
+func (c *Client) rollbackRoleset(ctx context.Context, createErr *RolesetCreateError) {
+	// The request context may already be done; cleanup gets its own deadline
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 15*time.Second)
+	defer cancel()
+
+	log := c.log(ctx).WithField("roleset", createErr.Name)
+
+	roleset, err := c.GetRoleset(ctx, createErr.Name)
+	if errors.Is(err, ErrNotFound) {
+		return
+	}
+	if err != nil {
+		log.WithError(err).Error("Failed to inspect partially created roleset")
+		createErr.Remaining = append(createErr.Remaining, fmt.Sprintf("roleset %s (state unknown)", createErr.Name))
+		return
+	}
+
+	log.Warn("Roleset creation failed after Vault persisted it, rolling back...")
+
+	if err := c.DeleteRoleset(ctx, createErr.Name); err != nil {
+		log.WithError(err).Error("Failed to roll back partially created roleset")
+		createErr.Remaining = append(createErr.Remaining, fmt.Sprintf("roleset %s", createErr.Name))
+		if roleset.ServiceAccountEmail != "" {
+			createErr.Remaining = append(createErr.Remaining, fmt.Sprintf("service account %s", roleset.ServiceAccountEmail))
+		}
+		return
+	}
+
+	createErr.RolledBack = true
+}
+
+func (c *Client) GetRoleset(ctx context.Context, name string) (*RolesetResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roleset/%s", c.mount, name))
 	if err != nil {
-		return fmt.Errorf("failed to create roleset: %w", err)
+		return nil, fmt.Errorf("failed to read roleset: %w", err)
 	}
 
-	c.logger.WithField("roleset", name).Info("GCP roleset created successfully")
-	return nil
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &RolesetResponse{
+		Name:     name,
+		Bindings: secret.Data["bindings"],
+	}
+	response.Project, _ = secret.Data["project"].(string)
+	response.SecretType, _ = secret.Data["secret_type"].(string)
+	response.ServiceAccountEmail, _ = secret.Data["service_account_email"].(string)
+	response.TokenScopes = stringSlice(secret.Data["token_scopes"])
+
+	return response, nil
+}
+
+// WaitForRoleset polls the roleset until it is readable and its service
+// account has been provisioned, or the context expires.
+func (c *Client) WaitForRoleset(ctx context.Context, name string, interval time.Duration) (*RolesetResponse, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		roleset, err := c.GetRoleset(ctx, name)
+		if err == nil && roleset.ServiceAccountEmail != "" {
+			return roleset, nil
+		}
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			c.log(ctx).WithError(err).WithField("roleset", name).Debug("Roleset not readable yet")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("roleset %s not ready: %w", name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
 }
 
 func (c *Client) GetToken(ctx context.Context, rolesetName string, ttl string) (*TokenResponse, error) {
-	c.logger.WithField("roleset", rolesetName).Info("Generating GCP access token...")
+	token, _, err := c.GetTokenWithMinTTL(ctx, rolesetName, ttl, 0)
+	return token, err
+}
+
+// GetTokenWithMinTTL returns an access token like GetToken, but only serves a
+// cached token if it remains valid for at least minTTL.
+func (c *Client) GetTokenWithMinTTL(ctx context.Context, rolesetName string, ttl string, minTTL time.Duration) (*TokenResponse, *TokenCacheInfo, error) {
+	cacheKey := c.tokenCacheKey("roleset", rolesetName, ttl)
+	if cached, cachedAt, ok := c.cachedToken(ctx, cacheKey); ok {
+		if time.Until(time.Unix(cached.ExpiresAtSeconds, 0)) >= minTTL {
+			c.log(ctx).WithField("roleset", rolesetName).Info("Serving GCP access token from cache")
+			return cached, &TokenCacheInfo{Hit: true, CachedAt: cachedAt}, nil
+		}
+		c.log(ctx).WithField("roleset", rolesetName).Info("Cached GCP access token expires too soon, refreshing")
+	}
+
+	c.log(ctx).WithField("roleset", rolesetName).Info("Generating GCP access token...")
 
 	var data map[string]interface{}
 	if ttl != "" {
@@ -188,86 +587,116 @@ func (c *Client) GetToken(ctx context.Context, rolesetName string, ttl string) (
 	var err error
 
 	if data != nil {
-		secret, err = c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("gcp/token/%s", rolesetName), data)
+		secret, err = c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/token/%s", c.mount, rolesetName), data)
 	} else {
-		secret, err = c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("gcp/token/%s", rolesetName))
+		secret, err = c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/token/%s", c.mount, rolesetName))
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
+		return nil, nil, c.rolesetError(ctx, rolesetName, fmt.Errorf("failed to get access token: %w", err))
 	}
 
+	response, err := tokenFromSecret(secret)
+	if err != nil {
+		return nil, nil, c.rolesetError(ctx, rolesetName, err)
+	}
+
+	c.cacheToken(ctx, cacheKey, response)
+
+	c.log(ctx).WithField("roleset", rolesetName).Info("GCP access token generated successfully")
+	return response, &TokenCacheInfo{}, nil
+}
+
+// rolesetError turns a failure to generate a secret for a roleset into
+// ErrNotFound if the roleset does not exist. Vault reports a missing roleset
+// inconsistently across versions and endpoints, so existence is checked.
+func (c *Client) rolesetError(ctx context.Context, rolesetName string, err error) error {
+	if _, lookupErr := c.GetRoleset(ctx, rolesetName); errors.Is(lookupErr, ErrNotFound) {
+		return fmt.Errorf("roleset %q: %w", rolesetName, ErrNotFound)
+	}
+	return err
+}
+
+// tokenFromSecret extracts an access token from a gcp token endpoint response.
+func tokenFromSecret(secret *api.Secret) (*TokenResponse, error) {
 	if secret == nil || secret.Data == nil {
 		return nil, fmt.Errorf("no token data returned")
 	}
 
-	response := &TokenResponse{
-		Token:            secret.Data["token"].(string),
-		TokenTTL:         secret.Data["token_ttl"].(string),
-		ExpiresAtSeconds: int64(secret.Data["expires_at_seconds"].(float64)),
+	token, ok := secret.Data["token"].(string)
+	if !ok || token == "" {
+		return nil, fmt.Errorf("no token data returned")
 	}
 
-	c.logger.WithField("roleset", rolesetName).Info("GCP access token generated successfully")
+	response := &TokenResponse{Token: token, Lease: leaseFromSecret(secret)}
+	response.TokenTTL, _ = secret.Data["token_ttl"].(string)
+	response.ExpiresAtSeconds = int64Value(secret.Data["expires_at_seconds"])
+
 	return response, nil
 }
 
-func (c *Client) GetServiceAccountKey(ctx context.Context, rolesetName string) (*ServiceAccountKeyResponse, error) {
-	c.logger.WithField("roleset", rolesetName).Info("Generating GCP service account key...")
+// GetServiceAccountKey generates a key for a service_account_key roleset,
+// passing the key options of req to Vault.
+func (c *Client) GetServiceAccountKey(ctx context.Context, rolesetName string, req *KeyRequest) (*ServiceAccountKeyResponse, error) {
+	c.log(ctx).WithField("roleset", rolesetName).Info("Generating GCP service account key...")
 
-	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("gcp/key/%s", rolesetName))
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/key/%s", c.mount, rolesetName), req.data())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get service account key: %w", err)
+		return nil, c.rolesetError(ctx, rolesetName, fmt.Errorf("failed to get service account key: %w", err))
 	}
 
+	response, err := keyFromSecret(secret)
+	if err != nil {
+		return nil, c.rolesetError(ctx, rolesetName, err)
+	}
+
+	c.log(ctx).WithField("roleset", rolesetName).Info("GCP service account key generated successfully")
+	return response, nil
+}
+
+// keyFromSecret extracts a service account key from a gcp key endpoint response.
+func keyFromSecret(secret *api.Secret) (*ServiceAccountKeyResponse, error) {
 	if secret == nil || secret.Data == nil {
 		return nil, fmt.Errorf("no key data returned")
 	}
 
-	response := &ServiceAccountKeyResponse{
-		PrivateKeyData: secret.Data["private_key_data"].(string),
-		KeyAlgorithm:   secret.Data["key_algorithm"].(string),
-		KeyType:        secret.Data["key_type"].(string),
-		KeyID:          secret.Data["key_id"].(string),
+	privateKey, ok := secret.Data["private_key_data"].(string)
+	if !ok || privateKey == "" {
+		return nil, fmt.Errorf("no key data returned")
 	}
 
-	c.logger.WithField("roleset", rolesetName).Info("GCP service account key generated successfully")
+	response := &ServiceAccountKeyResponse{PrivateKeyData: privateKey, Lease: leaseFromSecret(secret)}
+	response.KeyAlgorithm, _ = secret.Data["key_algorithm"].(string)
+	response.KeyType, _ = secret.Data["key_type"].(string)
+	response.KeyID, _ = secret.Data["key_id"].(string)
+
 	return response, nil
 }
 
 func (c *Client) ListRolesets(ctx context.Context) ([]string, error) {
-	c.logger.Info("Listing GCP rolesets...")
+	c.log(ctx).Info("Listing GCP rolesets...")
 
-	secret, err := c.client.Logical().ListWithContext(ctx, "gcp/roleset")
+	rolesets := []string{}
+	err := c.WalkRolesets(ctx, "", func(name string) bool {
+		rolesets = append(rolesets, name)
+		return true
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list rolesets: %w", err)
-	}
-
-	if secret == nil || secret.Data == nil {
-		return []string{}, nil
-	}
-
-	keys, ok := secret.Data["keys"].([]interface{})
-	if !ok {
-		return []string{}, nil
-	}
-
-	rolesets := make([]string, len(keys))
-	for i, key := range keys {
-		rolesets[i] = key.(string)
+		return nil, err
 	}
 
 	return rolesets, nil
 }
 
 func (c *Client) DeleteRoleset(ctx context.Context, name string) error {
-	c.logger.WithField("roleset", name).Info("Deleting GCP roleset...")
+	c.log(ctx).WithField("roleset", name).Info("Deleting GCP roleset...")
 
-	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("gcp/roleset/%s", name))
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/roleset/%s", c.mount, name))
 	if err != nil {
 		return fmt.Errorf("failed to delete roleset: %w", err)
 	}
 
-	c.logger.WithField("roleset", name).Info("GCP roleset deleted successfully")
+	c.log(ctx).WithField("roleset", name).Info("GCP roleset deleted successfully")
 	return nil
 }
 
@@ -275,6 +704,13 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
+	healthTTL := c.config.Cache.HealthTTL
+	if healthTTL > 0 {
+		if _, ok, err := c.cache.Get(ctx, healthCacheKey); err == nil && ok {
+			return nil
+		}
+	}
+
 	health, err := c.client.Sys().HealthWithContext(ctx)
 	if err != nil {
 		return fmt.Errorf("vault health check failed: %w", err)
@@ -284,5 +720,11 @@ func (c *Client) HealthCheck(ctx context.Context) error {
 		return fmt.Errorf("vault is not ready: initialized=%v, sealed=%v", health.Initialized, health.Sealed)
 	}
 
+	if healthTTL > 0 {
+		if err := c.cache.Set(ctx, healthCacheKey, []byte("ok"), healthTTL); err != nil {
+			c.log(ctx).WithError(err).Warn("Failed to cache health check result")
+		}
+	}
+
 	return nil
 }
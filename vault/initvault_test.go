@@ -0,0 +1,133 @@
+package vault
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeVault answers roleset reads and writes with the given status codes
+// and records every request it sees.
+type fakeVault struct {
+	readStatus  int
+	writeStatus int
+
+	mu       sync.Mutex
+	requests []string
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		w.WriteHeader(f.readStatus)
+		if f.readStatus == http.StatusOK {
+			io.WriteString(w, `{"data":{"project":"my-project","secret_type":"access_token","service_account_email":"sa@my-project.iam.gserviceaccount.com"}}`)
+			return
+		}
+	case http.MethodPut, http.MethodPost:
+		w.WriteHeader(f.writeStatus)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	io.WriteString(w, `{"errors":["internal error"]}`)
+}
+
+func (f *fakeVault) saw(request string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, seen := range f.requests {
+		if seen == request {
+			return true
+		}
+	}
+	return false
+}
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := api.DefaultConfig()
+	cfg.Address = server.URL
+	cfg.MaxRetries = 0
+	client, err := api.NewClient(cfg)
+	if err != nil {
+		t.Fatalf("failed to create vault client: %v", err)
+	}
+	client.SetToken("test-token")
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return &Client{client: client, logger: logger, mount: "gcp"}
+}
+
+func TestCreateRolesetKeepsRolesetWhenExistenceCheckFails(t *testing.T) {
+	vault := &fakeVault{readStatus: http.StatusServiceUnavailable, writeStatus: http.StatusInternalServerError}
+	c := newTestClient(t, vault)
+
+	err := c.CreateRoleset(context.Background(), "existing", &RolesetRequest{Project: "my-project", SecretType: "service_account_key"})
+	if err == nil {
+		t.Fatal("expected an error when the existence check fails")
+	}
+	if vault.saw(http.MethodDelete + " /v1/gcp/roleset/existing") {
+		t.Fatal("roleset was deleted although it may have existed")
+	}
+	if vault.saw(http.MethodPut+" /v1/gcp/roleset/existing") || vault.saw(http.MethodPost+" /v1/gcp/roleset/existing") {
+		t.Fatal("roleset was written although its existence could not be checked")
+	}
+}
+
+func TestCreateRolesetKeepsExistingRolesetWhenWriteFails(t *testing.T) {
+	vault := &fakeVault{readStatus: http.StatusOK, writeStatus: http.StatusInternalServerError}
+	c := newTestClient(t, vault)
+
+	err := c.CreateRoleset(context.Background(), "existing", &RolesetRequest{Project: "my-project", SecretType: "service_account_key"})
+	if err == nil {
+		t.Fatal("expected an error when the write fails")
+	}
+	if vault.saw(http.MethodDelete + " /v1/gcp/roleset/existing") {
+		t.Fatal("existing roleset was rolled back")
+	}
+}
+
+func TestCreateRolesetRollsBackNewRolesetWhenWriteFails(t *testing.T) {
+	var mu sync.Mutex
+	written := false
+	vault := &fakeVault{writeStatus: http.StatusInternalServerError}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Not found before the write, persisted after it
+		mu.Lock()
+		if r.Method == http.MethodPut || r.Method == http.MethodPost {
+			written = true
+		}
+		vault.readStatus = http.StatusNotFound
+		if written {
+			vault.readStatus = http.StatusOK
+		}
+		mu.Unlock()
+		vault.ServeHTTP(w, r)
+	})
+	c := newTestClient(t, handler)
+
+	err := c.CreateRoleset(context.Background(), "new", &RolesetRequest{Project: "my-project", SecretType: "service_account_key"})
+	if err == nil {
+		t.Fatal("expected an error when the write fails")
+	}
+	if !vault.saw(http.MethodDelete + " /v1/gcp/roleset/new") {
+		t.Fatal("partially created roleset was not rolled back")
+	}
+}
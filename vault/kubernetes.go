@@ -0,0 +1,113 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// KubernetesTokenRequest holds the parameters of a service account token
+// request. Namespace must be allowed by the role.
+type KubernetesTokenRequest struct {
+	Namespace          string   `json:"kubernetes_namespace" binding:"required"`
+	ClusterRoleBinding bool     `json:"cluster_role_binding"`
+	TTL                string   `json:"ttl,omitempty"`
+	Audiences          []string `json:"audiences,omitempty"`
+}
+
+type KubernetesTokenResponse struct {
+	ServiceAccountName      string `json:"service_account_name"`
+	ServiceAccountNamespace string `json:"service_account_namespace"`
+	ServiceAccountToken     string `json:"service_account_token"`
+	Lease
+}
+
+// InitializeKubernetes enables the Kubernetes secrets engine and configures
+// its access to the cluster. Without a host, Vault uses the service account
+// of the pod it runs in.
+func (c *Client) InitializeKubernetes(ctx context.Context) error {
+	mount := c.config.Kubernetes.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault Kubernetes secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "kubernetes", "Kubernetes secrets engine for issuing service account tokens"); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{}
+	if c.config.Kubernetes.Host != "" {
+		data["kubernetes_host"] = c.config.Kubernetes.Host
+	}
+	if c.config.Kubernetes.CACert != "" {
+		data["kubernetes_ca_cert"] = c.config.Kubernetes.CACert
+	}
+	if c.config.Kubernetes.ServiceAccountJWT != "" {
+		data["service_account_jwt"] = c.config.Kubernetes.ServiceAccountJWT
+	}
+	if _, err := c.client.Logical().WriteWithContext(ctx, mount+"/config", data); err != nil {
+		return fmt.Errorf("failed to configure Kubernetes engine: %w", err)
+	}
+
+	c.log(ctx).Info("Vault Kubernetes secrets engine initialized successfully")
+	return nil
+}
+
+// GetKubernetesToken creates a service account token for the role. Depending
+// on the role, Vault also creates the service account and its role binding,
+// which are deleted with the lease.
+func (c *Client) GetKubernetesToken(ctx context.Context, role string, req *KubernetesTokenRequest) (*KubernetesTokenResponse, error) {
+	c.log(ctx).WithFields(logrus.Fields{
+		"kubernetes_role": role,
+		"namespace":       req.Namespace,
+	}).Info("Generating Kubernetes service account token...")
+
+	data := map[string]interface{}{
+		"kubernetes_namespace": req.Namespace,
+		"cluster_role_binding": req.ClusterRoleBinding,
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+	if len(req.Audiences) > 0 {
+		data["audiences"] = req.Audiences
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/creds/%s", c.config.Kubernetes.MountPath, role), data)
+	if err != nil || secret == nil {
+		if _, lookupErr := c.getKubernetesRole(ctx, role); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("Kubernetes role %q: %w", role, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Kubernetes token: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no token data returned")
+	}
+
+	response := &KubernetesTokenResponse{Lease: leaseFromSecret(secret)}
+	response.ServiceAccountName, _ = secret.Data["service_account_name"].(string)
+	response.ServiceAccountNamespace, _ = secret.Data["service_account_namespace"].(string)
+	response.ServiceAccountToken, _ = secret.Data["service_account_token"].(string)
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"kubernetes_role": role,
+		"service_account": response.ServiceAccountName,
+	}).Info("Kubernetes service account token generated successfully")
+	return response, nil
+}
+
+// getKubernetesRole reads a Kubernetes role's raw settings.
+func (c *Client) getKubernetesRole(ctx context.Context, name string) (map[string]interface{}, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.Kubernetes.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Kubernetes role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+	return secret.Data, nil
+}
@@ -0,0 +1,296 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrCheckAndSet is returned when a KV write's cas does not match the
+// secret's current version.
+var ErrCheckAndSet = errors.New("check-and-set version does not match the current version")
+
+// ErrKVVersioning is returned for versioning operations on a KV v1 mount.
+var ErrKVVersioning = errors.New("KV v1 mounts do not keep versions or metadata")
+
+// KVSecretResponse is one version of a KV v2 secret. Data is nil when the
+// version has been deleted or destroyed. KV v1 secrets have no version.
+type KVSecretResponse struct {
+	Path           string                 `json:"path"`
+	Data           map[string]interface{} `json:"data"`
+	CustomMetadata map[string]interface{} `json:"custom_metadata,omitempty"`
+	*KVVersion
+}
+
+type KVVersion struct {
+	Version      int        `json:"version"`
+	CreatedTime  time.Time  `json:"created_time"`
+	DeletionTime *time.Time `json:"deletion_time,omitempty"`
+	Destroyed    bool       `json:"destroyed"`
+}
+
+// KVMetadataResponse is the metadata of a KV v2 secret across all of its
+// versions, oldest version first.
+type KVMetadataResponse struct {
+	Path           string                 `json:"path"`
+	CurrentVersion int                    `json:"current_version"`
+	OldestVersion  int                    `json:"oldest_version"`
+	MaxVersions    int                    `json:"max_versions"`
+	CASRequired    bool                   `json:"cas_required"`
+	CreatedTime    time.Time              `json:"created_time"`
+	UpdatedTime    time.Time              `json:"updated_time"`
+	CustomMetadata map[string]interface{} `json:"custom_metadata,omitempty"`
+	Versions       []KVVersion            `json:"versions"`
+}
+
+type KVWriteRequest struct {
+	Data map[string]interface{} `json:"data" binding:"required"`
+	// CAS is the version the write expects to replace; 0 only creates
+	CAS *int `json:"cas,omitempty"`
+}
+
+func (c *Client) kvMount() string {
+	return c.config.KV.MountPath
+}
+
+// kvV1 reports whether the KV mount is a KV v1 mount.
+func (c *Client) kvV1() bool {
+	return c.config.KV.Version == 1
+}
+
+// ReadKV reads a KV secret. A version of 0 reads the latest version.
+func (c *Client) ReadKV(ctx context.Context, path string, version int) (*KVSecretResponse, error) {
+	if c.kvV1() {
+		if version > 0 {
+			return nil, ErrKVVersioning
+		}
+		return c.readKVv1(ctx, path)
+	}
+
+	kv := c.client.KVv2(c.kvMount())
+
+	var (
+		secret *api.KVSecret
+		err    error
+	)
+	if version > 0 {
+		secret, err = kv.GetVersion(ctx, path, version)
+	} else {
+		secret, err = kv.Get(ctx, path)
+	}
+	if errors.Is(err, api.ErrSecretNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KV secret: %w", err)
+	}
+
+	response := &KVSecretResponse{
+		Path:           path,
+		Data:           secret.Data,
+		CustomMetadata: secret.CustomMetadata,
+	}
+	if secret.VersionMetadata != nil {
+		version := kvVersion(*secret.VersionMetadata)
+		response.KVVersion = &version
+	}
+
+	return response, nil
+}
+
+func (c *Client) readKVv1(ctx context.Context, path string) (*KVSecretResponse, error) {
+	secret, err := c.client.KVv1(c.kvMount()).Get(ctx, path)
+	if errors.Is(err, api.ErrSecretNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KV secret: %w", err)
+	}
+
+	return &KVSecretResponse{Path: path, Data: secret.Data}, nil
+}
+
+// WriteKV writes a new version of a KV secret and returns its version
+// metadata. On a KV v1 mount the secret is overwritten and no metadata is
+// returned.
+func (c *Client) WriteKV(ctx context.Context, path string, req *KVWriteRequest) (*KVVersion, error) {
+	c.log(ctx).WithField("path", path).Info("Writing KV secret...")
+
+	if c.kvV1() {
+		if req.CAS != nil {
+			return nil, ErrKVVersioning
+		}
+		if err := c.client.KVv1(c.kvMount()).Put(ctx, path, req.Data); err != nil {
+			return nil, fmt.Errorf("failed to write KV secret: %w", err)
+		}
+
+		c.log(ctx).WithField("path", path).Info("KV secret written successfully")
+		return nil, nil
+	}
+
+	var opts []api.KVOption
+	if req.CAS != nil {
+		opts = append(opts, api.WithCheckAndSet(*req.CAS))
+	}
+
+	secret, err := c.client.KVv2(c.kvMount()).Put(ctx, path, req.Data, opts...)
+	if isCheckAndSetError(err) {
+		return nil, ErrCheckAndSet
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write KV secret: %w", err)
+	}
+
+	c.log(ctx).WithField("path", path).Info("KV secret written successfully")
+
+	version := &KVVersion{}
+	if secret.VersionMetadata != nil {
+		*version = kvVersion(*secret.VersionMetadata)
+	}
+	return version, nil
+}
+
+// DeleteKV soft-deletes versions of a KV secret, or its latest version when
+// none are given. Deleted versions can be restored with UndeleteKV. On a KV
+// v1 mount the secret is deleted permanently.
+func (c *Client) DeleteKV(ctx context.Context, path string, versions []int) error {
+	if c.kvV1() && len(versions) > 0 {
+		return ErrKVVersioning
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"path":     path,
+		"versions": versions,
+	}).Info("Deleting KV secret...")
+
+	var err error
+	switch {
+	case c.kvV1():
+		err = c.client.KVv1(c.kvMount()).Delete(ctx, path)
+	case len(versions) > 0:
+		err = c.client.KVv2(c.kvMount()).DeleteVersions(ctx, path, versions)
+	default:
+		err = c.client.KVv2(c.kvMount()).Delete(ctx, path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete KV secret: %w", err)
+	}
+
+	c.log(ctx).WithField("path", path).Info("KV secret deleted successfully")
+	return nil
+}
+
+// UndeleteKV restores soft-deleted versions of a KV secret.
+func (c *Client) UndeleteKV(ctx context.Context, path string, versions []int) error {
+	if c.kvV1() {
+		return ErrKVVersioning
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"path":     path,
+		"versions": versions,
+	}).Info("Undeleting KV secret...")
+
+	if err := c.client.KVv2(c.kvMount()).Undelete(ctx, path, versions); err != nil {
+		return fmt.Errorf("failed to undelete KV secret: %w", err)
+	}
+
+	c.log(ctx).WithField("path", path).Info("KV secret undeleted successfully")
+	return nil
+}
+
+// GetKVMetadata reads the metadata of a KV secret, including every version.
+func (c *Client) GetKVMetadata(ctx context.Context, path string) (*KVMetadataResponse, error) {
+	if c.kvV1() {
+		return nil, ErrKVVersioning
+	}
+
+	metadata, err := c.client.KVv2(c.kvMount()).GetMetadata(ctx, path)
+	if errors.Is(err, api.ErrSecretNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KV metadata: %w", err)
+	}
+
+	response := &KVMetadataResponse{
+		Path:           path,
+		CurrentVersion: metadata.CurrentVersion,
+		OldestVersion:  metadata.OldestVersion,
+		MaxVersions:    metadata.MaxVersions,
+		CASRequired:    metadata.CASRequired,
+		CreatedTime:    metadata.CreatedTime,
+		UpdatedTime:    metadata.UpdatedTime,
+		CustomMetadata: metadata.CustomMetadata,
+		Versions:       make([]KVVersion, 0, len(metadata.Versions)),
+	}
+	for _, version := range metadata.Versions {
+		response.Versions = append(response.Versions, kvVersion(version))
+	}
+	sort.Slice(response.Versions, func(i, j int) bool {
+		return response.Versions[i].Version < response.Versions[j].Version
+	})
+
+	return response, nil
+}
+
+// ListKV lists the keys under a KV folder. Folders end in "/".
+func (c *Client) ListKV(ctx context.Context, path string) ([]string, error) {
+	listPath := fmt.Sprintf("%s/metadata/%s", c.kvMount(), path)
+	if c.kvV1() {
+		listPath = fmt.Sprintf("%s/%s", c.kvMount(), path)
+	}
+
+	secret, err := c.client.Logical().ListWithContext(ctx, listPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list KV secrets: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	keysRaw, _ := secret.Data["keys"].([]interface{})
+	keys := make([]string, 0, len(keysRaw))
+	for _, key := range keysRaw {
+		if keyStr, ok := key.(string); ok {
+			keys = append(keys, keyStr)
+		}
+	}
+
+	return keys, nil
+}
+
+func kvVersion(metadata api.KVVersionMetadata) KVVersion {
+	version := KVVersion{
+		Version:     metadata.Version,
+		CreatedTime: metadata.CreatedTime,
+		Destroyed:   metadata.Destroyed,
+	}
+	if !metadata.DeletionTime.IsZero() {
+		deletionTime := metadata.DeletionTime
+		version.DeletionTime = &deletionTime
+	}
+	return version
+}
+
+func isCheckAndSetError(err error) bool {
+	var respErr *api.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	for _, message := range respErr.Errors {
+		if strings.Contains(message, "check-and-set") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LDAPRoleRequest defines a dynamic LDAP role. The LDIF templates create and
+// delete the user entry; see Vault's LDAP secrets engine for their fields.
+type LDAPRoleRequest struct {
+	CreationLDIF     string `json:"creation_ldif" binding:"required"`
+	DeletionLDIF     string `json:"deletion_ldif" binding:"required"`
+	RollbackLDIF     string `json:"rollback_ldif,omitempty"`
+	UsernameTemplate string `json:"username_template,omitempty"`
+	DefaultTTL       string `json:"default_ttl,omitempty"`
+	MaxTTL           string `json:"max_ttl,omitempty"`
+}
+
+type LDAPRoleResponse struct {
+	Name             string `json:"name"`
+	CreationLDIF     string `json:"creation_ldif"`
+	DeletionLDIF     string `json:"deletion_ldif"`
+	RollbackLDIF     string `json:"rollback_ldif,omitempty"`
+	UsernameTemplate string `json:"username_template,omitempty"`
+	DefaultTTL       int64  `json:"default_ttl"`
+	MaxTTL           int64  `json:"max_ttl"`
+}
+
+type LDAPCredentialsResponse struct {
+	Username           string   `json:"username"`
+	Password           string   `json:"password"`
+	DistinguishedNames []string `json:"distinguished_names,omitempty"`
+	Lease
+}
+
+// LDAPStaticRoleRequest maps a static role to an existing LDAP account
+// whose password Vault rotates every rotation period.
+type LDAPStaticRoleRequest struct {
+	Username       string `json:"username" binding:"required"`
+	DN             string `json:"dn,omitempty"`
+	RotationPeriod string `json:"rotation_period" binding:"required"`
+}
+
+type LDAPStaticRoleResponse struct {
+	Name              string     `json:"name"`
+	Username          string     `json:"username"`
+	DN                string     `json:"dn,omitempty"`
+	RotationPeriod    int64      `json:"rotation_period"`
+	LastVaultRotation *time.Time `json:"last_vault_rotation,omitempty"`
+}
+
+type LDAPStaticCredentialsResponse struct {
+	Username          string     `json:"username"`
+	DN                string     `json:"dn,omitempty"`
+	Password          string     `json:"password"`
+	LastPassword      string     `json:"last_password,omitempty"`
+	LastVaultRotation *time.Time `json:"last_vault_rotation,omitempty"`
+	RotationPeriod    int64      `json:"rotation_period"`
+	// TTL is the number of seconds until the next rotation
+	TTL int64 `json:"ttl"`
+}
+
+// InitializeLDAP enables the LDAP secrets engine and configures its
+// connection to the directory.
+func (c *Client) InitializeLDAP(ctx context.Context) error {
+	mount := c.config.LDAP.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault LDAP secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "ldap", "LDAP secrets engine for brokering directory credentials"); err != nil {
+		return err
+	}
+
+	data := map[string]interface{}{
+		"url":          c.config.LDAP.URL,
+		"binddn":       c.config.LDAP.BindDN,
+		"bindpass":     c.config.LDAP.BindPassword,
+		"schema":       c.config.LDAP.Schema,
+		"insecure_tls": c.config.LDAP.InsecureTLS,
+	}
+	if c.config.LDAP.UserDN != "" {
+		data["userdn"] = c.config.LDAP.UserDN
+	}
+	if _, err := c.client.Logical().WriteWithContext(ctx, mount+"/config", data); err != nil {
+		return fmt.Errorf("failed to configure LDAP engine: %w", err)
+	}
+
+	c.log(ctx).Info("Vault LDAP secrets engine initialized successfully")
+	return nil
+}
+
+// WriteLDAPRole creates a dynamic LDAP role or updates an existing one.
+func (c *Client) WriteLDAPRole(ctx context.Context, name string, req *LDAPRoleRequest) error {
+	c.log(ctx).WithField("ldap_role", name).Info("Writing LDAP role...")
+
+	data := map[string]interface{}{
+		"creation_ldif": req.CreationLDIF,
+		"deletion_ldif": req.DeletionLDIF,
+	}
+	if req.RollbackLDIF != "" {
+		data["rollback_ldif"] = req.RollbackLDIF
+	}
+	if req.UsernameTemplate != "" {
+		data["username_template"] = req.UsernameTemplate
+	}
+	if req.DefaultTTL != "" {
+		data["default_ttl"] = req.DefaultTTL
+	}
+	if req.MaxTTL != "" {
+		data["max_ttl"] = req.MaxTTL
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.LDAP.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write LDAP role: %w", err)
+	}
+
+	c.log(ctx).WithField("ldap_role", name).Info("LDAP role written successfully")
+	return nil
+}
+
+func (c *Client) GetLDAPRole(ctx context.Context, name string) (*LDAPRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.LDAP.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LDAP role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &LDAPRoleResponse{
+		Name:       name,
+		DefaultTTL: int64Value(secret.Data["default_ttl"]),
+		MaxTTL:     int64Value(secret.Data["max_ttl"]),
+	}
+	response.CreationLDIF, _ = secret.Data["creation_ldif"].(string)
+	response.DeletionLDIF, _ = secret.Data["deletion_ldif"].(string)
+	response.RollbackLDIF, _ = secret.Data["rollback_ldif"].(string)
+	response.UsernameTemplate, _ = secret.Data["username_template"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListLDAPRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing LDAP roles...")
+	return c.listLDAP(ctx, "role")
+}
+
+func (c *Client) DeleteLDAPRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("ldap_role", name).Info("Deleting LDAP role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.LDAP.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete LDAP role: %w", err)
+	}
+
+	c.log(ctx).WithField("ldap_role", name).Info("LDAP role deleted successfully")
+	return nil
+}
+
+// GetLDAPCredentials creates an LDAP user for the dynamic role. The user is
+// deleted when its lease expires or is revoked.
+func (c *Client) GetLDAPCredentials(ctx context.Context, name string) (*LDAPCredentialsResponse, error) {
+	c.log(ctx).WithField("ldap_role", name).Info("Generating LDAP credentials...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/creds/%s", c.config.LDAP.MountPath, name))
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetLDAPRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("LDAP role %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LDAP credentials: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no credential data returned")
+	}
+
+	response := &LDAPCredentialsResponse{
+		Lease:              leaseFromSecret(secret),
+		DistinguishedNames: stringSlice(secret.Data["distinguished_names"]),
+	}
+	response.Username, _ = secret.Data["username"].(string)
+	response.Password, _ = secret.Data["password"].(string)
+
+	c.log(ctx).WithField("ldap_role", name).Info("LDAP credentials generated successfully")
+	return response, nil
+}
+
+// WriteLDAPStaticRole creates a static LDAP role or updates an existing one.
+// Vault rotates the account's password when the role is created.
+func (c *Client) WriteLDAPStaticRole(ctx context.Context, name string, req *LDAPStaticRoleRequest) error {
+	c.log(ctx).WithField("ldap_static_role", name).Info("Writing LDAP static role...")
+
+	data := map[string]interface{}{
+		"username":        req.Username,
+		"rotation_period": req.RotationPeriod,
+	}
+	if req.DN != "" {
+		data["dn"] = req.DN
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/static-role/%s", c.config.LDAP.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write LDAP static role: %w", err)
+	}
+
+	c.log(ctx).WithField("ldap_static_role", name).Info("LDAP static role written successfully")
+	return nil
+}
+
+func (c *Client) GetLDAPStaticRole(ctx context.Context, name string) (*LDAPStaticRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/static-role/%s", c.config.LDAP.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LDAP static role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &LDAPStaticRoleResponse{
+		Name:              name,
+		RotationPeriod:    int64Value(secret.Data["rotation_period"]),
+		LastVaultRotation: timeValue(secret.Data["last_vault_rotation"]),
+	}
+	response.Username, _ = secret.Data["username"].(string)
+	response.DN, _ = secret.Data["dn"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListLDAPStaticRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing LDAP static roles...")
+	return c.listLDAP(ctx, "static-role")
+}
+
+// DeleteLDAPStaticRole deletes a static role. The account is left in the
+// directory with its current password.
+func (c *Client) DeleteLDAPStaticRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("ldap_static_role", name).Info("Deleting LDAP static role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/static-role/%s", c.config.LDAP.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete LDAP static role: %w", err)
+	}
+
+	c.log(ctx).WithField("ldap_static_role", name).Info("LDAP static role deleted successfully")
+	return nil
+}
+
+// GetLDAPStaticCredentials returns the current password of a static role's
+// account.
+func (c *Client) GetLDAPStaticCredentials(ctx context.Context, name string) (*LDAPStaticCredentialsResponse, error) {
+	c.log(ctx).WithField("ldap_static_role", name).Info("Reading LDAP static credentials...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/static-cred/%s", c.config.LDAP.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LDAP static credentials: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("LDAP static role %q: %w", name, ErrNotFound)
+	}
+
+	response := &LDAPStaticCredentialsResponse{
+		LastVaultRotation: timeValue(secret.Data["last_vault_rotation"]),
+		RotationPeriod:    int64Value(secret.Data["rotation_period"]),
+		TTL:               int64Value(secret.Data["ttl"]),
+	}
+	response.Username, _ = secret.Data["username"].(string)
+	response.DN, _ = secret.Data["dn"].(string)
+	response.Password, _ = secret.Data["password"].(string)
+	response.LastPassword, _ = secret.Data["last_password"].(string)
+
+	c.log(ctx).WithField("ldap_static_role", name).Info("LDAP static credentials read successfully")
+	return response, nil
+}
+
+// RotateLDAPStaticRole rotates a static role's password right away.
+func (c *Client) RotateLDAPStaticRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("ldap_static_role", name).Info("Rotating LDAP static role...")
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/rotate-role/%s", c.config.LDAP.MountPath, name), nil)
+	if err != nil {
+		if _, lookupErr := c.GetLDAPStaticRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return fmt.Errorf("LDAP static role %q: %w", name, ErrNotFound)
+		}
+		return fmt.Errorf("failed to rotate LDAP static role: %w", err)
+	}
+
+	c.log(ctx).WithField("ldap_static_role", name).Info("LDAP static role rotated successfully")
+	return nil
+}
+
+func (c *Client) listLDAP(ctx context.Context, path string) ([]string, error) {
+	secret, err := c.client.Logical().ListWithContext(ctx, fmt.Sprintf("%s/%s", c.config.LDAP.MountPath, path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LDAP %ss: %w", path, err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
@@ -0,0 +1,241 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrNoAccountsAvailable is returned when every service account of a library
+// set is checked out.
+var ErrNoAccountsAvailable = errors.New("no service accounts available for check-out")
+
+// LDAPLibraryRequest defines a library set: a pool of existing service
+// accounts that are lent out one at a time.
+type LDAPLibraryRequest struct {
+	ServiceAccountNames []string `json:"service_account_names" binding:"required,min=1"`
+	TTL                 string   `json:"ttl,omitempty"`
+	MaxTTL              string   `json:"max_ttl,omitempty"`
+	// DisableCheckInEnforcement lets any caller check accounts in, not only
+	// the one that checked them out
+	DisableCheckInEnforcement bool `json:"disable_check_in_enforcement"`
+}
+
+type LDAPLibraryResponse struct {
+	Name                      string   `json:"name"`
+	ServiceAccountNames       []string `json:"service_account_names"`
+	TTL                       int64    `json:"ttl"`
+	MaxTTL                    int64    `json:"max_ttl"`
+	DisableCheckInEnforcement bool     `json:"disable_check_in_enforcement"`
+}
+
+type LDAPCheckOutRequest struct {
+	TTL string `json:"ttl,omitempty"`
+}
+
+type LDAPCheckOutResponse struct {
+	ServiceAccountName string `json:"service_account_name"`
+	Password           string `json:"password"`
+	Lease
+}
+
+type LDAPAccountStatus struct {
+	ServiceAccountName string `json:"service_account_name"`
+	Available          bool   `json:"available"`
+	BorrowerEntityID   string `json:"borrower_entity_id,omitempty"`
+}
+
+// WriteLDAPLibrary creates a library set or updates an existing one.
+func (c *Client) WriteLDAPLibrary(ctx context.Context, name string, req *LDAPLibraryRequest) error {
+	c.log(ctx).WithField("ldap_library", name).Info("Writing LDAP library set...")
+
+	data := map[string]interface{}{
+		"service_account_names":        req.ServiceAccountNames,
+		"disable_check_in_enforcement": req.DisableCheckInEnforcement,
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+	if req.MaxTTL != "" {
+		data["max_ttl"] = req.MaxTTL
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, c.ldapLibraryPath(name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write LDAP library set: %w", err)
+	}
+
+	c.log(ctx).WithField("ldap_library", name).Info("LDAP library set written successfully")
+	return nil
+}
+
+func (c *Client) GetLDAPLibrary(ctx context.Context, name string) (*LDAPLibraryResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, c.ldapLibraryPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LDAP library set: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &LDAPLibraryResponse{
+		Name:                name,
+		ServiceAccountNames: stringSlice(secret.Data["service_account_names"]),
+		TTL:                 int64Value(secret.Data["ttl"]),
+		MaxTTL:              int64Value(secret.Data["max_ttl"]),
+	}
+	response.DisableCheckInEnforcement, _ = secret.Data["disable_check_in_enforcement"].(bool)
+
+	return response, nil
+}
+
+func (c *Client) ListLDAPLibraries(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing LDAP library sets...")
+	return c.listLDAP(ctx, "library")
+}
+
+// DeleteLDAPLibrary deletes a library set. It fails while accounts of the
+// set are checked out.
+func (c *Client) DeleteLDAPLibrary(ctx context.Context, name string) error {
+	c.log(ctx).WithField("ldap_library", name).Info("Deleting LDAP library set...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, c.ldapLibraryPath(name))
+	if err != nil {
+		return fmt.Errorf("failed to delete LDAP library set: %w", err)
+	}
+
+	c.log(ctx).WithField("ldap_library", name).Info("LDAP library set deleted successfully")
+	return nil
+}
+
+// CheckOutLDAPAccount lends an available account of the library set. The
+// account is checked back in and its password rotated when the lease
+// expires or is revoked.
+func (c *Client) CheckOutLDAPAccount(ctx context.Context, name string, req *LDAPCheckOutRequest) (*LDAPCheckOutResponse, error) {
+	c.log(ctx).WithField("ldap_library", name).Info("Checking out LDAP service account...")
+
+	data := map[string]interface{}{}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, c.ldapLibraryPath(name)+"/check-out", data)
+	if isNoAccountsAvailableError(err) {
+		return nil, ErrNoAccountsAvailable
+	}
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetLDAPLibrary(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("LDAP library set %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to check out LDAP service account: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no credential data returned")
+	}
+
+	response := &LDAPCheckOutResponse{Lease: leaseFromSecret(secret)}
+	response.ServiceAccountName, _ = secret.Data["service_account_name"].(string)
+	response.Password, _ = secret.Data["password"].(string)
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"ldap_library":    name,
+		"service_account": response.ServiceAccountName,
+	}).Info("LDAP service account checked out successfully")
+	return response, nil
+}
+
+// CheckInLDAPAccounts returns accounts to the library set and rotates their
+// passwords. Without accounts, the caller's only checked out account is
+// checked in. With force, accounts checked out by others are checked in too.
+func (c *Client) CheckInLDAPAccounts(ctx context.Context, name string, accounts []string, force bool) ([]string, error) {
+	c.log(ctx).WithFields(logrus.Fields{
+		"ldap_library": name,
+		"accounts":     accounts,
+		"force":        force,
+	}).Info("Checking in LDAP service accounts...")
+
+	path := c.ldapLibraryPath(name) + "/check-in"
+	if force {
+		path = fmt.Sprintf("%s/library/manage/%s/check-in", c.config.LDAP.MountPath, name)
+	}
+
+	data := map[string]interface{}{}
+	if len(accounts) > 0 {
+		data["service_account_names"] = accounts
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, path, data)
+	if err != nil {
+		if _, lookupErr := c.GetLDAPLibrary(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("LDAP library set %q: %w", name, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to check in LDAP service accounts: %w", err)
+	}
+
+	checkedIn := []string{}
+	if secret != nil && secret.Data != nil {
+		checkedIn = stringSlice(secret.Data["check_ins"])
+	}
+
+	c.log(ctx).WithField("ldap_library", name).Info("LDAP service accounts checked in successfully")
+	return checkedIn, nil
+}
+
+// GetLDAPLibraryStatus reports which accounts of the library set are
+// available, sorted by account name.
+func (c *Client) GetLDAPLibraryStatus(ctx context.Context, name string) ([]LDAPAccountStatus, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, c.ldapLibraryPath(name)+"/status")
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetLDAPLibrary(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("LDAP library set %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read LDAP library set status: %w", err)
+	}
+
+	statuses := []LDAPAccountStatus{}
+	if secret == nil || secret.Data == nil {
+		return statuses, nil
+	}
+
+	for account, value := range secret.Data {
+		fields, _ := value.(map[string]interface{})
+		status := LDAPAccountStatus{ServiceAccountName: account}
+		status.Available, _ = fields["available"].(bool)
+		status.BorrowerEntityID, _ = fields["borrower_entity_id"].(string)
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ServiceAccountName < statuses[j].ServiceAccountName
+	})
+
+	return statuses, nil
+}
+
+func (c *Client) ldapLibraryPath(name string) string {
+	return fmt.Sprintf("%s/library/%s", c.config.LDAP.MountPath, name)
+}
+
+func isNoAccountsAvailableError(err error) bool {
+	var respErr *api.ResponseError
+	if !errors.As(err, &respErr) {
+		return false
+	}
+
+	for _, message := range respErr.Errors {
+		if strings.Contains(message, "no service accounts available") {
+			return true
+		}
+	}
+	return false
+}
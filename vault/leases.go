@@ -0,0 +1,116 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+type LeaseResponse struct {
+	LeaseID     string     `json:"lease_id"`
+	IssueTime   *time.Time `json:"issue_time"`
+	ExpireTime  *time.Time `json:"expire_time"`
+	LastRenewal *time.Time `json:"last_renewal,omitempty"`
+	Renewable   bool       `json:"renewable"`
+	TTL         int64      `json:"ttl"`
+}
+
+// LookupLease reads the timing of a lease. Looking up leases requires update
+// on sys/leases/lookup.
+func (c *Client) LookupLease(ctx context.Context, leaseID string) (*LeaseResponse, error) {
+	secret, err := c.client.Sys().LookupWithContext(ctx, leaseID)
+	if isInvalidLeaseError(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up lease: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &LeaseResponse{
+		LeaseID:     leaseID,
+		IssueTime:   timeValue(secret.Data["issue_time"]),
+		ExpireTime:  timeValue(secret.Data["expire_time"]),
+		LastRenewal: timeValue(secret.Data["last_renewal"]),
+		TTL:         int64Value(secret.Data["ttl"]),
+	}
+	response.Renewable, _ = secret.Data["renewable"].(bool)
+
+	return response, nil
+}
+
+// RevokeLease revokes a lease right away instead of waiting for it to
+// expire. The secret engine deletes the secret issued with it, such as a
+// service account key.
+func (c *Client) RevokeLease(ctx context.Context, leaseID string) error {
+	c.log(ctx).WithField("lease_id", leaseID).Info("Revoking lease...")
+
+	if err := c.client.Sys().RevokeWithContext(ctx, leaseID); err != nil {
+		return fmt.Errorf("failed to revoke lease: %w", err)
+	}
+
+	c.log(ctx).WithField("lease_id", leaseID).Info("Lease revoked successfully")
+	return nil
+}
+
+// RevokeRolesetLeases revokes every lease of the secrets issued for a
+// roleset, keys and tokens alike. Revoking by prefix requires sudo on
+// sys/leases/revoke-prefix.
+func (c *Client) RevokeRolesetLeases(ctx context.Context, name string) error {
+	c.log(ctx).WithField("roleset", name).Info("Revoking GCP roleset leases...")
+
+	for _, secretType := range []string{"key", "token"} {
+		prefix := fmt.Sprintf("%s/%s/%s", c.mount, secretType, name)
+		if err := c.client.Sys().RevokePrefixWithContext(ctx, prefix); err != nil {
+			return fmt.Errorf("failed to revoke roleset leases: %w", err)
+		}
+	}
+
+	c.log(ctx).WithField("roleset", name).Info("GCP roleset leases revoked successfully")
+	return nil
+}
+
+// ListRolesetLeases returns the IDs of the outstanding leases of the secrets
+// issued for a roleset, keys and tokens alike. Looking up leases requires sudo
+// on sys/leases/lookup.
+func (c *Client) ListRolesetLeases(ctx context.Context, name string) ([]string, error) {
+	leases := []string{}
+	for _, secretType := range []string{"key", "token"} {
+		prefix := fmt.Sprintf("%s/%s/%s", c.mount, secretType, name)
+
+		secret, err := c.client.Logical().ListWithContext(ctx, "sys/leases/lookup/"+prefix+"/")
+		if err != nil {
+			return nil, fmt.Errorf("failed to list roleset leases: %w", err)
+		}
+		if secret == nil || secret.Data == nil {
+			continue
+		}
+
+		for _, key := range stringSlice(secret.Data["keys"]) {
+			leases = append(leases, prefix+"/"+key)
+		}
+	}
+	return leases, nil
+}
+
+func isInvalidLeaseError(err error) bool {
+	var respErr *api.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+
+	for _, message := range respErr.Errors {
+		if strings.Contains(message, "invalid lease") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type NamespaceInfo struct {
+	Path        string   `json:"path"`
+	GCPMounts   []string `json:"gcp_mounts"`
+	HasGCPMount bool     `json:"has_gcp_mount"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// DiscoverNamespaces lists the child namespaces visible to the client's token
+// and the GCP secrets engine mounts in each. Namespaces are a Vault
+// Enterprise feature.
+func (c *Client) DiscoverNamespaces(ctx context.Context) ([]NamespaceInfo, error) {
+	secret, err := c.client.Logical().ListWithContext(ctx, "sys/namespaces")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+
+	namespaces := []NamespaceInfo{}
+	if secret == nil || secret.Data == nil {
+		return namespaces, nil
+	}
+
+	keys, _ := secret.Data["keys"].([]interface{})
+	for _, key := range keys {
+		name, ok := key.(string)
+		if !ok {
+			continue
+		}
+
+		path := strings.Trim(name, "/")
+		if parent := strings.Trim(c.client.Namespace(), "/"); parent != "" {
+			path = parent + "/" + path
+		}
+
+		info := NamespaceInfo{Path: path, GCPMounts: []string{}}
+
+		mounts, err := c.client.WithNamespace(path).Sys().ListMountsWithContext(ctx)
+		if err != nil {
+			info.Error = err.Error()
+			namespaces = append(namespaces, info)
+			continue
+		}
+
+		for mountPath, mount := range mounts {
+			if mount.Type == "gcp" {
+				info.GCPMounts = append(info.GCPMounts, strings.TrimSuffix(mountPath, "/"))
+			}
+		}
+		sort.Strings(info.GCPMounts)
+		info.HasGCPMount = len(info.GCPMounts) > 0
+
+		namespaces = append(namespaces, info)
+	}
+
+	return namespaces, nil
+}
+
+// NamespaceRequest creates a child namespace. Custom metadata needs Vault
+// 1.12 or later.
+type NamespaceRequest struct {
+	CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+}
+
+type NamespaceResponse struct {
+	ID             string            `json:"id"`
+	Path           string            `json:"path"`
+	CustomMetadata map[string]string `json:"custom_metadata,omitempty"`
+}
+
+// CreateNamespace creates a child namespace of the client's namespace.
+func (c *Client) CreateNamespace(ctx context.Context, name string, req *NamespaceRequest) (*NamespaceResponse, error) {
+	c.log(ctx).WithField("namespace", name).Info("Creating namespace...")
+
+	data := map[string]interface{}{}
+	if req.CustomMetadata != nil {
+		data["custom_metadata"] = req.CustomMetadata
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, "sys/namespaces/"+name, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create namespace: %w", err)
+	}
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no namespace data returned")
+	}
+
+	response := &NamespaceResponse{}
+	if err := remarshal(secret.Data, response); err != nil {
+		return nil, fmt.Errorf("failed to decode namespace: %w", err)
+	}
+	response.Path = strings.Trim(response.Path, "/")
+
+	c.log(ctx).WithField("namespace", response.Path).Info("Namespace created successfully")
+	return response, nil
+}
+
+// DeleteNamespace deletes a child namespace of the client's namespace along
+// with everything in it. Vault refuses while it has child namespaces.
+func (c *Client) DeleteNamespace(ctx context.Context, name string) error {
+	c.log(ctx).WithField("namespace", name).Info("Deleting namespace...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, "sys/namespaces/"+name)
+	if err != nil {
+		return fmt.Errorf("failed to read namespace: %w", err)
+	}
+	if secret == nil {
+		return ErrNotFound
+	}
+
+	if _, err := c.client.Logical().DeleteWithContext(ctx, "sys/namespaces/"+name); err != nil {
+		return fmt.Errorf("failed to delete namespace: %w", err)
+	}
+
+	c.log(ctx).WithField("namespace", name).Info("Namespace deleted successfully")
+	return nil
+}
+
+// Namespace returns the namespace the client operates in.
+func (c *Client) Namespace() string {
+	return strings.Trim(c.client.Namespace(), "/")
+}
+
+// WithNamespace returns a copy of the client operating in namespace, a path
+// from the root namespace such as those DiscoverNamespaces returns.
+func (c *Client) WithNamespace(namespace string) *Client {
+	namespaced := *c
+	namespaced.client = c.client.WithNamespace(namespace)
+	return &namespaced
+}
@@ -0,0 +1,160 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// NomadRoleRequest defines the ACL token a Nomad role issues. Client tokens
+// need at least one policy; management tokens take none.
+type NomadRoleRequest struct {
+	Type     string   `json:"type,omitempty" binding:"omitempty,oneof=client management"`
+	Policies []string `json:"policies,omitempty"`
+	Global   bool     `json:"global"`
+}
+
+type NomadRoleResponse struct {
+	Name     string   `json:"name"`
+	Type     string   `json:"type"`
+	Policies []string `json:"policies,omitempty"`
+	Global   bool     `json:"global"`
+}
+
+type NomadTokenResponse struct {
+	SecretID   string `json:"secret_id"`
+	AccessorID string `json:"accessor_id"`
+	Lease
+}
+
+// InitializeNomad enables the Nomad secrets engine and configures its
+// access to Nomad and its leases.
+func (c *Client) InitializeNomad(ctx context.Context) error {
+	mount := c.config.Nomad.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault Nomad secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "nomad", "Nomad secrets engine for issuing ACL tokens"); err != nil {
+		return err
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, mount+"/config/access", map[string]interface{}{
+		"address": c.config.Nomad.Address,
+		"token":   c.config.Nomad.Token,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure Nomad engine: %w", err)
+	}
+
+	_, err = c.client.Logical().WriteWithContext(ctx, mount+"/config/lease", map[string]interface{}{
+		"ttl":     c.config.Nomad.DefaultTTL,
+		"max_ttl": c.config.Nomad.MaxTTL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure Nomad engine leases: %w", err)
+	}
+
+	c.log(ctx).Info("Vault Nomad secrets engine initialized successfully")
+	return nil
+}
+
+// WriteNomadRole creates a Nomad role or updates an existing one.
+func (c *Client) WriteNomadRole(ctx context.Context, name string, req *NomadRoleRequest) error {
+	c.log(ctx).WithField("nomad_role", name).Info("Writing Nomad role...")
+
+	data := map[string]interface{}{
+		"global": req.Global,
+	}
+	if req.Type != "" {
+		data["type"] = req.Type
+	}
+	if len(req.Policies) > 0 {
+		data["policies"] = req.Policies
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.Nomad.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write Nomad role: %w", err)
+	}
+
+	c.log(ctx).WithField("nomad_role", name).Info("Nomad role written successfully")
+	return nil
+}
+
+func (c *Client) GetNomadRole(ctx context.Context, name string) (*NomadRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.Nomad.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Nomad role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &NomadRoleResponse{
+		Name:     name,
+		Policies: stringSlice(secret.Data["policies"]),
+	}
+	// Older Vault versions return policies as a comma separated string
+	if policies, ok := secret.Data["policies"].(string); ok && policies != "" {
+		response.Policies = strings.Split(policies, ",")
+	}
+	response.Type, _ = secret.Data["type"].(string)
+	response.Global, _ = secret.Data["global"].(bool)
+
+	return response, nil
+}
+
+func (c *Client) ListNomadRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing Nomad roles...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.Nomad.MountPath+"/role")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Nomad roles: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+func (c *Client) DeleteNomadRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("nomad_role", name).Info("Deleting Nomad role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.Nomad.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete Nomad role: %w", err)
+	}
+
+	c.log(ctx).WithField("nomad_role", name).Info("Nomad role deleted successfully")
+	return nil
+}
+
+// GetNomadToken creates a Nomad ACL token for the role. The token is
+// deleted from Nomad when its lease expires or is revoked.
+func (c *Client) GetNomadToken(ctx context.Context, name string) (*NomadTokenResponse, error) {
+	c.log(ctx).WithField("nomad_role", name).Info("Generating Nomad token...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/creds/%s", c.config.Nomad.MountPath, name))
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetNomadRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("Nomad role %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Nomad token: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no token data returned")
+	}
+
+	response := &NomadTokenResponse{Lease: leaseFromSecret(secret)}
+	response.SecretID, _ = secret.Data["secret_id"].(string)
+	response.AccessorID, _ = secret.Data["accessor_id"].(string)
+
+	c.log(ctx).WithField("nomad_role", name).Info("Nomad token generated successfully")
+	return response, nil
+}
@@ -0,0 +1,390 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type PKIIssueRequest struct {
+	CommonName string   `json:"common_name" binding:"required"`
+	AltNames   []string `json:"alt_names,omitempty"`
+	IPSANs     []string `json:"ip_sans,omitempty"`
+	TTL        string   `json:"ttl,omitempty"`
+}
+
+type PKICertificateResponse struct {
+	Certificate    string   `json:"certificate"`
+	IssuingCA      string   `json:"issuing_ca"`
+	CAChain        []string `json:"ca_chain,omitempty"`
+	PrivateKey     string   `json:"private_key"`
+	PrivateKeyType string   `json:"private_key_type"`
+	SerialNumber   string   `json:"serial_number"`
+	Expiration     int64    `json:"expiration"`
+	Lease
+}
+
+type PKIRoleRequest struct {
+	AllowedDomains   []string `json:"allowed_domains,omitempty"`
+	AllowSubdomains  bool     `json:"allow_subdomains,omitempty"`
+	AllowBareDomains bool     `json:"allow_bare_domains,omitempty"`
+	AllowGlobDomains bool     `json:"allow_glob_domains,omitempty"`
+	AllowAnyName     bool     `json:"allow_any_name,omitempty"`
+	AllowIPSANs      *bool    `json:"allow_ip_sans,omitempty"`
+	EnforceHostnames *bool    `json:"enforce_hostnames,omitempty"`
+	ServerFlag       *bool    `json:"server_flag,omitempty"`
+	ClientFlag       *bool    `json:"client_flag,omitempty"`
+	KeyType          string   `json:"key_type,omitempty" binding:"omitempty,oneof=rsa ec ed25519 any"`
+	KeyBits          int      `json:"key_bits,omitempty"`
+	TTL              string   `json:"ttl,omitempty"`
+	MaxTTL           string   `json:"max_ttl,omitempty"`
+	Organization     []string `json:"organization,omitempty"`
+	OU               []string `json:"ou,omitempty"`
+	NoStore          bool     `json:"no_store,omitempty"`
+}
+
+type PKIRoleResponse struct {
+	Name             string   `json:"name"`
+	AllowedDomains   []string `json:"allowed_domains"`
+	AllowSubdomains  bool     `json:"allow_subdomains"`
+	AllowBareDomains bool     `json:"allow_bare_domains"`
+	AllowGlobDomains bool     `json:"allow_glob_domains"`
+	AllowAnyName     bool     `json:"allow_any_name"`
+	AllowIPSANs      bool     `json:"allow_ip_sans"`
+	EnforceHostnames bool     `json:"enforce_hostnames"`
+	ServerFlag       bool     `json:"server_flag"`
+	ClientFlag       bool     `json:"client_flag"`
+	KeyType          string   `json:"key_type"`
+	KeyBits          int64    `json:"key_bits"`
+	TTL              int64    `json:"ttl"`
+	MaxTTL           int64    `json:"max_ttl"`
+	Organization     []string `json:"organization,omitempty"`
+	OU               []string `json:"ou,omitempty"`
+	NoStore          bool     `json:"no_store"`
+}
+
+type PKIIntermediateCSRRequest struct {
+	CommonName string `json:"common_name" binding:"required"`
+	KeyType    string `json:"key_type,omitempty" binding:"omitempty,oneof=rsa ec ed25519"`
+	KeyBits    int    `json:"key_bits,omitempty"`
+}
+
+type PKIIntermediateCSRResponse struct {
+	CSR   string `json:"csr"`
+	KeyID string `json:"key_id,omitempty"`
+}
+
+type PKISignIntermediateRequest struct {
+	CSR           string `json:"csr" binding:"required"`
+	CommonName    string `json:"common_name" binding:"required"`
+	TTL           string `json:"ttl,omitempty"`
+	MaxPathLength *int   `json:"max_path_length,omitempty"`
+	UseCSRValues  bool   `json:"use_csr_values,omitempty"`
+}
+
+type PKISignedIntermediateResponse struct {
+	Certificate  string   `json:"certificate"`
+	IssuingCA    string   `json:"issuing_ca"`
+	CAChain      []string `json:"ca_chain,omitempty"`
+	SerialNumber string   `json:"serial_number"`
+	Expiration   int64    `json:"expiration"`
+}
+
+type PKISetSignedRequest struct {
+	Certificate string `json:"certificate" binding:"required"`
+}
+
+// InitializePKI enables the PKI secrets engine. Issuing certificates also
+// needs a CA, which is set up separately.
+func (c *Client) InitializePKI(ctx context.Context) error {
+	mount := c.config.PKI.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault PKI secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "pki", "PKI secrets engine for issuing TLS certificates"); err != nil {
+		return err
+	}
+
+	c.log(ctx).Info("Vault PKI secrets engine initialized successfully")
+	return nil
+}
+
+// IssueCertificate issues a certificate and private key for the role. The
+// private key is not stored by Vault and cannot be retrieved again.
+func (c *Client) IssueCertificate(ctx context.Context, role string, req *PKIIssueRequest) (*PKICertificateResponse, error) {
+	c.log(ctx).WithFields(logrus.Fields{
+		"pki_role":    role,
+		"common_name": req.CommonName,
+	}).Info("Issuing PKI certificate...")
+
+	data := map[string]interface{}{
+		"common_name": req.CommonName,
+	}
+	if len(req.AltNames) > 0 {
+		data["alt_names"] = strings.Join(req.AltNames, ",")
+	}
+	if len(req.IPSANs) > 0 {
+		data["ip_sans"] = strings.Join(req.IPSANs, ",")
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/issue/%s", c.config.PKI.MountPath, role), data)
+	if err != nil {
+		if _, lookupErr := c.GetPKIRole(ctx, role); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("PKI role %q: %w", role, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to issue certificate: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no certificate data returned")
+	}
+
+	response := &PKICertificateResponse{
+		CAChain:    stringSlice(secret.Data["ca_chain"]),
+		Expiration: int64Value(secret.Data["expiration"]),
+		Lease:      leaseFromSecret(secret),
+	}
+	response.Certificate, _ = secret.Data["certificate"].(string)
+	response.IssuingCA, _ = secret.Data["issuing_ca"].(string)
+	response.PrivateKey, _ = secret.Data["private_key"].(string)
+	response.PrivateKeyType, _ = secret.Data["private_key_type"].(string)
+	response.SerialNumber, _ = secret.Data["serial_number"].(string)
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"pki_role":      role,
+		"serial_number": response.SerialNumber,
+	}).Info("PKI certificate issued successfully")
+	return response, nil
+}
+
+func (c *Client) ListPKIRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing PKI roles...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.PKI.MountPath+"/roles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PKI roles: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+// WritePKIRole creates a PKI role or updates an existing one. Vault
+// replaces the whole role, so unset fields revert to their defaults.
+func (c *Client) WritePKIRole(ctx context.Context, name string, req *PKIRoleRequest) error {
+	c.log(ctx).WithField("pki_role", name).Info("Writing PKI role...")
+
+	data := map[string]interface{}{
+		"allow_subdomains":   req.AllowSubdomains,
+		"allow_bare_domains": req.AllowBareDomains,
+		"allow_glob_domains": req.AllowGlobDomains,
+		"allow_any_name":     req.AllowAnyName,
+		"no_store":           req.NoStore,
+	}
+	if len(req.AllowedDomains) > 0 {
+		data["allowed_domains"] = req.AllowedDomains
+	}
+	if req.AllowIPSANs != nil {
+		data["allow_ip_sans"] = *req.AllowIPSANs
+	}
+	if req.EnforceHostnames != nil {
+		data["enforce_hostnames"] = *req.EnforceHostnames
+	}
+	if req.ServerFlag != nil {
+		data["server_flag"] = *req.ServerFlag
+	}
+	if req.ClientFlag != nil {
+		data["client_flag"] = *req.ClientFlag
+	}
+	if req.KeyType != "" {
+		data["key_type"] = req.KeyType
+	}
+	if req.KeyBits > 0 {
+		data["key_bits"] = req.KeyBits
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+	if req.MaxTTL != "" {
+		data["max_ttl"] = req.MaxTTL
+	}
+	if len(req.Organization) > 0 {
+		data["organization"] = req.Organization
+	}
+	if len(req.OU) > 0 {
+		data["ou"] = req.OU
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.PKI.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write PKI role: %w", err)
+	}
+
+	c.log(ctx).WithField("pki_role", name).Info("PKI role written successfully")
+	return nil
+}
+
+func (c *Client) GetPKIRole(ctx context.Context, name string) (*PKIRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.PKI.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PKI role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &PKIRoleResponse{
+		Name:           name,
+		AllowedDomains: stringSlice(secret.Data["allowed_domains"]),
+		KeyBits:        int64Value(secret.Data["key_bits"]),
+		TTL:            int64Value(secret.Data["ttl"]),
+		MaxTTL:         int64Value(secret.Data["max_ttl"]),
+		Organization:   stringSlice(secret.Data["organization"]),
+		OU:             stringSlice(secret.Data["ou"]),
+	}
+	response.AllowSubdomains, _ = secret.Data["allow_subdomains"].(bool)
+	response.AllowBareDomains, _ = secret.Data["allow_bare_domains"].(bool)
+	response.AllowGlobDomains, _ = secret.Data["allow_glob_domains"].(bool)
+	response.AllowAnyName, _ = secret.Data["allow_any_name"].(bool)
+	response.AllowIPSANs, _ = secret.Data["allow_ip_sans"].(bool)
+	response.EnforceHostnames, _ = secret.Data["enforce_hostnames"].(bool)
+	response.ServerFlag, _ = secret.Data["server_flag"].(bool)
+	response.ClientFlag, _ = secret.Data["client_flag"].(bool)
+	response.KeyType, _ = secret.Data["key_type"].(string)
+	response.NoStore, _ = secret.Data["no_store"].(bool)
+
+	return response, nil
+}
+
+func (c *Client) DeletePKIRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("pki_role", name).Info("Deleting PKI role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.PKI.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete PKI role: %w", err)
+	}
+
+	c.log(ctx).WithField("pki_role", name).Info("PKI role deleted successfully")
+	return nil
+}
+
+// GetPKICRL reads the mount's current certificate revocation list as PEM.
+func (c *Client) GetPKICRL(ctx context.Context) (string, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, c.config.PKI.MountPath+"/cert/crl")
+	if err != nil {
+		return "", fmt.Errorf("failed to read PKI CRL: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return "", ErrNotFound
+	}
+
+	crl, _ := secret.Data["certificate"].(string)
+	if crl == "" {
+		return "", ErrNotFound
+	}
+	return crl, nil
+}
+
+// GeneratePKIIntermediateCSR generates a new intermediate CA key inside Vault
+// and returns its CSR. The key never leaves Vault.
+func (c *Client) GeneratePKIIntermediateCSR(ctx context.Context, req *PKIIntermediateCSRRequest) (*PKIIntermediateCSRResponse, error) {
+	c.log(ctx).WithField("common_name", req.CommonName).Info("Generating PKI intermediate CSR...")
+
+	data := map[string]interface{}{
+		"common_name": req.CommonName,
+	}
+	if req.KeyType != "" {
+		data["key_type"] = req.KeyType
+	}
+	if req.KeyBits > 0 {
+		data["key_bits"] = req.KeyBits
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, c.config.PKI.MountPath+"/intermediate/generate/internal", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate intermediate CSR: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no CSR data returned")
+	}
+
+	response := &PKIIntermediateCSRResponse{}
+	response.CSR, _ = secret.Data["csr"].(string)
+	response.KeyID, _ = secret.Data["key_id"].(string)
+
+	c.log(ctx).WithField("common_name", req.CommonName).Info("PKI intermediate CSR generated successfully")
+	return response, nil
+}
+
+// SignPKIIntermediate signs an intermediate CSR with the CA on the root
+// mount.
+func (c *Client) SignPKIIntermediate(ctx context.Context, req *PKISignIntermediateRequest) (*PKISignedIntermediateResponse, error) {
+	mount := c.config.PKI.RootMountPath
+	c.log(ctx).WithFields(logrus.Fields{
+		"mount":       mount,
+		"common_name": req.CommonName,
+	}).Info("Signing PKI intermediate CSR...")
+
+	data := map[string]interface{}{
+		"csr":            req.CSR,
+		"common_name":    req.CommonName,
+		"format":         "pem_bundle",
+		"use_csr_values": req.UseCSRValues,
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+	if req.MaxPathLength != nil {
+		data["max_path_length"] = *req.MaxPathLength
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, mount+"/root/sign-intermediate", data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign intermediate CSR: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no certificate data returned")
+	}
+
+	response := &PKISignedIntermediateResponse{
+		CAChain:    stringSlice(secret.Data["ca_chain"]),
+		Expiration: int64Value(secret.Data["expiration"]),
+	}
+	response.Certificate, _ = secret.Data["certificate"].(string)
+	response.IssuingCA, _ = secret.Data["issuing_ca"].(string)
+	response.SerialNumber, _ = secret.Data["serial_number"].(string)
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"mount":         mount,
+		"serial_number": response.SerialNumber,
+	}).Info("PKI intermediate CSR signed successfully")
+	return response, nil
+}
+
+// SetPKIIntermediateSigned imports the signed intermediate certificate so the
+// mount can start issuing with it.
+func (c *Client) SetPKIIntermediateSigned(ctx context.Context, req *PKISetSignedRequest) error {
+	c.log(ctx).Info("Setting signed PKI intermediate certificate...")
+
+	_, err := c.client.Logical().WriteWithContext(ctx, c.config.PKI.MountPath+"/intermediate/set-signed", map[string]interface{}{
+		"certificate": req.Certificate,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set signed intermediate certificate: %w", err)
+	}
+
+	c.log(ctx).Info("Signed PKI intermediate certificate set successfully")
+	return nil
+}
@@ -0,0 +1,89 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// ErrPolicyRejected is returned when Vault refuses a policy change, such as a
+// policy that does not parse or a change to the root policy.
+var ErrPolicyRejected = errors.New("policy change rejected")
+
+// PolicyRequest carries an ACL policy in HCL or JSON.
+type PolicyRequest struct {
+	Policy string `json:"policy" binding:"required"`
+}
+
+type PolicyResponse struct {
+	Name   string `json:"name"`
+	Policy string `json:"policy"`
+}
+
+// WritePolicy creates an ACL policy or replaces an existing one.
+func (c *Client) WritePolicy(ctx context.Context, name string, req *PolicyRequest) error {
+	c.log(ctx).WithField("policy", name).Info("Writing policy...")
+
+	_, err := c.client.Logical().WriteWithContext(ctx, "sys/policies/acl/"+name, map[string]interface{}{
+		"policy": req.Policy,
+	})
+	if err != nil {
+		return policyError("write", err)
+	}
+
+	c.log(ctx).WithField("policy", name).Info("Policy written successfully")
+	return nil
+}
+
+func (c *Client) GetPolicy(ctx context.Context, name string) (*PolicyResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, "sys/policies/acl/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &PolicyResponse{Name: name}
+	response.Policy, _ = secret.Data["policy"].(string)
+	return response, nil
+}
+
+func (c *Client) ListPolicies(ctx context.Context) ([]string, error) {
+	secret, err := c.client.Logical().ListWithContext(ctx, "sys/policies/acl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list policies: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+// DeletePolicy deletes an ACL policy. Tokens and identities referring to it
+// lose its capabilities right away.
+func (c *Client) DeletePolicy(ctx context.Context, name string) error {
+	c.log(ctx).WithField("policy", name).Info("Deleting policy...")
+
+	if _, err := c.client.Logical().DeleteWithContext(ctx, "sys/policies/acl/"+name); err != nil {
+		return policyError("delete", err)
+	}
+
+	c.log(ctx).WithField("policy", name).Info("Policy deleted successfully")
+	return nil
+}
+
+func policyError(operation string, err error) error {
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusBadRequest {
+		return fmt.Errorf("%w: %s", ErrPolicyRejected, strings.Join(respErr.Errors, "; "))
+	}
+	return fmt.Errorf("failed to %s policy: %w", operation, err)
+}
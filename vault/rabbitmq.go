@@ -0,0 +1,200 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type RabbitMQVHostPermissions struct {
+	Configure string `json:"configure"`
+	Write     string `json:"write"`
+	Read      string `json:"read"`
+}
+
+type RabbitMQTopicPermissions struct {
+	Write string `json:"write"`
+	Read  string `json:"read"`
+}
+
+// RabbitMQRoleRequest defines the RabbitMQ user a role creates. VHosts maps
+// virtual hosts to permission regexes; VHostTopics maps virtual hosts to the
+// permissions per topic exchange.
+type RabbitMQRoleRequest struct {
+	Tags        []string                                       `json:"tags,omitempty"`
+	VHosts      map[string]RabbitMQVHostPermissions            `json:"vhosts" binding:"required,min=1"`
+	VHostTopics map[string]map[string]RabbitMQTopicPermissions `json:"vhost_topics,omitempty"`
+}
+
+type RabbitMQRoleResponse struct {
+	Name        string                                         `json:"name"`
+	Tags        []string                                       `json:"tags"`
+	VHosts      map[string]RabbitMQVHostPermissions            `json:"vhosts"`
+	VHostTopics map[string]map[string]RabbitMQTopicPermissions `json:"vhost_topics,omitempty"`
+}
+
+type RabbitMQCredentialsResponse struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Lease
+}
+
+// InitializeRabbitMQ enables the RabbitMQ secrets engine and configures its
+// connection to the management API and its leases.
+func (c *Client) InitializeRabbitMQ(ctx context.Context) error {
+	mount := c.config.RabbitMQ.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault RabbitMQ secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "rabbitmq", "RabbitMQ secrets engine for issuing dynamic RabbitMQ users"); err != nil {
+		return err
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, mount+"/config/connection", map[string]interface{}{
+		"connection_uri": c.config.RabbitMQ.ConnectionURI,
+		"username":       c.config.RabbitMQ.Username,
+		"password":       c.config.RabbitMQ.Password,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure RabbitMQ connection: %w", err)
+	}
+
+	_, err = c.client.Logical().WriteWithContext(ctx, mount+"/config/lease", map[string]interface{}{
+		"ttl":     c.config.RabbitMQ.DefaultTTL,
+		"max_ttl": c.config.RabbitMQ.MaxTTL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure RabbitMQ engine leases: %w", err)
+	}
+
+	c.log(ctx).Info("Vault RabbitMQ secrets engine initialized successfully")
+	return nil
+}
+
+// WriteRabbitMQRole creates a RabbitMQ role or updates an existing one.
+func (c *Client) WriteRabbitMQRole(ctx context.Context, name string, req *RabbitMQRoleRequest) error {
+	c.log(ctx).WithField("rabbitmq_role", name).Info("Writing RabbitMQ role...")
+
+	data := map[string]interface{}{
+		"tags": strings.Join(req.Tags, ","),
+	}
+	// Vault takes the permissions as JSON strings
+	if len(req.VHosts) > 0 {
+		vhosts, err := json.Marshal(req.VHosts)
+		if err != nil {
+			return fmt.Errorf("failed to encode vhosts: %w", err)
+		}
+		data["vhosts"] = string(vhosts)
+	}
+	if len(req.VHostTopics) > 0 {
+		topics, err := json.Marshal(req.VHostTopics)
+		if err != nil {
+			return fmt.Errorf("failed to encode vhost topics: %w", err)
+		}
+		data["vhost_topics"] = string(topics)
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.RabbitMQ.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write RabbitMQ role: %w", err)
+	}
+
+	c.log(ctx).WithField("rabbitmq_role", name).Info("RabbitMQ role written successfully")
+	return nil
+}
+
+func (c *Client) GetRabbitMQRole(ctx context.Context, name string) (*RabbitMQRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.RabbitMQ.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read RabbitMQ role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &RabbitMQRoleResponse{
+		Name:   name,
+		Tags:   []string{},
+		VHosts: map[string]RabbitMQVHostPermissions{},
+	}
+	if tags, _ := secret.Data["tags"].(string); tags != "" {
+		response.Tags = strings.Split(tags, ",")
+	}
+	if err := remarshal(secret.Data["vhosts"], &response.VHosts); err != nil {
+		return nil, fmt.Errorf("failed to decode vhosts: %w", err)
+	}
+	if err := remarshal(secret.Data["vhost_topics"], &response.VHostTopics); err != nil {
+		return nil, fmt.Errorf("failed to decode vhost topics: %w", err)
+	}
+
+	return response, nil
+}
+
+func (c *Client) ListRabbitMQRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing RabbitMQ roles...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.RabbitMQ.MountPath+"/roles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list RabbitMQ roles: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+func (c *Client) DeleteRabbitMQRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("rabbitmq_role", name).Info("Deleting RabbitMQ role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.RabbitMQ.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete RabbitMQ role: %w", err)
+	}
+
+	c.log(ctx).WithField("rabbitmq_role", name).Info("RabbitMQ role deleted successfully")
+	return nil
+}
+
+// GetRabbitMQCredentials creates a RabbitMQ user for the role. The user is
+// deleted when its lease expires or is revoked.
+func (c *Client) GetRabbitMQCredentials(ctx context.Context, name string) (*RabbitMQCredentialsResponse, error) {
+	c.log(ctx).WithField("rabbitmq_role", name).Info("Generating RabbitMQ credentials...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/creds/%s", c.config.RabbitMQ.MountPath, name))
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetRabbitMQRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("RabbitMQ role %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get RabbitMQ credentials: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no credential data returned")
+	}
+
+	response := &RabbitMQCredentialsResponse{Lease: leaseFromSecret(secret)}
+	response.Username, _ = secret.Data["username"].(string)
+	response.Password, _ = secret.Data["password"].(string)
+
+	c.log(ctx).WithField("rabbitmq_role", name).Info("RabbitMQ credentials generated successfully")
+	return response, nil
+}
+
+// remarshal decodes a value of a Vault response into out through JSON.
+// Missing values leave out unchanged.
+func remarshal(value interface{}, out interface{}) error {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
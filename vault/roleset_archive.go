@@ -0,0 +1,38 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ArchiveRoleset saves the roleset's definition as a KV secret under
+// archivePath and then deletes the roleset. It returns the KV path written.
+func (c *Client) ArchiveRoleset(ctx context.Context, name, archivePath string) (string, error) {
+	roleset, err := c.GetRoleset(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	path := fmt.Sprintf("%s/%s/%s", strings.Trim(archivePath, "/"), c.mount, name)
+	_, err = c.WriteKV(ctx, path, &KVWriteRequest{
+		Data: map[string]interface{}{
+			"project":               roleset.Project,
+			"secret_type":           roleset.SecretType,
+			"token_scopes":          roleset.TokenScopes,
+			"bindings":              roleset.Bindings,
+			"service_account_email": roleset.ServiceAccountEmail,
+			"archived_at":           time.Now().UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to archive roleset: %w", err)
+	}
+
+	if err := c.DeleteRoleset(ctx, name); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
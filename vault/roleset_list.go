@@ -0,0 +1,134 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RolesetListOptions narrows a roleset listing. Names are returned in the
+// order Vault lists them, which is sorted.
+type RolesetListOptions struct {
+	Prefix string
+	After  string
+	Limit  int
+	// Filter, when set, leaves out the names it returns false for
+	Filter func(name string) bool
+}
+
+type RolesetPage struct {
+	Rolesets []string `json:"rolesets"`
+	Next     string   `json:"next,omitempty"`
+}
+
+// WalkRolesets streams the roleset LIST response and calls fn for every name
+// starting with prefix, without holding the full list in memory. The walk
+// stops early when fn returns false.
+//
+// Roleset names cannot contain "/", so the prefix cannot be pushed into the
+// LIST path and is applied while decoding instead.
+func (c *Client) WalkRolesets(ctx context.Context, prefix string, fn func(name string) bool) error {
+	resp, err := c.client.Logical().ReadRawWithDataWithContext(ctx, c.mount+"/roleset", map[string][]string{
+		"list": {"true"},
+	})
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// Vault answers an empty list with a 404
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list rolesets: %w", err)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if err := enterObjectField(dec, "data"); err != nil {
+		return fmt.Errorf("failed to decode roleset list: %w", err)
+	}
+	if err := enterObjectField(dec, "keys"); err != nil {
+		return fmt.Errorf("failed to decode roleset list: %w", err)
+	}
+	if err := expectDelim(dec, '['); err != nil {
+		return fmt.Errorf("failed to decode roleset list: %w", err)
+	}
+
+	for dec.More() {
+		var name string
+		if err := dec.Decode(&name); err != nil {
+			return fmt.Errorf("failed to decode roleset list: %w", err)
+		}
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if !fn(name) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// ListRolesetPage returns at most opts.Limit rolesets after opts.After. Next
+// is set to the cursor of the following page when more rolesets remain.
+func (c *Client) ListRolesetPage(ctx context.Context, opts RolesetListOptions) (*RolesetPage, error) {
+	page := &RolesetPage{Rolesets: []string{}}
+
+	err := c.WalkRolesets(ctx, opts.Prefix, func(name string) bool {
+		if opts.After != "" && name <= opts.After {
+			return true
+		}
+		if opts.Filter != nil && !opts.Filter(name) {
+			return true
+		}
+		if opts.Limit > 0 && len(page.Rolesets) == opts.Limit {
+			page.Next = page.Rolesets[len(page.Rolesets)-1]
+			return false
+		}
+		page.Rolesets = append(page.Rolesets, name)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+// enterObjectField advances dec into the value of field of the next JSON
+// object, skipping the fields before it.
+func enterObjectField(dec *json.Decoder, field string) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key, _ := token.(string); key == field {
+			return nil
+		}
+
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("field %q not found", field)
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return fmt.Errorf("expected %q, got %v", delim, token)
+	}
+	return nil
+}
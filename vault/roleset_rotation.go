@@ -0,0 +1,38 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+)
+
+// RotateRoleset rotates the service account backing a roleset. Secrets issued
+// by the old service account stop working once it is deleted.
+func (c *Client) RotateRoleset(ctx context.Context, name string) error {
+	c.log(ctx).WithField("roleset", name).Info("Rotating GCP roleset service account...")
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roleset/%s/rotate", c.mount, name), nil)
+	if err != nil {
+		return fmt.Errorf("failed to rotate roleset: %w", err)
+	}
+
+	c.invalidateToken(ctx, c.tokenCacheKey("roleset", name, ""))
+
+	c.log(ctx).WithField("roleset", name).Info("GCP roleset service account rotated successfully")
+	return nil
+}
+
+// RotateRolesetKey rotates the key an access_token roleset uses to sign
+// tokens, keeping the service account itself.
+func (c *Client) RotateRolesetKey(ctx context.Context, name string) error {
+	c.log(ctx).WithField("roleset", name).Info("Rotating GCP roleset key...")
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roleset/%s/rotate-key", c.mount, name), nil)
+	if err != nil {
+		return fmt.Errorf("failed to rotate roleset key: %w", err)
+	}
+
+	c.invalidateToken(ctx, c.tokenCacheKey("roleset", name, ""))
+
+	c.log(ctx).WithField("roleset", name).Info("GCP roleset key rotated successfully")
+	return nil
+}
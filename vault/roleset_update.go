@@ -0,0 +1,108 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RolesetPatch holds the fields of a partial roleset update; nil fields are
+// left unchanged.
+type RolesetPatch struct {
+	Project     *string  `json:"project,omitempty"`
+	SecretType  *string  `json:"secret_type,omitempty"`
+	TokenScopes *string  `json:"token_scopes,omitempty"`
+	Bindings    Bindings `json:"bindings,omitempty"`
+	TTL         *string  `json:"ttl,omitempty"`
+	MaxTTL      *string  `json:"max_ttl,omitempty"`
+}
+
+// ErrSecretTypeChange is returned when an update tries to change a roleset's
+// secret_type, which Vault does not allow.
+var ErrSecretTypeChange = errors.New("secret_type cannot be changed after roleset creation")
+
+// UpdateRoleset overwrites an existing roleset. Unlike CreateRoleset it fails
+// with ErrNotFound instead of creating a new roleset.
+func (c *Client) UpdateRoleset(ctx context.Context, name string, req *RolesetRequest) error {
+	c.log(ctx).WithField("roleset", name).Info("Updating GCP roleset...")
+
+	current, err := c.GetRoleset(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if req.SecretType != current.SecretType {
+		return ErrSecretTypeChange
+	}
+
+	data, err := c.rolesetData(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/roleset/%s", c.mount, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to update roleset: %w", err)
+	}
+
+	c.log(ctx).WithField("roleset", name).Info("GCP roleset updated successfully")
+	return nil
+}
+
+// MergeRolesetPatch reads the current roleset and applies the patch on top of
+// it, returning the complete request to write back.
+func (c *Client) MergeRolesetPatch(ctx context.Context, name string, patch *RolesetPatch) (*RolesetRequest, error) {
+	current, err := c.GetRoleset(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := &RolesetRequest{
+		Project:     current.Project,
+		SecretType:  current.SecretType,
+		TokenScopes: strings.Join(current.TokenScopes, ","),
+		Bindings:    bindingsFromRead(current.Bindings),
+	}
+
+	if patch.Project != nil {
+		merged.Project = *patch.Project
+	}
+	if patch.SecretType != nil && *patch.SecretType != current.SecretType {
+		return nil, ErrSecretTypeChange
+	}
+	if patch.TokenScopes != nil {
+		merged.TokenScopes = *patch.TokenScopes
+	}
+	if patch.Bindings != nil {
+		merged.Bindings = patch.Bindings
+	}
+	if patch.TTL != nil {
+		merged.TTL = *patch.TTL
+	}
+	if patch.MaxTTL != nil {
+		merged.MaxTTL = *patch.MaxTTL
+	}
+
+	return merged, nil
+}
+
+// bindingsFromRead converts bindings as returned by a roleset read (resource
+// to role list) back into the resource-block form accepted on write.
+func bindingsFromRead(bindings interface{}) Bindings {
+	resources, ok := bindings.(map[string]interface{})
+	if !ok || len(resources) == 0 {
+		return nil
+	}
+
+	blocks := make(map[string]interface{}, len(resources))
+	for resource, roles := range resources {
+		blocks[resource] = map[string]interface{}{
+			"roles": roles,
+		}
+	}
+
+	return Bindings{
+		"resource": blocks,
+	}
+}
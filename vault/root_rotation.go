@@ -0,0 +1,57 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+type RootRotationResponse struct {
+	PrivateKeyID string    `json:"private_key_id"`
+	RotatedAt    time.Time `json:"rotated_at"`
+}
+
+// RotateRootCredentials rotates the GCP credentials Vault itself uses. Vault
+// creates a new key for its service account and deletes the old one.
+func (c *Client) RotateRootCredentials(ctx context.Context) (*RootRotationResponse, error) {
+	c.log(ctx).WithField("mount", c.mount).Info("Rotating GCP root credentials...")
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, c.mount+"/config/rotate-root", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rotate root credentials: %w", err)
+	}
+
+	response := &RootRotationResponse{RotatedAt: time.Now().UTC()}
+	if secret != nil && secret.Data != nil {
+		response.PrivateKeyID, _ = secret.Data["private_key_id"].(string)
+	}
+
+	c.log(ctx).WithField("private_key_id", response.PrivateKeyID).Info("GCP root credentials rotated successfully")
+	return response, nil
+}
+
+// RunRootRotation rotates the root credentials of every mount each period
+// until ctx is done.
+func (c *Client) RunRootRotation(ctx context.Context) {
+	period := c.config.GCP.RootRotationPeriod
+	c.log(ctx).WithField("period", period).Info("Starting scheduled GCP root credential rotation")
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, path := range c.MountPaths() {
+			rotateCtx, cancel := context.WithTimeout(ctx, time.Minute)
+			if _, err := c.mounts[path].RotateRootCredentials(rotateCtx); err != nil {
+				c.log(ctx).WithError(err).WithField("mount", path).Error("Scheduled GCP root credential rotation failed")
+			}
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// SealStatusResponse is Vault's seal state. Threshold and Shares are the
+// number of unseal key shares needed and issued; Progress counts the shares
+// submitted towards the next unseal.
+type SealStatusResponse struct {
+	Type         string `json:"type"`
+	Initialized  bool   `json:"initialized"`
+	Sealed       bool   `json:"sealed"`
+	Threshold    int    `json:"threshold"`
+	Shares       int    `json:"shares"`
+	Progress     int    `json:"progress"`
+	Version      string `json:"version"`
+	BuildDate    string `json:"build_date,omitempty"`
+	Migration    bool   `json:"migration"`
+	RecoverySeal bool   `json:"recovery_seal"`
+	StorageType  string `json:"storage_type,omitempty"`
+	ClusterName  string `json:"cluster_name,omitempty"`
+	ClusterID    string `json:"cluster_id,omitempty"`
+}
+
+// SealStatus reads Vault's seal status. The endpoint is unauthenticated, so
+// it answers while Vault is sealed.
+func (c *Client) SealStatus(ctx context.Context) (*SealStatusResponse, error) {
+	status, err := c.client.Sys().SealStatusWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seal status: %w", err)
+	}
+	return sealStatusResponse(status), nil
+}
+
+// Unseal submits one unseal key share, or discards the shares submitted so
+// far when reset is set, and returns the resulting seal status. The key is
+// never logged.
+func (c *Client) Unseal(ctx context.Context, key string, reset bool) (*SealStatusResponse, error) {
+	c.log(ctx).WithField("reset", reset).Info("Submitting unseal key share...")
+
+	status, err := c.client.Sys().UnsealWithOptionsWithContext(ctx, &api.UnsealOpts{
+		Key:   key,
+		Reset: reset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unseal vault: %w", err)
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"sealed":    status.Sealed,
+		"progress":  status.Progress,
+		"threshold": status.T,
+	}).Info("Unseal key share submitted successfully")
+	return sealStatusResponse(status), nil
+}
+
+func sealStatusResponse(status *api.SealStatusResponse) *SealStatusResponse {
+	return &SealStatusResponse{
+		Type:         status.Type,
+		Initialized:  status.Initialized,
+		Sealed:       status.Sealed,
+		Threshold:    status.T,
+		Shares:       status.N,
+		Progress:     status.Progress,
+		Version:      status.Version,
+		BuildDate:    status.BuildDate,
+		Migration:    status.Migration,
+		RecoverySeal: status.RecoverySeal,
+		StorageType:  status.StorageType,
+		ClusterName:  status.ClusterName,
+		ClusterID:    status.ClusterID,
+	}
+}
+
+// KeyStatusResponse is the state of Vault's barrier encryption key. Term
+// counts the key rotations so far and Encryptions the writes made with the
+// current key.
+type KeyStatusResponse struct {
+	Term        int       `json:"term"`
+	InstallTime time.Time `json:"install_time"`
+	Encryptions int       `json:"encryptions"`
+}
+
+// KeyStatus reads the state of Vault's barrier key. Reading it requires
+// sudo on sys/key-status.
+func (c *Client) KeyStatus(ctx context.Context) (*KeyStatusResponse, error) {
+	status, err := c.client.Sys().KeyStatusWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key status: %w", err)
+	}
+
+	return &KeyStatusResponse{
+		Term:        status.Term,
+		InstallTime: status.InstallTime,
+		Encryptions: status.Encryptions,
+	}, nil
+}
+
+// RotateBarrierKey installs a new barrier key and returns its status. Data
+// written before stays readable through the keyring. Rotating requires sudo
+// on sys/rotate.
+func (c *Client) RotateBarrierKey(ctx context.Context) (*KeyStatusResponse, error) {
+	c.log(ctx).Info("Rotating barrier key...")
+
+	if err := c.client.Sys().RotateWithContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to rotate barrier key: %w", err)
+	}
+
+	status, err := c.KeyStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log(ctx).WithField("term", status.Term).Info("Barrier key rotated successfully")
+	return status, nil
+}
@@ -0,0 +1,115 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type SSHSignRequest struct {
+	PublicKey string `json:"public_key" binding:"required"`
+	// ValidPrincipals must be allowed by the role's allowed_users
+	ValidPrincipals []string `json:"valid_principals,omitempty"`
+	CertType        string   `json:"cert_type,omitempty" binding:"omitempty,oneof=user host"`
+	KeyID           string   `json:"key_id,omitempty"`
+	TTL             string   `json:"ttl,omitempty"`
+}
+
+type SSHCertificateResponse struct {
+	SignedKey    string `json:"signed_key"`
+	SerialNumber string `json:"serial_number"`
+	Lease
+}
+
+// InitializeSSH enables the SSH secrets engine and, if configured, has Vault
+// generate a signing CA when the mount has none.
+func (c *Client) InitializeSSH(ctx context.Context) error {
+	mount := c.config.SSH.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault SSH secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "ssh", "SSH secrets engine for signing SSH certificates"); err != nil {
+		return err
+	}
+
+	if c.config.SSH.GenerateCA {
+		secret, err := c.client.Logical().ReadWithContext(ctx, mount+"/config/ca")
+		if err != nil && !strings.Contains(err.Error(), "keys haven't been configured yet") {
+			return fmt.Errorf("failed to read SSH CA: %w", err)
+		}
+
+		if secret == nil || secret.Data == nil || secret.Data["public_key"] == nil {
+			_, err := c.client.Logical().WriteWithContext(ctx, mount+"/config/ca", map[string]interface{}{
+				"generate_signing_key": true,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to generate SSH CA: %w", err)
+			}
+			c.log(ctx).Info("SSH CA generated successfully")
+		}
+	}
+
+	c.log(ctx).Info("Vault SSH secrets engine initialized successfully")
+	return nil
+}
+
+// SignSSHKey signs a public key with the role's CA.
+func (c *Client) SignSSHKey(ctx context.Context, role string, req *SSHSignRequest) (*SSHCertificateResponse, error) {
+	c.log(ctx).WithFields(logrus.Fields{
+		"ssh_role":   role,
+		"principals": req.ValidPrincipals,
+	}).Info("Signing SSH key...")
+
+	data := map[string]interface{}{
+		"public_key": req.PublicKey,
+	}
+	if len(req.ValidPrincipals) > 0 {
+		data["valid_principals"] = strings.Join(req.ValidPrincipals, ",")
+	}
+	if req.CertType != "" {
+		data["cert_type"] = req.CertType
+	}
+	if req.KeyID != "" {
+		data["key_id"] = req.KeyID
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/sign/%s", c.config.SSH.MountPath, role), data)
+	if err != nil {
+		if _, lookupErr := c.getSSHRole(ctx, role); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("SSH role %q: %w", role, ErrNotFound)
+		}
+		return nil, fmt.Errorf("failed to sign SSH key: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no signed key returned")
+	}
+
+	response := &SSHCertificateResponse{Lease: leaseFromSecret(secret)}
+	response.SignedKey, _ = secret.Data["signed_key"].(string)
+	response.SerialNumber, _ = secret.Data["serial_number"].(string)
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"ssh_role":      role,
+		"serial_number": response.SerialNumber,
+	}).Info("SSH key signed successfully")
+	return response, nil
+}
+
+// getSSHRole reads an SSH role's raw settings.
+func (c *Client) getSSHRole(ctx context.Context, name string) (map[string]interface{}, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/roles/%s", c.config.SSH.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+	return secret.Data, nil
+}
@@ -0,0 +1,156 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+)
+
+type StaticAccountRequest struct {
+	ServiceAccountEmail string   `json:"service_account_email" binding:"required"`
+	SecretType          string   `json:"secret_type" binding:"required,oneof=access_token service_account_key"`
+	TokenScopes         string   `json:"token_scopes,omitempty"`
+	Bindings            Bindings `json:"bindings"`
+}
+
+type StaticAccountResponse struct {
+	Name                  string      `json:"name"`
+	ServiceAccountEmail   string      `json:"service_account_email"`
+	ServiceAccountProject string      `json:"service_account_project,omitempty"`
+	SecretType            string      `json:"secret_type"`
+	Bindings              interface{} `json:"bindings,omitempty"`
+	TokenScopes           []string    `json:"token_scopes,omitempty"`
+}
+
+// WriteStaticAccount creates a static account or updates an existing one.
+func (c *Client) WriteStaticAccount(ctx context.Context, name string, req *StaticAccountRequest) error {
+	c.log(ctx).WithField("static_account", name).Info("Writing GCP static account...")
+
+	data := map[string]interface{}{
+		"service_account_email": req.ServiceAccountEmail,
+		"secret_type":           req.SecretType,
+	}
+
+	if req.TokenScopes != "" {
+		data["token_scopes"] = req.TokenScopes
+	} else if req.SecretType == "access_token" {
+		data["token_scopes"] = c.engine.DefaultTokenScopes
+	}
+
+	if len(req.Bindings) > 0 {
+		bindings, err := encodeBindings(req.Bindings)
+		if err != nil {
+			return err
+		}
+		data["bindings"] = bindings
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/static-account/%s", c.mount, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write static account: %w", err)
+	}
+
+	c.log(ctx).WithField("static_account", name).Info("GCP static account written successfully")
+	return nil
+}
+
+func (c *Client) GetStaticAccount(ctx context.Context, name string) (*StaticAccountResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/static-account/%s", c.mount, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read static account: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &StaticAccountResponse{
+		Name:        name,
+		Bindings:    secret.Data["bindings"],
+		TokenScopes: stringSlice(secret.Data["token_scopes"]),
+	}
+	response.ServiceAccountEmail, _ = secret.Data["service_account_email"].(string)
+	response.ServiceAccountProject, _ = secret.Data["service_account_project"].(string)
+	response.SecretType, _ = secret.Data["secret_type"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListStaticAccounts(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing GCP static accounts...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.mount+"/static-accounts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list static accounts: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+// StaticAccountSecretTypes looks up the secret_type of each static account.
+// Accounts that cannot be read, e.g. because they were deleted after being
+// listed, are left out.
+func (c *Client) StaticAccountSecretTypes(ctx context.Context, names []string) map[string]string {
+	secretTypes := make(map[string]string, len(names))
+	for _, name := range names {
+		if ctx.Err() != nil {
+			break
+		}
+
+		account, err := c.GetStaticAccount(ctx, name)
+		if err != nil {
+			continue
+		}
+		secretTypes[name] = account.SecretType
+	}
+	return secretTypes
+}
+
+func (c *Client) DeleteStaticAccount(ctx context.Context, name string) error {
+	c.log(ctx).WithField("static_account", name).Info("Deleting GCP static account...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/static-account/%s", c.mount, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete static account: %w", err)
+	}
+
+	c.log(ctx).WithField("static_account", name).Info("GCP static account deleted successfully")
+	return nil
+}
+
+func (c *Client) GetStaticAccountToken(ctx context.Context, name string) (*TokenResponse, error) {
+	c.log(ctx).WithField("static_account", name).Info("Generating GCP access token for static account...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/static-account/%s/token", c.mount, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	response, err := tokenFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log(ctx).WithField("static_account", name).Info("GCP access token generated successfully")
+	return response, nil
+}
+
+func (c *Client) GetStaticAccountKey(ctx context.Context, name string, req *KeyRequest) (*ServiceAccountKeyResponse, error) {
+	c.log(ctx).WithField("static_account", name).Info("Generating GCP service account key for static account...")
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/static-account/%s/key", c.mount, name), req.data())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service account key: %w", err)
+	}
+
+	response, err := keyFromSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log(ctx).WithField("static_account", name).Info("GCP service account key generated successfully")
+	return response, nil
+}
@@ -0,0 +1,139 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrMountExists is returned when enabling a secrets engine at a path that is
+// already mounted.
+var ErrMountExists = errors.New("a secrets engine is already mounted at this path")
+
+// MountRequest enables a secrets engine of Type. Options are passed to the
+// engine as is, such as {"version": "2"} for KV.
+type MountRequest struct {
+	Type        string            `json:"type" binding:"required"`
+	Description string            `json:"description,omitempty"`
+	Config      MountTuning       `json:"config,omitempty"`
+	Options     map[string]string `json:"options,omitempty"`
+}
+
+// MountResponse is a mounted secrets engine. TTLs are in seconds; 0 uses the
+// system default.
+type MountResponse struct {
+	Path            string            `json:"path"`
+	Type            string            `json:"type"`
+	Description     string            `json:"description"`
+	Accessor        string            `json:"accessor"`
+	DefaultLeaseTTL int               `json:"default_lease_ttl"`
+	MaxLeaseTTL     int               `json:"max_lease_ttl"`
+	Options         map[string]string `json:"options,omitempty"`
+	Local           bool              `json:"local"`
+	SealWrap        bool              `json:"seal_wrap"`
+}
+
+// ListMounts lists the secrets engines mounted in Vault, sorted by path.
+func (c *Client) ListMounts(ctx context.Context) ([]MountResponse, error) {
+	mounts, err := c.client.Sys().ListMountsWithContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mounts: %w", err)
+	}
+
+	response := make([]MountResponse, 0, len(mounts))
+	for path, mount := range mounts {
+		response = append(response, MountResponse{
+			Path:            strings.TrimSuffix(path, "/"),
+			Type:            mount.Type,
+			Description:     mount.Description,
+			Accessor:        mount.Accessor,
+			DefaultLeaseTTL: mount.Config.DefaultLeaseTTL,
+			MaxLeaseTTL:     mount.Config.MaxLeaseTTL,
+			Options:         mount.Options,
+			Local:           mount.Local,
+			SealWrap:        mount.SealWrap,
+		})
+	}
+	sort.Slice(response, func(i, j int) bool {
+		return response[i].Path < response[j].Path
+	})
+
+	return response, nil
+}
+
+// EnableMount mounts a secrets engine at path.
+func (c *Client) EnableMount(ctx context.Context, path string, req *MountRequest) error {
+	path = strings.Trim(path, "/")
+
+	exists, err := c.hasMount(ctx, path)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrMountExists
+	}
+
+	return c.mountEngine(ctx, path, &api.MountInput{
+		Type:        req.Type,
+		Description: req.Description,
+		Config: api.MountConfigInput{
+			DefaultLeaseTTL: req.Config.DefaultLeaseTTL,
+			MaxLeaseTTL:     req.Config.MaxLeaseTTL,
+		},
+		Options: req.Options,
+	})
+}
+
+// DisableMount unmounts the secrets engine at path. Vault revokes all of its
+// leases and deletes all of its data.
+func (c *Client) DisableMount(ctx context.Context, path string) error {
+	path = strings.Trim(path, "/")
+	c.log(ctx).WithField("path", path).Info("Disabling secrets engine...")
+
+	exists, err := c.hasMount(ctx, path)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+
+	if err := c.client.Sys().UnmountWithContext(ctx, path); err != nil {
+		return fmt.Errorf("failed to disable secrets engine: %w", err)
+	}
+
+	c.log(ctx).WithField("path", path).Info("Secrets engine disabled successfully")
+	return nil
+}
+
+// TuneMountPath changes the lease TTLs of the mount at path and returns the
+// resulting values. Empty fields keep their values.
+func (c *Client) TuneMountPath(ctx context.Context, path string, tuning *MountTuning) (*MountTuningResponse, error) {
+	path = strings.Trim(path, "/")
+	c.log(ctx).WithFields(logrus.Fields{
+		"path":              path,
+		"default_lease_ttl": tuning.DefaultLeaseTTL,
+		"max_lease_ttl":     tuning.MaxLeaseTTL,
+	}).Info("Tuning secrets engine mount...")
+
+	exists, err := c.hasMount(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+
+	response, err := c.tuneMount(ctx, path, tuning)
+	if err != nil {
+		return nil, fmt.Errorf("failed to tune secrets engine mount: %w", err)
+	}
+
+	c.log(ctx).WithField("path", path).Info("Secrets engine mount tuned successfully")
+	return response, nil
+}
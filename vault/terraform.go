@@ -0,0 +1,167 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// TerraformRoleRequest defines the Terraform Cloud token a role issues: a
+// user token with UserID, a team token with Organization and TeamID, or an
+// organization token with only Organization. Team and organization tokens
+// are not leased; Vault rotates the single token on each request.
+type TerraformRoleRequest struct {
+	Organization string `json:"organization,omitempty"`
+	TeamID       string `json:"team_id,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+	TTL          string `json:"ttl,omitempty"`
+	MaxTTL       string `json:"max_ttl,omitempty"`
+}
+
+type TerraformRoleResponse struct {
+	Name         string `json:"name"`
+	Organization string `json:"organization,omitempty"`
+	TeamID       string `json:"team_id,omitempty"`
+	UserID       string `json:"user_id,omitempty"`
+	TTL          int64  `json:"ttl"`
+	MaxTTL       int64  `json:"max_ttl"`
+}
+
+type TerraformTokenResponse struct {
+	Token        string `json:"token"`
+	TokenID      string `json:"token_id"`
+	Organization string `json:"organization,omitempty"`
+	TeamID       string `json:"team_id,omitempty"`
+	Lease
+}
+
+// InitializeTerraform enables the Terraform Cloud secrets engine and
+// configures its access to Terraform Cloud or Enterprise.
+func (c *Client) InitializeTerraform(ctx context.Context) error {
+	mount := c.config.Terraform.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault Terraform secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "terraform", "Terraform Cloud secrets engine for issuing API tokens"); err != nil {
+		return err
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, mount+"/config", map[string]interface{}{
+		"address": c.config.Terraform.Address,
+		"token":   c.config.Terraform.Token,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to configure Terraform engine: %w", err)
+	}
+
+	c.log(ctx).Info("Vault Terraform secrets engine initialized successfully")
+	return nil
+}
+
+// WriteTerraformRole creates a Terraform role or updates an existing one.
+func (c *Client) WriteTerraformRole(ctx context.Context, name string, req *TerraformRoleRequest) error {
+	c.log(ctx).WithField("terraform_role", name).Info("Writing Terraform role...")
+
+	data := map[string]interface{}{}
+	if req.Organization != "" {
+		data["organization"] = req.Organization
+	}
+	if req.TeamID != "" {
+		data["team_id"] = req.TeamID
+	}
+	if req.UserID != "" {
+		data["user_id"] = req.UserID
+	}
+	if req.TTL != "" {
+		data["ttl"] = req.TTL
+	}
+	if req.MaxTTL != "" {
+		data["max_ttl"] = req.MaxTTL
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.Terraform.MountPath, name), data)
+	if err != nil {
+		return fmt.Errorf("failed to write Terraform role: %w", err)
+	}
+
+	c.log(ctx).WithField("terraform_role", name).Info("Terraform role written successfully")
+	return nil
+}
+
+func (c *Client) GetTerraformRole(ctx context.Context, name string) (*TerraformRoleResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.Terraform.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Terraform role: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &TerraformRoleResponse{
+		Name:   name,
+		TTL:    int64Value(secret.Data["ttl"]),
+		MaxTTL: int64Value(secret.Data["max_ttl"]),
+	}
+	response.Organization, _ = secret.Data["organization"].(string)
+	response.TeamID, _ = secret.Data["team_id"].(string)
+	response.UserID, _ = secret.Data["user_id"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListTerraformRoles(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing Terraform roles...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.Terraform.MountPath+"/role")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Terraform roles: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+func (c *Client) DeleteTerraformRole(ctx context.Context, name string) error {
+	c.log(ctx).WithField("terraform_role", name).Info("Deleting Terraform role...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/role/%s", c.config.Terraform.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete Terraform role: %w", err)
+	}
+
+	c.log(ctx).WithField("terraform_role", name).Info("Terraform role deleted successfully")
+	return nil
+}
+
+// GetTerraformToken returns a Terraform Cloud token for the role. User
+// tokens are deleted when their lease expires or is revoked; team and
+// organization tokens replace the role's previous token.
+func (c *Client) GetTerraformToken(ctx context.Context, name string) (*TerraformTokenResponse, error) {
+	c.log(ctx).WithField("terraform_role", name).Info("Generating Terraform token...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/creds/%s", c.config.Terraform.MountPath, name))
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetTerraformRole(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("Terraform role %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Terraform token: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no token data returned")
+	}
+
+	response := &TerraformTokenResponse{Lease: leaseFromSecret(secret)}
+	response.Token, _ = secret.Data["token"].(string)
+	response.TokenID, _ = secret.Data["token_id"].(string)
+	response.Organization, _ = secret.Data["organization"].(string)
+	response.TeamID, _ = secret.Data["team_id"].(string)
+
+	c.log(ctx).WithField("terraform_role", name).Info("Terraform token generated successfully")
+	return response, nil
+}
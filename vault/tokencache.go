@@ -0,0 +1,122 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const healthCacheKey = "health:vault"
+
+// tokenCacheKey includes the namespace, since rolesets of the same name in
+// different namespaces are unrelated.
+func (c *Client) tokenCacheKey(kind, name, ttl string) string {
+	return fmt.Sprintf("token:%s:%s:%s:%s:%s", c.Namespace(), c.mount, kind, name, ttl)
+}
+
+// cachedTokenEntry is a cached access token along with when it was cached.
+type cachedTokenEntry struct {
+	Token    *TokenResponse `json:"token"`
+	CachedAt int64          `json:"cached_at"`
+}
+
+// TokenCacheInfo tells whether a token was served from the cache and since
+// when it has been cached.
+type TokenCacheInfo struct {
+	Hit      bool
+	CachedAt time.Time
+}
+
+// cachedToken returns a cached access token when token caching is enabled.
+// Cache errors are logged and treated as a miss.
+func (c *Client) cachedToken(ctx context.Context, key string) (*TokenResponse, time.Time, bool) {
+	if !c.config.Cache.Tokens {
+		return nil, time.Time{}, false
+	}
+
+	value, ok, err := c.cache.Get(ctx, key)
+	if err != nil {
+		c.log(ctx).WithError(err).Warn("Failed to read token cache")
+		return nil, time.Time{}, false
+	}
+	if !ok {
+		return nil, time.Time{}, false
+	}
+
+	var entry cachedTokenEntry
+	if err := json.Unmarshal(value, &entry); err != nil || entry.Token == nil {
+		c.log(ctx).WithError(err).Warn("Discarding unreadable cached token")
+		return nil, time.Time{}, false
+	}
+
+	return entry.Token, time.Unix(entry.CachedAt, 0), true
+}
+
+// cacheToken stores an access token until shortly before it expires.
+func (c *Client) cacheToken(ctx context.Context, key string, token *TokenResponse) {
+	if !c.config.Cache.Tokens || token.ExpiresAtSeconds == 0 {
+		return
+	}
+
+	ttl := time.Until(time.Unix(token.ExpiresAtSeconds, 0)) - c.config.Cache.TokenRefreshMargin
+	if ttl <= 0 {
+		return
+	}
+
+	value, err := json.Marshal(cachedTokenEntry{Token: token, CachedAt: time.Now().Unix()})
+	if err != nil {
+		return
+	}
+
+	if err := c.cache.Set(ctx, key, value, ttl); err != nil {
+		c.log(ctx).WithError(err).Warn("Failed to write token cache")
+	}
+}
+
+// invalidateToken drops a cached access token, e.g. after the credentials
+// that minted it were rotated.
+func (c *Client) invalidateToken(ctx context.Context, key string) {
+	if !c.config.Cache.Tokens {
+		return
+	}
+
+	if err := c.cache.Invalidate(ctx, key); err != nil {
+		c.log(ctx).WithError(err).Warn("Failed to invalidate cached token")
+	}
+}
+
+// WarmTokens pre-fetches access tokens for the configured hot rolesets that
+// are not cached yet.
+func (c *Client) WarmTokens(ctx context.Context) {
+	for _, roleset := range c.config.Cache.WarmRolesets {
+		if _, _, ok := c.cachedToken(ctx, c.tokenCacheKey("roleset", roleset, "")); ok {
+			continue
+		}
+
+		if _, err := c.GetToken(ctx, roleset, ""); err != nil {
+			c.log(ctx).WithError(err).WithField("roleset", roleset).Warn("Failed to warm token cache")
+		}
+	}
+}
+
+// RunTokenWarmer keeps the hot rolesets' tokens cached, refilling entries
+// that expired or were flushed, until ctx is done.
+func (c *Client) RunTokenWarmer(ctx context.Context) {
+	if !c.config.Cache.Tokens {
+		c.log(ctx).Warn("Hot rolesets configured but token caching is disabled; skipping warm-up")
+		return
+	}
+
+	ticker := time.NewTicker(c.config.Cache.WarmInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.WarmTokens(ctx)
+		}
+	}
+}
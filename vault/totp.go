@@ -0,0 +1,209 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TOTPKeyRequest creates a TOTP key. With Generate, Vault generates the
+// key and acts as the provider; otherwise the key is imported from URL or
+// Key and Vault acts as the generator of codes.
+type TOTPKeyRequest struct {
+	Generate    bool   `json:"generate"`
+	Issuer      string `json:"issuer,omitempty"`
+	AccountName string `json:"account_name,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Key         string `json:"key,omitempty"`
+	Algorithm   string `json:"algorithm,omitempty" binding:"omitempty,oneof=SHA1 SHA256 SHA512"`
+	Digits      int    `json:"digits,omitempty" binding:"omitempty,oneof=6 8"`
+	Period      string `json:"period,omitempty"`
+	Skew        *int   `json:"skew,omitempty" binding:"omitempty,oneof=0 1"`
+}
+
+// TOTPKeyCreatedResponse holds the barcode and URL of a generated key. They
+// are only returned once.
+type TOTPKeyCreatedResponse struct {
+	Name    string `json:"name"`
+	Barcode string `json:"barcode,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+type TOTPKeyResponse struct {
+	Name        string `json:"name"`
+	Issuer      string `json:"issuer"`
+	AccountName string `json:"account_name"`
+	Algorithm   string `json:"algorithm"`
+	Digits      int64  `json:"digits"`
+	Period      int64  `json:"period"`
+}
+
+// InitializeTOTP enables the TOTP secrets engine.
+func (c *Client) InitializeTOTP(ctx context.Context) error {
+	mount := c.config.TOTP.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault TOTP secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "totp", "TOTP secrets engine for generating and validating one-time codes"); err != nil {
+		return err
+	}
+
+	c.log(ctx).Info("Vault TOTP secrets engine initialized successfully")
+	return nil
+}
+
+// CreateTOTPKey creates a TOTP key. Keys cannot be updated, only deleted and
+// created again.
+func (c *Client) CreateTOTPKey(ctx context.Context, name string, req *TOTPKeyRequest) (*TOTPKeyCreatedResponse, error) {
+	c.log(ctx).WithFields(logrus.Fields{
+		"totp_key": name,
+		"generate": req.Generate,
+	}).Info("Creating TOTP key...")
+
+	data := map[string]interface{}{
+		"generate": req.Generate,
+	}
+	if req.Issuer != "" {
+		data["issuer"] = req.Issuer
+	}
+	if req.AccountName != "" {
+		data["account_name"] = req.AccountName
+	}
+	if req.URL != "" {
+		data["url"] = req.URL
+	}
+	if req.Key != "" {
+		data["key"] = req.Key
+	}
+	if req.Algorithm != "" {
+		data["algorithm"] = req.Algorithm
+	}
+	if req.Digits != 0 {
+		data["digits"] = req.Digits
+	}
+	if req.Period != "" {
+		data["period"] = req.Period
+	}
+	if req.Skew != nil {
+		data["skew"] = *req.Skew
+	}
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/keys/%s", c.config.TOTP.MountPath, name), data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create TOTP key: %w", err)
+	}
+
+	response := &TOTPKeyCreatedResponse{Name: name}
+	if secret != nil && secret.Data != nil {
+		response.Barcode, _ = secret.Data["barcode"].(string)
+		response.URL, _ = secret.Data["url"].(string)
+	}
+
+	c.log(ctx).WithField("totp_key", name).Info("TOTP key created successfully")
+	return response, nil
+}
+
+func (c *Client) GetTOTPKey(ctx context.Context, name string) (*TOTPKeyResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/keys/%s", c.config.TOTP.MountPath, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TOTP key: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &TOTPKeyResponse{
+		Name:   name,
+		Digits: int64Value(secret.Data["digits"]),
+		Period: int64Value(secret.Data["period"]),
+	}
+	response.Issuer, _ = secret.Data["issuer"].(string)
+	response.AccountName, _ = secret.Data["account_name"].(string)
+	response.Algorithm, _ = secret.Data["algorithm"].(string)
+
+	return response, nil
+}
+
+func (c *Client) ListTOTPKeys(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing TOTP keys...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.TOTP.MountPath+"/keys")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TOTP keys: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+func (c *Client) DeleteTOTPKey(ctx context.Context, name string) error {
+	c.log(ctx).WithField("totp_key", name).Info("Deleting TOTP key...")
+
+	_, err := c.client.Logical().DeleteWithContext(ctx, fmt.Sprintf("%s/keys/%s", c.config.TOTP.MountPath, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete TOTP key: %w", err)
+	}
+
+	c.log(ctx).WithField("totp_key", name).Info("TOTP key deleted successfully")
+	return nil
+}
+
+// GenerateTOTPCode returns the current code of a key. Only keys Vault
+// generates codes for can be used, not keys created with generate.
+func (c *Client) GenerateTOTPCode(ctx context.Context, name string) (string, error) {
+	c.log(ctx).WithField("totp_key", name).Info("Generating TOTP code...")
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/code/%s", c.config.TOTP.MountPath, name))
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetTOTPKey(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return "", fmt.Errorf("TOTP key %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to generate TOTP code: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return "", fmt.Errorf("no code returned")
+	}
+
+	code, _ := secret.Data["code"].(string)
+
+	c.log(ctx).WithField("totp_key", name).Info("TOTP code generated successfully")
+	return code, nil
+}
+
+// ValidateTOTPCode reports whether code is valid for the key. Vault rejects
+// a code that was already used within its period.
+func (c *Client) ValidateTOTPCode(ctx context.Context, name, code string) (bool, error) {
+	c.log(ctx).WithField("totp_key", name).Info("Validating TOTP code...")
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/code/%s", c.config.TOTP.MountPath, name), map[string]interface{}{
+		"code": code,
+	})
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetTOTPKey(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return false, fmt.Errorf("TOTP key %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to validate TOTP code: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return false, fmt.Errorf("no validation result returned")
+	}
+
+	valid, _ := secret.Data["valid"].(bool)
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"totp_key": name,
+		"valid":    valid,
+	}).Info("TOTP code validated successfully")
+	return valid, nil
+}
@@ -0,0 +1,181 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// TransitSignRequest signs base64 encoded Input with an asymmetric key.
+// With Prehashed, Input is already a digest of HashAlgorithm.
+type TransitSignRequest struct {
+	Input               string `json:"input" binding:"required,base64"`
+	HashAlgorithm       string `json:"hash_algorithm,omitempty"`
+	Prehashed           bool   `json:"prehashed,omitempty"`
+	SignatureAlgorithm  string `json:"signature_algorithm,omitempty" binding:"omitempty,oneof=pss pkcs1v15"`
+	MarshalingAlgorithm string `json:"marshaling_algorithm,omitempty" binding:"omitempty,oneof=asn1 jws"`
+	KeyVersion          int    `json:"key_version,omitempty"`
+	// Context is required for keys with key derivation
+	Context string `json:"context,omitempty" binding:"omitempty,base64"`
+}
+
+type TransitSignResponse struct {
+	Signature  string `json:"signature"`
+	KeyVersion int64  `json:"key_version,omitempty"`
+}
+
+// TransitVerifyRequest verifies either a Signature or an HMAC of Input.
+type TransitVerifyRequest struct {
+	Input               string `json:"input" binding:"required,base64"`
+	Signature           string `json:"signature,omitempty"`
+	HMAC                string `json:"hmac,omitempty"`
+	HashAlgorithm       string `json:"hash_algorithm,omitempty"`
+	Prehashed           bool   `json:"prehashed,omitempty"`
+	SignatureAlgorithm  string `json:"signature_algorithm,omitempty" binding:"omitempty,oneof=pss pkcs1v15"`
+	MarshalingAlgorithm string `json:"marshaling_algorithm,omitempty" binding:"omitempty,oneof=asn1 jws"`
+	Context             string `json:"context,omitempty" binding:"omitempty,base64"`
+}
+
+type TransitHMACRequest struct {
+	Input      string `json:"input" binding:"required,base64"`
+	Algorithm  string `json:"algorithm,omitempty" binding:"omitempty,oneof=sha2-224 sha2-256 sha2-384 sha2-512 sha3-224 sha3-256 sha3-384 sha3-512"`
+	KeyVersion int    `json:"key_version,omitempty"`
+}
+
+type TransitHMACResponse struct {
+	HMAC string `json:"hmac"`
+}
+
+// InitializeTransit enables the Transit secrets engine.
+func (c *Client) InitializeTransit(ctx context.Context) error {
+	mount := c.config.Transit.MountPath
+	c.log(ctx).WithField("mount", mount).Info("Initializing Vault Transit secrets engine...")
+
+	if err := c.enableEngine(ctx, mount, "transit", "Transit secrets engine for cryptographic operations"); err != nil {
+		return err
+	}
+
+	c.log(ctx).Info("Vault Transit secrets engine initialized successfully")
+	return nil
+}
+
+// TransitSign signs input with the key. Vault prefixes the signature with
+// the key version, e.g. vault:v1:.
+func (c *Client) TransitSign(ctx context.Context, name string, req *TransitSignRequest) (*TransitSignResponse, error) {
+	data := map[string]interface{}{
+		"input":     req.Input,
+		"prehashed": req.Prehashed,
+	}
+	if req.HashAlgorithm != "" {
+		data["hash_algorithm"] = req.HashAlgorithm
+	}
+	if req.SignatureAlgorithm != "" {
+		data["signature_algorithm"] = req.SignatureAlgorithm
+	}
+	if req.MarshalingAlgorithm != "" {
+		data["marshaling_algorithm"] = req.MarshalingAlgorithm
+	}
+	if req.KeyVersion != 0 {
+		data["key_version"] = req.KeyVersion
+	}
+	if req.Context != "" {
+		data["context"] = req.Context
+	}
+
+	secret, err := c.transitOperation(ctx, "sign", name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransitSignResponse{KeyVersion: int64Value(secret.Data["key_version"])}
+	response.Signature, _ = secret.Data["signature"].(string)
+	return response, nil
+}
+
+// TransitVerify verifies a signature or an HMAC of input.
+func (c *Client) TransitVerify(ctx context.Context, name string, req *TransitVerifyRequest) (bool, error) {
+	data := map[string]interface{}{
+		"input":     req.Input,
+		"prehashed": req.Prehashed,
+	}
+	if req.Signature != "" {
+		data["signature"] = req.Signature
+	}
+	if req.HMAC != "" {
+		data["hmac"] = req.HMAC
+	}
+	if req.HashAlgorithm != "" {
+		data["hash_algorithm"] = req.HashAlgorithm
+	}
+	if req.SignatureAlgorithm != "" {
+		data["signature_algorithm"] = req.SignatureAlgorithm
+	}
+	if req.MarshalingAlgorithm != "" {
+		data["marshaling_algorithm"] = req.MarshalingAlgorithm
+	}
+	if req.Context != "" {
+		data["context"] = req.Context
+	}
+
+	secret, err := c.transitOperation(ctx, "verify", name, data)
+	if err != nil {
+		return false, err
+	}
+
+	valid, _ := secret.Data["valid"].(bool)
+	return valid, nil
+}
+
+// TransitHMAC computes the HMAC of input with the key.
+func (c *Client) TransitHMAC(ctx context.Context, name string, req *TransitHMACRequest) (*TransitHMACResponse, error) {
+	data := map[string]interface{}{
+		"input": req.Input,
+	}
+	if req.Algorithm != "" {
+		data["algorithm"] = req.Algorithm
+	}
+	if req.KeyVersion != 0 {
+		data["key_version"] = req.KeyVersion
+	}
+
+	secret, err := c.transitOperation(ctx, "hmac", name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TransitHMACResponse{}
+	response.HMAC, _ = secret.Data["hmac"].(string)
+	return response, nil
+}
+
+// transitOperation runs a cryptographic operation with a Transit key. A
+// missing key is reported as ErrNotFound.
+func (c *Client) transitOperation(ctx context.Context, operation, name string, data map[string]interface{}) (*api.Secret, error) {
+	c.log(ctx).WithFields(logrus.Fields{
+		"transit_key": name,
+		"operation":   operation,
+	}).Info("Running Transit operation...")
+
+	secret, err := c.client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/%s/%s", c.config.Transit.MountPath, operation, name), data)
+	if err != nil || secret == nil {
+		if _, lookupErr := c.GetTransitKey(ctx, name); errors.Is(lookupErr, ErrNotFound) {
+			return nil, fmt.Errorf("Transit key %q: %w", name, ErrNotFound)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s with Transit key: %w", operation, err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no %s result returned", operation)
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"transit_key": name,
+		"operation":   operation,
+	}).Info("Transit operation completed successfully")
+	return secret, nil
+}
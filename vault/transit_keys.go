@@ -0,0 +1,260 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// ErrKeyNotExportable is returned when exporting key material of a Transit
+// key that was not created exportable.
+var ErrKeyNotExportable = errors.New("key is not exportable")
+
+// TransitKeyRequest creates a Transit key. Exportable and
+// AllowPlaintextBackup cannot be turned off once enabled.
+type TransitKeyRequest struct {
+	Type                 string `json:"type,omitempty" binding:"omitempty,oneof=aes128-gcm96 aes256-gcm96 chacha20-poly1305 ed25519 ecdsa-p256 ecdsa-p384 ecdsa-p521 rsa-2048 rsa-3072 rsa-4096 hmac"`
+	Exportable           bool   `json:"exportable"`
+	AllowPlaintextBackup bool   `json:"allow_plaintext_backup"`
+	Derived              bool   `json:"derived"`
+	ConvergentEncryption bool   `json:"convergent_encryption"`
+	AutoRotatePeriod     string `json:"auto_rotate_period,omitempty"`
+}
+
+// TransitKeyConfigRequest updates the settings of a Transit key. Unset fields
+// are left unchanged. Deletion is only allowed through DeleteTransitKey.
+type TransitKeyConfigRequest struct {
+	MinDecryptionVersion *int    `json:"min_decryption_version,omitempty" binding:"omitempty,min=0"`
+	MinEncryptionVersion *int    `json:"min_encryption_version,omitempty" binding:"omitempty,min=0"`
+	Exportable           *bool   `json:"exportable,omitempty"`
+	AllowPlaintextBackup *bool   `json:"allow_plaintext_backup,omitempty"`
+	AutoRotatePeriod     *string `json:"auto_rotate_period,omitempty"`
+}
+
+type TransitKeyResponse struct {
+	Name                 string `json:"name"`
+	Type                 string `json:"type"`
+	LatestVersion        int64  `json:"latest_version"`
+	MinDecryptionVersion int64  `json:"min_decryption_version"`
+	MinEncryptionVersion int64  `json:"min_encryption_version"`
+	Exportable           bool   `json:"exportable"`
+	AllowPlaintextBackup bool   `json:"allow_plaintext_backup"`
+	DeletionAllowed      bool   `json:"deletion_allowed"`
+	Derived              bool   `json:"derived"`
+	SupportsEncryption   bool   `json:"supports_encryption"`
+	SupportsSigning      bool   `json:"supports_signing"`
+	AutoRotatePeriod     int64  `json:"auto_rotate_period"`
+	// Versions lists the key versions that still exist
+	Versions []int `json:"versions"`
+}
+
+// CreateTransitKey creates a Transit key. Creating an existing key leaves it
+// unchanged.
+func (c *Client) CreateTransitKey(ctx context.Context, name string, req *TransitKeyRequest) error {
+	c.log(ctx).WithField("transit_key", name).Info("Creating Transit key...")
+
+	data := map[string]interface{}{
+		"exportable":             req.Exportable,
+		"allow_plaintext_backup": req.AllowPlaintextBackup,
+		"derived":                req.Derived,
+		"convergent_encryption":  req.ConvergentEncryption,
+	}
+	if req.Type != "" {
+		data["type"] = req.Type
+	}
+	if req.AutoRotatePeriod != "" {
+		data["auto_rotate_period"] = req.AutoRotatePeriod
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, c.transitKeyPath(name), data)
+	if err != nil {
+		return fmt.Errorf("failed to create Transit key: %w", err)
+	}
+
+	c.log(ctx).WithField("transit_key", name).Info("Transit key created successfully")
+	return nil
+}
+
+func (c *Client) GetTransitKey(ctx context.Context, name string) (*TransitKeyResponse, error) {
+	secret, err := c.client.Logical().ReadWithContext(ctx, c.transitKeyPath(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Transit key: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, ErrNotFound
+	}
+
+	response := &TransitKeyResponse{
+		Name:                 name,
+		LatestVersion:        int64Value(secret.Data["latest_version"]),
+		MinDecryptionVersion: int64Value(secret.Data["min_decryption_version"]),
+		MinEncryptionVersion: int64Value(secret.Data["min_encryption_version"]),
+		AutoRotatePeriod:     int64Value(secret.Data["auto_rotate_period"]),
+		Versions:             []int{},
+	}
+	response.Type, _ = secret.Data["type"].(string)
+	response.Exportable, _ = secret.Data["exportable"].(bool)
+	response.AllowPlaintextBackup, _ = secret.Data["allow_plaintext_backup"].(bool)
+	response.DeletionAllowed, _ = secret.Data["deletion_allowed"].(bool)
+	response.Derived, _ = secret.Data["derived"].(bool)
+	response.SupportsEncryption, _ = secret.Data["supports_encryption"].(bool)
+	response.SupportsSigning, _ = secret.Data["supports_signing"].(bool)
+
+	keys, _ := secret.Data["keys"].(map[string]interface{})
+	for version := range keys {
+		if n, err := strconv.Atoi(version); err == nil {
+			response.Versions = append(response.Versions, n)
+		}
+	}
+	sort.Ints(response.Versions)
+
+	return response, nil
+}
+
+func (c *Client) ListTransitKeys(ctx context.Context) ([]string, error) {
+	c.log(ctx).Info("Listing Transit keys...")
+
+	secret, err := c.client.Logical().ListWithContext(ctx, c.config.Transit.MountPath+"/keys")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Transit keys: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return []string{}, nil
+	}
+
+	return stringSlice(secret.Data["keys"]), nil
+}
+
+// RotateTransitKey adds a new version of the key and makes it the latest.
+func (c *Client) RotateTransitKey(ctx context.Context, name string) error {
+	c.log(ctx).WithField("transit_key", name).Info("Rotating Transit key...")
+
+	if _, err := c.GetTransitKey(ctx, name); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("Transit key %q: %w", name, ErrNotFound)
+		}
+		return err
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, c.transitKeyPath(name)+"/rotate", nil)
+	if err != nil {
+		return fmt.Errorf("failed to rotate Transit key: %w", err)
+	}
+
+	c.log(ctx).WithField("transit_key", name).Info("Transit key rotated successfully")
+	return nil
+}
+
+// ConfigureTransitKey updates the key's settings. Raising
+// min_decryption_version makes older ciphertexts undecryptable.
+func (c *Client) ConfigureTransitKey(ctx context.Context, name string, req *TransitKeyConfigRequest) error {
+	c.log(ctx).WithField("transit_key", name).Info("Configuring Transit key...")
+
+	if _, err := c.GetTransitKey(ctx, name); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("Transit key %q: %w", name, ErrNotFound)
+		}
+		return err
+	}
+
+	data := map[string]interface{}{}
+	if req.MinDecryptionVersion != nil {
+		data["min_decryption_version"] = *req.MinDecryptionVersion
+	}
+	if req.MinEncryptionVersion != nil {
+		data["min_encryption_version"] = *req.MinEncryptionVersion
+	}
+	if req.Exportable != nil {
+		data["exportable"] = *req.Exportable
+	}
+	if req.AllowPlaintextBackup != nil {
+		data["allow_plaintext_backup"] = *req.AllowPlaintextBackup
+	}
+	if req.AutoRotatePeriod != nil {
+		data["auto_rotate_period"] = *req.AutoRotatePeriod
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, c.transitKeyPath(name)+"/config", data)
+	if err != nil {
+		return fmt.Errorf("failed to configure Transit key: %w", err)
+	}
+
+	c.log(ctx).WithField("transit_key", name).Info("Transit key configured successfully")
+	return nil
+}
+
+// ExportTransitKey returns key material by version. keyType is
+// encryption-key, signing-key, hmac-key or public-key; only public keys can
+// be exported from keys that are not exportable. An empty version exports
+// every version.
+func (c *Client) ExportTransitKey(ctx context.Context, name, keyType, version string) (map[string]interface{}, error) {
+	c.log(ctx).WithField("transit_key", name).Info("Exporting Transit key...")
+
+	key, err := c.GetTransitKey(ctx, name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("Transit key %q: %w", name, ErrNotFound)
+		}
+		return nil, err
+	}
+	if keyType != "public-key" && !key.Exportable {
+		return nil, ErrKeyNotExportable
+	}
+
+	path := fmt.Sprintf("%s/export/%s/%s", c.config.Transit.MountPath, keyType, name)
+	if version != "" {
+		path += "/" + version
+	}
+
+	secret, err := c.client.Logical().ReadWithContext(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export Transit key: %w", err)
+	}
+
+	if secret == nil || secret.Data == nil {
+		return nil, fmt.Errorf("no key material returned")
+	}
+
+	keys, _ := secret.Data["keys"].(map[string]interface{})
+	if keys == nil {
+		keys = map[string]interface{}{}
+	}
+
+	c.log(ctx).WithField("transit_key", name).Info("Transit key exported successfully")
+	return keys, nil
+}
+
+// DeleteTransitKey allows deletion of the key and deletes it. Everything
+// encrypted with the key becomes undecryptable.
+func (c *Client) DeleteTransitKey(ctx context.Context, name string) error {
+	c.log(ctx).WithField("transit_key", name).Warn("Deleting Transit key...")
+
+	if _, err := c.GetTransitKey(ctx, name); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return fmt.Errorf("Transit key %q: %w", name, ErrNotFound)
+		}
+		return err
+	}
+
+	_, err := c.client.Logical().WriteWithContext(ctx, c.transitKeyPath(name)+"/config", map[string]interface{}{
+		"deletion_allowed": true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to allow Transit key deletion: %w", err)
+	}
+
+	if _, err := c.client.Logical().DeleteWithContext(ctx, c.transitKeyPath(name)); err != nil {
+		return fmt.Errorf("failed to delete Transit key: %w", err)
+	}
+
+	c.log(ctx).WithField("transit_key", name).Info("Transit key deleted successfully")
+	return nil
+}
+
+func (c *Client) transitKeyPath(name string) string {
+	return fmt.Sprintf("%s/keys/%s", c.config.Transit.MountPath, name)
+}
@@ -0,0 +1,219 @@
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrWrappingToken is returned when a wrapping token is invalid, expired or
+// already unwrapped.
+var ErrWrappingToken = errors.New("wrapping token is invalid, expired or already used")
+
+// ErrWrappedSecretType is returned when a wrapping token does not wrap a GCP
+// access token or service account key. Such tokens are left untouched.
+var ErrWrappedSecretType = errors.New("wrapping token does not wrap a GCP access token or service account key")
+
+// Types of the secrets Unwrap returns
+const (
+	WrappedAccessToken       = "access_token"
+	WrappedServiceAccountKey = "service_account_key"
+)
+
+// WrappedSecretResponse stands in for a secret Vault response-wrapped. Only
+// the first party to unwrap WrappingToken gets the secret.
+type WrappedSecretResponse struct {
+	WrappingToken    string    `json:"wrapping_token"`
+	WrappingAccessor string    `json:"wrapping_accessor"`
+	TTL              int       `json:"ttl"`
+	CreationTime     time.Time `json:"creation_time"`
+	CreationPath     string    `json:"creation_path"`
+}
+
+// UnwrappedSecretResponse is the secret a wrapping token stood in for: an
+// access token or a service account key of the roleset on Mount.
+type UnwrappedSecretResponse struct {
+	Type    string                     `json:"type"`
+	Mount   string                     `json:"mount"`
+	Roleset string                     `json:"roleset"`
+	Token   *TokenResponse             `json:"token,omitempty"`
+	Key     *ServiceAccountKeyResponse `json:"key,omitempty"`
+}
+
+// GetWrappedToken generates an access token for a roleset, response-wrapped
+// by Vault for wrapTTL. Wrapped tokens bypass the token cache, so hcvapi
+// never holds the token itself.
+func (c *Client) GetWrappedToken(ctx context.Context, rolesetName, ttl, wrapTTL string) (*WrappedSecretResponse, error) {
+	c.log(ctx).WithField("roleset", rolesetName).Info("Generating wrapped GCP access token...")
+
+	client, err := c.wrappingClient(wrapTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret *api.Secret
+	if ttl != "" {
+		secret, err = client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/token/%s", c.mount, rolesetName), map[string]interface{}{
+			"ttl": ttl,
+		})
+	} else {
+		secret, err = client.Logical().ReadWithContext(ctx, fmt.Sprintf("%s/token/%s", c.mount, rolesetName))
+	}
+	if err != nil {
+		return nil, c.rolesetError(ctx, rolesetName, fmt.Errorf("failed to get wrapped access token: %w", err))
+	}
+
+	response, err := wrappedFromSecret(secret)
+	if err != nil {
+		return nil, c.rolesetError(ctx, rolesetName, err)
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"roleset":           rolesetName,
+		"wrapping_accessor": response.WrappingAccessor,
+	}).Info("Wrapped GCP access token generated successfully")
+	return response, nil
+}
+
+// GetWrappedServiceAccountKey generates a key for a service_account_key
+// roleset, response-wrapped by Vault for wrapTTL.
+func (c *Client) GetWrappedServiceAccountKey(ctx context.Context, rolesetName string, req *KeyRequest, wrapTTL string) (*WrappedSecretResponse, error) {
+	c.log(ctx).WithField("roleset", rolesetName).Info("Generating wrapped GCP service account key...")
+
+	client, err := c.wrappingClient(wrapTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := client.Logical().WriteWithContext(ctx, fmt.Sprintf("%s/key/%s", c.mount, rolesetName), req.data())
+	if err != nil {
+		return nil, c.rolesetError(ctx, rolesetName, fmt.Errorf("failed to get wrapped service account key: %w", err))
+	}
+
+	response, err := wrappedFromSecret(secret)
+	if err != nil {
+		return nil, c.rolesetError(ctx, rolesetName, err)
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"roleset":           rolesetName,
+		"wrapping_accessor": response.WrappingAccessor,
+	}).Info("Wrapped GCP service account key generated successfully")
+	return response, nil
+}
+
+// LookupWrapped looks up wrappingToken without using it up and returns the
+// type, mount and roleset of the GCP access token or service account key it
+// wraps on one of the configured mounts. Tokens wrapping anything else are
+// refused with ErrWrappedSecretType.
+func (c *Client) LookupWrapped(ctx context.Context, wrappingToken string) (*UnwrappedSecretResponse, error) {
+	lookup, err := c.client.Logical().WriteWithContext(ctx, "sys/wrapping/lookup", map[string]interface{}{
+		"token": wrappingToken,
+	})
+	if err != nil {
+		return nil, wrappingError("look up", err)
+	}
+	if lookup == nil || lookup.Data == nil {
+		return nil, fmt.Errorf("no wrapping data returned")
+	}
+
+	creationPath, _ := lookup.Data["creation_path"].(string)
+	response, ok := unwrappedFromPath(creationPath)
+	if !ok {
+		return nil, ErrWrappedSecretType
+	}
+	if _, known := c.mounts[response.Mount]; !known {
+		return nil, ErrWrappedSecretType
+	}
+	return response, nil
+}
+
+// Unwrap redeems wrappingToken, which LookupWrapped found to wrap the secret
+// described by response, and fills in the access token or key.
+func (c *Client) Unwrap(ctx context.Context, wrappingToken string, response *UnwrappedSecretResponse) (*UnwrappedSecretResponse, error) {
+	secret, err := c.client.Logical().UnwrapWithContext(ctx, wrappingToken)
+	if err != nil {
+		return nil, wrappingError("unwrap", err)
+	}
+
+	switch response.Type {
+	case WrappedAccessToken:
+		response.Token, err = tokenFromSecret(secret)
+	case WrappedServiceAccountKey:
+		response.Key, err = keyFromSecret(secret)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.log(ctx).WithFields(logrus.Fields{
+		"type":    response.Type,
+		"mount":   response.Mount,
+		"roleset": response.Roleset,
+	}).Info("Wrapped GCP secret unwrapped successfully")
+	return response, nil
+}
+
+// wrappingClient returns a copy of the Vault client whose responses are
+// wrapped for ttl.
+func (c *Client) wrappingClient(ttl string) (*api.Client, error) {
+	client, err := c.client.CloneWithHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone vault client: %w", err)
+	}
+	client.SetToken(c.client.Token())
+	client.SetWrappingLookupFunc(func(operation, path string) string {
+		return ttl
+	})
+	return client, nil
+}
+
+func wrappedFromSecret(secret *api.Secret) (*WrappedSecretResponse, error) {
+	if secret == nil || secret.WrapInfo == nil {
+		return nil, fmt.Errorf("no wrapping data returned")
+	}
+	return &WrappedSecretResponse{
+		WrappingToken:    secret.WrapInfo.Token,
+		WrappingAccessor: secret.WrapInfo.Accessor,
+		TTL:              secret.WrapInfo.TTL,
+		CreationTime:     secret.WrapInfo.CreationTime,
+		CreationPath:     secret.WrapInfo.CreationPath,
+	}, nil
+}
+
+// unwrappedFromPath recognizes the creation paths of GCP access tokens,
+// {mount}/token/{roleset}, and keys, {mount}/key/{roleset}.
+func unwrappedFromPath(creationPath string) (*UnwrappedSecretResponse, bool) {
+	segments := strings.Split(strings.Trim(creationPath, "/"), "/")
+	if len(segments) < 3 {
+		return nil, false
+	}
+
+	response := &UnwrappedSecretResponse{
+		Mount:   strings.Join(segments[:len(segments)-2], "/"),
+		Roleset: segments[len(segments)-1],
+	}
+	switch segments[len(segments)-2] {
+	case "token":
+		response.Type = WrappedAccessToken
+	case "key":
+		response.Type = WrappedServiceAccountKey
+	default:
+		return nil, false
+	}
+	return response, true
+}
+
+func wrappingError(operation string, err error) error {
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) && respErr.StatusCode == http.StatusBadRequest {
+		return ErrWrappingToken
+	}
+	return fmt.Errorf("failed to %s wrapping token: %w", operation, err)
+}
@@ -0,0 +1,330 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+// Event types.
+const (
+	EventRolesetCreated = "roleset.created"
+	EventRolesetDeleted = "roleset.deleted"
+	EventKeyIssued      = "key.issued"
+)
+
+// Headers sent with every delivery. The signature is
+//
+//	sha256=hex(HMAC-SHA256(secret, TIMESTAMP + "." + BODY))
+//
+// keyed with the endpoint's secret. The delivery ID stays the same across
+// retries, so receivers can drop duplicates.
+const (
+	EventHeader     = "X-HCVAPI-Event"
+	DeliveryHeader  = "X-HCVAPI-Delivery"
+	TimestampHeader = "X-HCVAPI-Timestamp"
+	SignatureHeader = "X-HCVAPI-Signature"
+)
+
+// Event is the payload POSTed to webhook endpoints. Key events name either
+// the roleset or the static account the key belongs to. Events never carry
+// the secrets themselves.
+type Event struct {
+	ID            string                 `json:"id"`
+	Type          string                 `json:"type"`
+	Time          time.Time              `json:"time"`
+	Mount         string                 `json:"mount,omitempty"`
+	Roleset       string                 `json:"roleset,omitempty"`
+	StaticAccount string                 `json:"static_account,omitempty"`
+	Caller        string                 `json:"caller"`
+	Tenant        string                 `json:"tenant,omitempty"`
+	RequestID     string                 `json:"request_id,omitempty"`
+	Data          map[string]interface{} `json:"data,omitempty"`
+}
+
+type delivery struct {
+	endpoint string
+	cfg      config.WebhookEndpointConfig
+	event    *Event
+	body     []byte
+}
+
+// Dispatcher delivers events to the subscribed endpoints in the background,
+// retrying failures with exponential backoff. Deliveries that still fail are
+// logged as dead letters with their payload, so they can be replayed by
+// hand. At most cfg.Workers deliveries are in flight; the rest queue up to
+// cfg.BufferSize, beyond which they are dropped. A nil Dispatcher discards
+// events.
+type Dispatcher struct {
+	cfg    config.WebhooksConfig
+	client *http.Client
+	logger *logrus.Logger
+
+	// Tenants whose events each tenant notification channel receives
+	channels map[string]map[string]bool
+
+	// Cancelled when Close gives up waiting, ending pending retries
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu         sync.RWMutex
+	closed     bool
+	deliveries chan delivery
+	done       chan struct{}
+
+	// Deliveries dropped because the buffer was full
+	dropped atomic.Uint64
+}
+
+// New creates a dispatcher for the configured endpoints, or returns nil when
+// there are none. Endpoints named as notification channels of tenants only
+// receive the events of those tenants.
+func New(cfg config.WebhooksConfig, tenants map[string]config.TenantConfig, logger *logrus.Logger) *Dispatcher {
+	if len(cfg.Endpoints) == 0 {
+		return nil
+	}
+
+	channels := make(map[string]map[string]bool)
+	for name, tenant := range tenants {
+		for _, channel := range tenant.NotificationChannels {
+			if channels[channel] == nil {
+				channels[channel] = make(map[string]bool)
+			}
+			channels[channel][name] = true
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Dispatcher{
+		cfg:        cfg,
+		client:     &http.Client{Timeout: cfg.Timeout},
+		channels:   channels,
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+		deliveries: make(chan delivery, cfg.BufferSize),
+		done:       make(chan struct{}),
+	}
+}
+
+// Notify queues event for every endpoint subscribed to its type, leaving out
+// the notification channels of other tenants. ID and Time are filled in when
+// unset.
+func (d *Dispatcher) Notify(event *Event) {
+	if d == nil {
+		return
+	}
+
+	if event.ID == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err == nil {
+			event.ID = hex.EncodeToString(buf)
+		}
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.logger.WithError(err).WithField("webhook_event", event.Type).Error("Failed to encode webhook event")
+		return
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for name, endpoint := range d.cfg.Endpoints {
+		if !subscribed(endpoint, event.Type) {
+			continue
+		}
+		if tenants, ok := d.channels[name]; ok && !tenants[event.Tenant] {
+			continue
+		}
+
+		dl := delivery{endpoint: name, cfg: endpoint, event: event, body: body}
+		if d.closed {
+			d.deadLetter(dl, 0, fmt.Errorf("webhooks are shutting down"))
+			continue
+		}
+		select {
+		case d.deliveries <- dl:
+		default:
+			dropped := d.dropped.Add(1)
+			d.logger.WithField("dropped_total", dropped).Warn("Webhook buffer full, dropping delivery")
+			d.deadLetter(dl, 0, fmt.Errorf("webhook buffer full"))
+		}
+	}
+}
+
+// Run delivers queued events until Close is called and every delivery has
+// succeeded or been given up.
+func (d *Dispatcher) Run() {
+	if d == nil {
+		return
+	}
+	defer close(d.done)
+
+	// A fixed pool of workers bounds the deliveries in flight. Each retries
+	// its delivery on its own, so one failing endpoint only holds up the
+	// others once it occupies every worker
+	var wg sync.WaitGroup
+	for i := 0; i < d.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for dl := range d.deliveries {
+				d.deliver(dl)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Dropped returns how many deliveries have been dropped because the buffer
+// was full.
+func (d *Dispatcher) Dropped() uint64 {
+	if d == nil {
+		return 0
+	}
+	return d.dropped.Load()
+}
+
+// Close stops accepting events and waits for pending deliveries, including
+// their retries. When ctx ends first, pending retries are abandoned and
+// logged as dead letters.
+func (d *Dispatcher) Close(ctx context.Context) error {
+	if d == nil {
+		return nil
+	}
+
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.deliveries)
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.done:
+	case <-ctx.Done():
+		d.cancel()
+		<-d.done
+	}
+	d.cancel()
+	d.client.CloseIdleConnections()
+	return nil
+}
+
+func (d *Dispatcher) deliver(dl delivery) {
+	backoff := d.cfg.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		retry, err := d.post(dl)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= d.cfg.MaxAttempts {
+			d.deadLetter(dl, attempt, err)
+			return
+		}
+
+		d.logger.WithError(err).WithFields(logrus.Fields{
+			"webhook":       dl.endpoint,
+			"webhook_event": dl.event.Type,
+			"delivery_id":   dl.event.ID,
+			"attempt":       attempt,
+			"retry_in":      backoff.String(),
+		}).Warn("Webhook delivery failed, retrying")
+
+		select {
+		case <-time.After(backoff):
+		case <-d.ctx.Done():
+			d.deadLetter(dl, attempt, fmt.Errorf("retries abandoned on shutdown: %w", err))
+			return
+		}
+
+		backoff *= 2
+		if backoff > d.cfg.MaxBackoff {
+			backoff = d.cfg.MaxBackoff
+		}
+	}
+}
+
+// post makes one delivery attempt. Network errors, 408, 429 and 5xx
+// responses are worth retrying; other failures are not.
+func (d *Dispatcher) post(dl delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(d.ctx, http.MethodPost, dl.cfg.URL, bytes.NewReader(dl.body))
+	if err != nil {
+		return false, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, dl.event.Type)
+	req.Header.Set(DeliveryHeader, dl.event.ID)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(dl.cfg.Secret, timestamp, dl.body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		retry := resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retry, fmt.Errorf("webhook failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return false, nil
+}
+
+// deadLetter logs a delivery that will not be attempted again, with its
+// payload.
+func (d *Dispatcher) deadLetter(dl delivery, attempts int, err error) {
+	d.logger.WithError(err).WithFields(logrus.Fields{
+		"webhook":       dl.endpoint,
+		"webhook_event": dl.event.Type,
+		"delivery_id":   dl.event.ID,
+		"attempts":      attempts,
+		"payload":       string(dl.body),
+	}).Error("Webhook delivery failed, giving up")
+}
+
+// Sign returns the signature header value of body sent at timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func subscribed(endpoint config.WebhookEndpointConfig, eventType string) bool {
+	if len(endpoint.Events) == 0 {
+		return true
+	}
+	for _, candidate := range endpoint.Events {
+		if candidate == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,173 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/kalpesh172000/hcvapi/config"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		body      string
+		want      string
+	}{
+		{
+			name:      "event",
+			secret:    "whsec",
+			timestamp: "1700000000",
+			body:      `{"type":"key.issued"}`,
+			want:      "sha256=9b9ffb6bbeedeb8180fa7362588cf25db46c9f25ecf713bb4c35bce737b5ba43",
+		},
+		{
+			name:      "empty secret and body",
+			timestamp: "0",
+			want:      "sha256=b849d5a581847b281957065739df36df2463d1977ea8d6e1e4e6cf33fadc68c3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// receiver records the deliveries an endpoint receives.
+type receiver struct {
+	secret string
+
+	mu       sync.Mutex
+	received []*Event
+	invalid  int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if req.Header.Get(SignatureHeader) != Sign(r.secret, req.Header.Get(TimestampHeader), body) {
+		r.invalid++
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var event Event
+	json.Unmarshal(body, &event)
+	r.received = append(r.received, &event)
+}
+
+func (r *receiver) tenants() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var tenants []string
+	for _, event := range r.received {
+		tenants = append(tenants, event.Tenant)
+	}
+	return tenants
+}
+
+func testConfig() config.WebhooksConfig {
+	return config.WebhooksConfig{
+		Endpoints:      map[string]config.WebhookEndpointConfig{},
+		MaxAttempts:    1,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Timeout:        time.Second,
+		BufferSize:     16,
+		Workers:        2,
+	}
+}
+
+func TestDispatcherDeliversSignedEventsToTenantChannels(t *testing.T) {
+	shared := &receiver{secret: "shared-secret"}
+	teamA := &receiver{secret: "team-a-secret"}
+	cfg := testConfig()
+	sharedServer := httptest.NewServer(shared)
+	t.Cleanup(sharedServer.Close)
+	teamAServer := httptest.NewServer(teamA)
+	t.Cleanup(teamAServer.Close)
+	cfg.Endpoints["siem"] = config.WebhookEndpointConfig{URL: sharedServer.URL, Secret: shared.secret}
+	cfg.Endpoints["team-a-hooks"] = config.WebhookEndpointConfig{URL: teamAServer.URL, Secret: teamA.secret}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	d := New(cfg, map[string]config.TenantConfig{
+		"team-a": {NotificationChannels: []string{"team-a-hooks"}},
+	}, logger)
+	go d.Run()
+
+	d.Notify(&Event{Type: EventKeyIssued, Tenant: "team-a"})
+	d.Notify(&Event{Type: EventKeyIssued, Tenant: "team-b"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	d.Close(ctx)
+
+	if shared.invalid+teamA.invalid > 0 {
+		t.Fatal("deliveries carried invalid signatures")
+	}
+	if got := shared.tenants(); len(got) != 2 {
+		t.Fatalf("shared endpoint got events of tenants %v, want team-a and team-b", got)
+	}
+	if got := teamA.tenants(); len(got) != 1 || got[0] != "team-a" {
+		t.Fatalf("team-a channel got events of tenants %v, want only team-a", got)
+	}
+}
+
+func TestDispatcherBoundsDeliveriesInFlight(t *testing.T) {
+	arrived := make(chan struct{}, 4)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		arrived <- struct{}{}
+		<-release
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := testConfig()
+	cfg.Workers = 1
+	cfg.BufferSize = 1
+	cfg.Endpoints["slow"] = config.WebhookEndpointConfig{URL: server.URL, Secret: "secret"}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	d := New(cfg, nil, logger)
+	go d.Run()
+
+	// The only worker is busy with the first delivery, the second is queued
+	// and the third finds the buffer full
+	d.Notify(&Event{Type: EventKeyIssued})
+	<-arrived
+	d.Notify(&Event{Type: EventKeyIssued})
+	d.Notify(&Event{Type: EventKeyIssued})
+
+	if got := d.Dropped(); got != 1 {
+		t.Fatalf("got %d dropped deliveries, want 1", got)
+	}
+	select {
+	case <-arrived:
+		t.Fatal("second delivery started while the only worker was busy")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	d.Close(ctx)
+
+	if got := len(arrived); got != 1 {
+		t.Fatalf("got %d more deliveries after release, want the queued one", got)
+	}
+}